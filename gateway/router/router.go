@@ -44,7 +44,7 @@ func NewRouter(cfg *config.Config, logger log.Logger) http.Handler {
 	r.Use(chiMiddleware.RealIP)
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
-	r.Use(chiMiddleware.AllowContentType("application/json"))
+	r.Use(chiMiddleware.AllowContentType("application/json", "text/csv"))
 
 	httpClient := client.NewClient(logger)
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret, logger)
@@ -99,12 +99,97 @@ func NewRouter(cfg *config.Config, logger log.Logger) http.Handler {
 
 			r.Route("/items", func(r chi.Router) {
 				r.Get("/", router.forwardToService("inventory", "/items"))
+				r.Get("/search", router.forwardToService("inventory", "/items/search"))
+				r.Get("/export", router.forwardToService("inventory", "/items/export"))
+				r.Post("/import", router.forwardToService("inventory", "/items/import"))
+				r.Get("/reorder-suggestions", router.forwardToService("inventory", "/items/reorder-suggestions"))
 				r.Get("/{id}", router.forwardToService("inventory", "/items/{id}"))
 				r.Post("/", router.forwardToService("inventory", "/items"))
 				r.Put("/{id}", router.forwardToService("inventory", "/items/{id}"))
 				r.Delete("/{id}", router.forwardToService("inventory", "/items/{id}"))
 				r.Get("/{item_id}/stock", router.forwardToService("inventory", "/items/{item_id}/stock"))
 				r.Put("/{item_id}/stock", router.forwardToService("inventory", "/items/{item_id}/stock"))
+				r.Get("/{item_id}/movements", router.forwardToService("inventory", "/items/{item_id}/movements"))
+				r.Get("/{item_id}/lots", router.forwardToService("inventory", "/items/{item_id}/lots"))
+				r.Get("/{item_id}/serials", router.forwardToService("inventory", "/items/{item_id}/serials"))
+				r.Get("/by-barcode/{code}", router.forwardToService("inventory", "/items/by-barcode/{code}"))
+				r.Get("/{item_id}/barcodes", router.forwardToService("inventory", "/items/{item_id}/barcodes"))
+				r.Post("/{item_id}/barcodes", router.forwardToService("inventory", "/items/{item_id}/barcodes"))
+				r.Delete("/{item_id}/barcodes/{code}", router.forwardToService("inventory", "/items/{item_id}/barcodes/{code}"))
+				r.Get("/{item_id}/barcodes/{code}/label", router.forwardToService("inventory", "/items/{item_id}/barcodes/{code}/label"))
+				r.Get("/{item_id}/prices", router.forwardToService("inventory", "/items/{item_id}/prices"))
+				r.Post("/{item_id}/prices", router.forwardToService("inventory", "/items/{item_id}/prices"))
+				r.Delete("/{item_id}/prices/{price_id}", router.forwardToService("inventory", "/items/{item_id}/prices/{price_id}"))
+				r.Get("/{item_id}/price", router.forwardToService("inventory", "/items/{item_id}/price"))
+				r.Get("/{item_id}/reservations", router.forwardToService("inventory", "/items/{item_id}/reservations"))
+				r.Get("/{item_id}/bom", router.forwardToService("inventory", "/items/{item_id}/bom"))
+				r.Put("/{item_id}/bom", router.forwardToService("inventory", "/items/{item_id}/bom"))
+				r.Delete("/{item_id}/bom", router.forwardToService("inventory", "/items/{item_id}/bom"))
+			})
+
+			r.Route("/categories", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/categories"))
+				r.Get("/{id}", router.forwardToService("inventory", "/categories/{id}"))
+				r.Post("/", router.forwardToService("inventory", "/categories"))
+				r.Put("/{id}", router.forwardToService("inventory", "/categories/{id}"))
+				r.Delete("/{id}", router.forwardToService("inventory", "/categories/{id}"))
+			})
+
+			r.Route("/products", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/products"))
+				r.Get("/{id}", router.forwardToService("inventory", "/products/{id}"))
+				r.Post("/", router.forwardToService("inventory", "/products"))
+				r.Put("/{id}", router.forwardToService("inventory", "/products/{id}"))
+			})
+
+			r.Get("/lots/expiring", router.forwardToService("inventory", "/lots/expiring"))
+			r.Get("/serials/{serial_number}", router.forwardToService("inventory", "/serials/{serial_number}"))
+			r.Get("/valuation", router.forwardToService("inventory", "/valuation"))
+			r.Get("/sales-orders/{order_id}/cogs", router.forwardToService("inventory", "/sales-orders/{order_id}/cogs"))
+
+			r.Route("/reservations", func(r chi.Router) {
+				r.Get("/{source_type}/{source_id}", router.forwardToService("inventory", "/reservations/{source_type}/{source_id}"))
+				r.Put("/{source_type}/{source_id}", router.forwardToService("inventory", "/reservations/{source_type}/{source_id}"))
+				r.Delete("/{source_type}/{source_id}", router.forwardToService("inventory", "/reservations/{source_type}/{source_id}"))
+			})
+
+			r.Route("/warehouses", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/warehouses"))
+				r.Get("/{id}", router.forwardToService("inventory", "/warehouses/{id}"))
+				r.Post("/", router.forwardToService("inventory", "/warehouses"))
+				r.Put("/{id}", router.forwardToService("inventory", "/warehouses/{id}"))
+				r.Get("/{id}/locations", router.forwardToService("inventory", "/warehouses/{id}/locations"))
+				r.Post("/{id}/locations", router.forwardToService("inventory", "/warehouses/{id}/locations"))
+			})
+
+			r.Route("/locations", func(r chi.Router) {
+				r.Put("/{id}", router.forwardToService("inventory", "/locations/{id}"))
+			})
+
+			r.Route("/counts", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/counts"))
+				r.Post("/", router.forwardToService("inventory", "/counts"))
+				r.Get("/{id}", router.forwardToService("inventory", "/counts/{id}"))
+				r.Put("/{id}/lines", router.forwardToService("inventory", "/counts/{id}/lines"))
+				r.Post("/{id}/post", router.forwardToService("inventory", "/counts/{id}/post"))
+				r.Post("/{id}/cancel", router.forwardToService("inventory", "/counts/{id}/cancel"))
+			})
+
+			r.Route("/transfers", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/transfers"))
+				r.Post("/", router.forwardToService("inventory", "/transfers"))
+				r.Get("/{id}", router.forwardToService("inventory", "/transfers/{id}"))
+				r.Post("/{id}/ship", router.forwardToService("inventory", "/transfers/{id}/ship"))
+				r.Post("/{id}/receive", router.forwardToService("inventory", "/transfers/{id}/receive"))
+				r.Post("/{id}/cancel", router.forwardToService("inventory", "/transfers/{id}/cancel"))
+			})
+
+			r.Route("/assembly-orders", func(r chi.Router) {
+				r.Get("/", router.forwardToService("inventory", "/assembly-orders"))
+				r.Post("/", router.forwardToService("inventory", "/assembly-orders"))
+				r.Get("/{id}", router.forwardToService("inventory", "/assembly-orders/{id}"))
+				r.Post("/{id}/complete", router.forwardToService("inventory", "/assembly-orders/{id}/complete"))
+				r.Post("/{id}/cancel", router.forwardToService("inventory", "/assembly-orders/{id}/cancel"))
 			})
 
 			r.Route("/sales/orders", func(r chi.Router) {
@@ -123,6 +208,24 @@ func NewRouter(cfg *config.Config, logger log.Logger) http.Handler {
 				r.Put("/{id}", router.forwardToService("purchase", "/orders/{id}"))
 				r.Post("/{id}/receive", router.forwardToService("purchase", "/orders/{id}/receive"))
 				r.Post("/{id}/pay", router.forwardToService("purchase", "/orders/{id}/pay"))
+				r.Post("/{id}/cancel", router.forwardToService("purchase", "/orders/{id}/cancel"))
+				r.Get("/{id}/charges", router.forwardToService("purchase", "/orders/{id}/charges"))
+				r.Post("/{id}/charges", router.forwardToService("purchase", "/orders/{id}/charges"))
+				r.Delete("/{id}/charges/{charge_id}", router.forwardToService("purchase", "/orders/{id}/charges/{charge_id}"))
+				r.Get("/{id}/returns", router.forwardToService("purchase", "/orders/{id}/returns"))
+				r.Post("/{id}/returns", router.forwardToService("purchase", "/orders/{id}/returns"))
+			})
+
+			r.Get("/purchase/debit-notes", router.forwardToService("purchase", "/debit-notes"))
+			r.Get("/purchase/vendors/{vendor_id}/balance", router.forwardToService("purchase", "/vendors/{vendor_id}/balance"))
+			r.Get("/purchase/vendors/{vendor_id}/performance", router.forwardToService("purchase", "/vendors/{vendor_id}/performance"))
+
+			r.Route("/purchase/catalog", func(r chi.Router) {
+				r.Get("/", router.forwardToService("purchase", "/catalog"))
+				r.Get("/{id}", router.forwardToService("purchase", "/catalog/{id}"))
+				r.Post("/", router.forwardToService("purchase", "/catalog"))
+				r.Put("/{id}", router.forwardToService("purchase", "/catalog/{id}"))
+				r.Delete("/{id}", router.forwardToService("purchase", "/catalog/{id}"))
 			})
 		})
 	})
@@ -178,6 +281,10 @@ func (rt *Router) forwardToService(serviceName string, path string) http.Handler
 			targetURL = targetURL[:start] + paramValue + targetURL[start+end+1:]
 		}
 
+		if r.URL.RawQuery != "" {
+			targetURL += "?" + r.URL.RawQuery
+		}
+
 		resp, err := rt.client.ForwardRequest(r.Context(), targetURL, r)
 		if err != nil {
 			rt.logger.Error(r.Context(), "failed to forward request", zap.String("service", serviceName), zap.String("url", targetURL), zap.Error(err))
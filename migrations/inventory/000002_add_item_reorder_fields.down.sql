@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_items_preferred_vendor_id;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS reorder_requested_at,
+    DROP COLUMN IF EXISTS preferred_vendor_id,
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point;
@@ -0,0 +1,7 @@
+ALTER TABLE items
+    ADD COLUMN reorder_point INTEGER NOT NULL DEFAULT 0 CHECK (reorder_point >= 0),
+    ADD COLUMN reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0),
+    ADD COLUMN preferred_vendor_id UUID,
+    ADD COLUMN reorder_requested_at TIMESTAMP;
+
+CREATE INDEX idx_items_preferred_vendor_id ON items(preferred_vendor_id);
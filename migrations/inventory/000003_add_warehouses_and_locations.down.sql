@@ -0,0 +1,20 @@
+-- Collapse per-location stock back into a single row per item
+CREATE TEMP TABLE stock_totals AS
+SELECT item_id, SUM(quantity)::integer AS quantity, MIN(created_at) AS created_at, MAX(updated_at) AS updated_at
+FROM stock
+GROUP BY item_id;
+
+DROP INDEX IF EXISTS idx_stock_location_id;
+ALTER TABLE stock DROP CONSTRAINT IF EXISTS stock_item_id_location_id_key;
+
+DELETE FROM stock;
+ALTER TABLE stock DROP COLUMN location_id;
+
+INSERT INTO stock (item_id, quantity, created_at, updated_at)
+SELECT item_id, quantity, created_at, updated_at FROM stock_totals;
+
+ALTER TABLE stock ADD CONSTRAINT stock_item_id_key UNIQUE(item_id);
+
+DROP TABLE stock_totals;
+DROP TABLE IF EXISTS locations;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,37 @@
+CREATE TABLE warehouses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(warehouse_id, code)
+);
+
+CREATE INDEX idx_locations_warehouse_id ON locations(warehouse_id);
+CREATE UNIQUE INDEX idx_locations_default ON locations(warehouse_id) WHERE is_default;
+
+-- Existing stock moves to the default location of the main warehouse
+INSERT INTO warehouses (id, code, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'MAIN', 'Main Warehouse');
+
+INSERT INTO locations (id, warehouse_id, code, name, is_default)
+VALUES ('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'DEFAULT', 'Default Location', TRUE);
+
+ALTER TABLE stock ADD COLUMN location_id UUID REFERENCES locations(id) ON DELETE RESTRICT;
+UPDATE stock SET location_id = '00000000-0000-0000-0000-000000000002';
+ALTER TABLE stock ALTER COLUMN location_id SET NOT NULL;
+
+ALTER TABLE stock DROP CONSTRAINT stock_item_id_key;
+ALTER TABLE stock ADD CONSTRAINT stock_item_id_location_id_key UNIQUE(item_id, location_id);
+
+CREATE INDEX idx_stock_location_id ON stock(location_id);
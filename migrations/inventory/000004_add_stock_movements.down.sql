@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS stock_movements_immutable ON stock_movements;
+DROP FUNCTION IF EXISTS prevent_stock_movement_update();
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,25 @@
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES locations(id),
+    quantity_delta INTEGER NOT NULL CHECK (quantity_delta <> 0),
+    balance_after INTEGER NOT NULL CHECK (balance_after >= 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sale', 'purchase', 'adjustment', 'count', 'return')),
+    source_document_id UUID,
+    user_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_item_id_created_at ON stock_movements(item_id, created_at);
+CREATE INDEX idx_stock_movements_source_document_id ON stock_movements(source_document_id);
+
+-- Movements are an audit trail: once written they may only disappear together with their item
+CREATE FUNCTION prevent_stock_movement_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock movements are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_immutable
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_update();
@@ -0,0 +1,4 @@
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS lot_id;
+
+DROP TABLE IF EXISTS stock_lots;
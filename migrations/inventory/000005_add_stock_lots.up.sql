@@ -0,0 +1,17 @@
+-- Lots break down the quantity held at a location; stock not covered by a lot is unlotted
+CREATE TABLE stock_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES locations(id),
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, location_id, lot_number)
+);
+
+CREATE INDEX idx_stock_lots_expiry_date ON stock_lots(expiry_date) WHERE quantity > 0;
+
+ALTER TABLE stock_movements
+    ADD COLUMN lot_id UUID REFERENCES stock_lots(id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_serials;
+
+ALTER TABLE items DROP COLUMN IF EXISTS serialized;
@@ -0,0 +1,19 @@
+ALTER TABLE items ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A serialized item has one row per unit; the in-stock serials at a location add up to its stock
+CREATE TABLE stock_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('in_stock', 'sold', 'returned', 'removed')),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    order_id UUID,
+    return_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, serial_number)
+);
+
+CREATE INDEX idx_stock_serials_serial_number ON stock_serials(serial_number);
+CREATE INDEX idx_stock_serials_item_id_status ON stock_serials(item_id, status);
+CREATE INDEX idx_stock_serials_order_id ON stock_serials(order_id);
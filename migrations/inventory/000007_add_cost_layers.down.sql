@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS cost_entries;
+DROP TABLE IF EXISTS cost_layers;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS costing_method;
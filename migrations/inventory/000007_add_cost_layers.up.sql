@@ -0,0 +1,42 @@
+ALTER TABLE items
+    ADD COLUMN costing_method VARCHAR(20) NOT NULL DEFAULT 'fifo' CHECK (costing_method IN ('fifo', 'average'));
+
+-- Every receipt opens a cost layer; issues draw the layers down oldest first
+CREATE TABLE cost_layers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining_quantity INTEGER NOT NULL CHECK (remaining_quantity >= 0 AND remaining_quantity <= quantity),
+    unit_cost NUMERIC(15, 4) NOT NULL CHECK (unit_cost >= 0),
+    source_document_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cost_layers_item_id_open ON cost_layers(item_id, created_at) WHERE remaining_quantity > 0;
+
+-- The cost ledger values every change to an item's stock; its running totals give the stock value at any date
+CREATE TABLE cost_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity_delta INTEGER NOT NULL,
+    value_delta NUMERIC(15, 4) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    source_document_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cost_entries_item_id_created_at ON cost_entries(item_id, created_at);
+CREATE INDEX idx_cost_entries_source_document_id ON cost_entries(source_document_id);
+
+-- Stock held before costing started is opened at zero cost
+INSERT INTO cost_layers (item_id, quantity, remaining_quantity, unit_cost)
+SELECT item_id, SUM(quantity), SUM(quantity), 0
+FROM stock
+GROUP BY item_id
+HAVING SUM(quantity) > 0;
+
+INSERT INTO cost_entries (item_id, quantity_delta, value_delta, reason)
+SELECT item_id, SUM(quantity), 0, 'adjustment'
+FROM stock
+GROUP BY item_id
+HAVING SUM(quantity) > 0;
@@ -0,0 +1,29 @@
+-- A count session snapshots the stock of a warehouse; counts recorded against it are posted as
+-- adjustments in one go
+CREATE TABLE stock_counts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'posted', 'cancelled')),
+    notes TEXT,
+    snapshot_at TIMESTAMP NOT NULL,
+    created_by VARCHAR(255),
+    posted_by VARCHAR(255),
+    posted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_counts_status ON stock_counts(status);
+
+CREATE TABLE stock_count_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    count_id UUID NOT NULL REFERENCES stock_counts(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES locations(id),
+    expected_quantity INTEGER NOT NULL CHECK (expected_quantity >= 0),
+    counted_quantity INTEGER CHECK (counted_quantity >= 0),
+    counted_by VARCHAR(255),
+    counted_at TIMESTAMP,
+    adjustment_quantity INTEGER,
+    UNIQUE (count_id, item_id, location_id)
+);
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS stock_transfer_serials;
+DROP TABLE IF EXISTS stock_transfer_lots;
+DROP TABLE IF EXISTS stock_transfer_lines;
+DROP TABLE IF EXISTS stock_transfers;
+
+UPDATE stock_serials SET status = 'in_stock' WHERE status = 'in_transit';
+
+ALTER TABLE stock_serials
+    DROP CONSTRAINT stock_serials_status_check;
+ALTER TABLE stock_serials
+    ADD CONSTRAINT stock_serials_status_check CHECK (status IN ('in_stock', 'sold', 'returned', 'removed'));
+
+DELETE FROM stock_movements WHERE reason = 'transfer';
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements
+    ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN ('sale', 'purchase', 'adjustment', 'count', 'return'));
@@ -0,0 +1,54 @@
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements
+    ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN ('sale', 'purchase', 'adjustment', 'count', 'return', 'transfer'));
+
+ALTER TABLE stock_serials
+    DROP CONSTRAINT stock_serials_status_check;
+ALTER TABLE stock_serials
+    ADD CONSTRAINT stock_serials_status_check CHECK (status IN ('in_stock', 'in_transit', 'sold', 'returned', 'removed'));
+
+-- Stock shipped on a transfer leaves the source location and is held on the transfer until it is
+-- received at the destination
+CREATE TABLE stock_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_location_id UUID NOT NULL REFERENCES locations(id),
+    destination_location_id UUID NOT NULL REFERENCES locations(id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('draft', 'in_transit', 'received', 'cancelled')),
+    notes TEXT,
+    created_by VARCHAR(255),
+    shipped_at TIMESTAMP,
+    received_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (source_location_id <> destination_location_id)
+);
+
+CREATE INDEX idx_stock_transfers_status ON stock_transfers(status);
+
+CREATE TABLE stock_transfer_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_id UUID NOT NULL REFERENCES stock_transfers(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (transfer_id, item_id)
+);
+
+CREATE INDEX idx_stock_transfer_lines_item_id ON stock_transfer_lines(item_id);
+
+-- The lots a line drew from the source, so they arrive under the same lot numbers
+CREATE TABLE stock_transfer_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    line_id UUID NOT NULL REFERENCES stock_transfer_lines(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE TABLE stock_transfer_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    line_id UUID NOT NULL REFERENCES stock_transfer_lines(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    UNIQUE (line_id, serial_number)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS item_units;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS base_unit;
@@ -0,0 +1,12 @@
+-- Stock is kept in the item's base unit
+ALTER TABLE items
+    ADD COLUMN base_unit VARCHAR(20) NOT NULL DEFAULT 'each';
+
+-- Other units an item is bought and sold in, each worth a whole number of base units
+CREATE TABLE item_units (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    unit VARCHAR(20) NOT NULL,
+    conversion_factor INTEGER NOT NULL CHECK (conversion_factor > 0),
+    UNIQUE (item_id, unit)
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS item_attribute_values;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS category_attributes;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,40 @@
+-- Category tree items are filed under; a category without a parent is a root
+CREATE TABLE categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parent_id UUID REFERENCES categories(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (parent_id <> id)
+);
+
+-- Sibling categories have distinct names
+CREATE UNIQUE INDEX idx_categories_parent_name ON categories(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(name));
+
+-- Custom attributes of the items in a category and its descendants. Enum attributes take one of
+-- their options.
+CREATE TABLE category_attributes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'boolean', 'enum')),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, name)
+);
+
+ALTER TABLE items
+    ADD COLUMN category_id UUID REFERENCES categories(id);
+
+CREATE INDEX idx_items_category_id ON items(category_id);
+
+-- Attribute values of an item, stored as JSON so they keep their type
+CREATE TABLE item_attribute_values (
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    attribute_id UUID NOT NULL REFERENCES category_attributes(id) ON DELETE CASCADE,
+    value JSONB NOT NULL,
+    PRIMARY KEY (item_id, attribute_id)
+);
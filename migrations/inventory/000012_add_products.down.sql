@@ -0,0 +1,6 @@
+ALTER TABLE items
+    DROP COLUMN IF EXISTS variant_options,
+    DROP COLUMN IF EXISTS product_id;
+
+DROP TABLE IF EXISTS product_axes;
+DROP TABLE IF EXISTS products;
@@ -0,0 +1,27 @@
+-- Parent products group variant items, one per combination of the values of its axes
+CREATE TABLE products (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sku VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Variant axes of a product, such as size or colour, in display order
+CREATE TABLE product_axes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INTEGER NOT NULL,
+    axis_values TEXT[] NOT NULL,
+    UNIQUE (product_id, name)
+);
+
+-- Variant items reference their product and carry their value on each axis
+ALTER TABLE items
+    ADD COLUMN product_id UUID REFERENCES products(id),
+    ADD COLUMN variant_options JSONB NOT NULL DEFAULT '{}';
+
+CREATE UNIQUE INDEX idx_items_product_variant ON items(product_id, variant_options) WHERE product_id IS NOT NULL;
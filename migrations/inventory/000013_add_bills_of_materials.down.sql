@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS assembly_order_lines;
+DROP TABLE IF EXISTS assembly_orders;
+DROP TABLE IF EXISTS bom_components;
+DROP TABLE IF EXISTS bills_of_materials;
+
+DELETE FROM stock_movements WHERE reason = 'assembly';
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements
+    ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN ('sale', 'purchase', 'adjustment', 'count', 'return', 'transfer'));
@@ -0,0 +1,50 @@
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements
+    ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN ('sale', 'purchase', 'adjustment', 'count', 'return', 'transfer', 'assembly'));
+
+-- A bill of materials lists the components one unit of an item is made of. A kit is never
+-- stocked itself; selling it issues its components instead.
+CREATE TABLE bills_of_materials (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    kit BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bom_components (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES bills_of_materials(item_id) ON DELETE CASCADE,
+    component_item_id UUID NOT NULL REFERENCES items(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (item_id, component_item_id),
+    CHECK (item_id <> component_item_id)
+);
+
+CREATE INDEX idx_bom_components_component_item_id ON bom_components(component_item_id);
+
+-- Completing an assembly order issues its components from the warehouse and receives the
+-- finished items into its default location
+CREATE TABLE assembly_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('draft', 'completed', 'cancelled')),
+    notes TEXT,
+    created_by VARCHAR(255),
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_assembly_orders_status ON assembly_orders(status);
+
+-- The components an order consumes, taken from the bill of materials when the order is drafted
+CREATE TABLE assembly_order_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    assembly_order_id UUID NOT NULL REFERENCES assembly_orders(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (assembly_order_id, item_id)
+);
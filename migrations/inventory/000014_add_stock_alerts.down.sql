@@ -0,0 +1,3 @@
+ALTER TABLE items
+    DROP COLUMN IF EXISTS stock_alert,
+    DROP COLUMN IF EXISTS min_stock;
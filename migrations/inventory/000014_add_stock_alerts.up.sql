@@ -0,0 +1,5 @@
+-- stock_alert is the last low or out-of-stock alert sent for the item; it is cleared once stock
+-- recovers above min_stock so the alert can be sent again
+ALTER TABLE items
+    ADD COLUMN min_stock INTEGER NOT NULL DEFAULT 0 CHECK (min_stock >= 0),
+    ADD COLUMN stock_alert VARCHAR(20) CHECK (stock_alert IN ('low', 'depleted'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_items_sku_trgm;
+DROP INDEX IF EXISTS idx_items_search;
@@ -0,0 +1,12 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full-text search over SKU, name and description. SearchItems must use the same expression for
+-- the index to be used.
+CREATE INDEX idx_items_search ON items USING GIN ((
+    setweight(to_tsvector('english', sku), 'A') ||
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+));
+
+-- Fuzzy and partial SKU matching
+CREATE INDEX idx_items_sku_trgm ON items USING GIN (sku gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS item_barcodes;
+DROP SEQUENCE IF EXISTS item_barcode_numbers;
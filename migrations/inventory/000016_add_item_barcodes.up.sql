@@ -0,0 +1,15 @@
+-- Numbers for internally generated barcodes
+CREATE SEQUENCE item_barcode_numbers;
+
+-- Barcodes of an item, stored as 13-digit GTINs. A barcode on a pack identifies pack_quantity
+-- units of the item's base unit.
+CREATE TABLE item_barcodes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    code VARCHAR(13) UNIQUE NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('ean13', 'upca', 'internal')),
+    pack_quantity INTEGER NOT NULL DEFAULT 1 CHECK (pack_quantity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_item_barcodes_item_id ON item_barcodes(item_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_items_active;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS archived_at;
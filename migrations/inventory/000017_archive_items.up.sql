@@ -0,0 +1,6 @@
+-- Items are archived rather than deleted, so orders and movements that refer to them can still
+-- look them up. Archived items are left out of lists and cannot be put on new orders.
+ALTER TABLE items
+    ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX idx_items_active ON items(created_at DESC) WHERE archived_at IS NULL;
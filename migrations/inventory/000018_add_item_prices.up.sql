@@ -0,0 +1,18 @@
+-- Price history of an item. Each row is the item's unit price from effective_from until the next
+-- row's; rows effective in the future are scheduled price changes. items.unit_price holds the
+-- price currently in effect.
+CREATE TABLE item_prices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, effective_from)
+);
+
+CREATE INDEX idx_item_prices_effective_from ON item_prices(effective_from);
+
+-- Existing items start their history with their current price
+INSERT INTO item_prices (item_id, unit_price, effective_from, created_at)
+SELECT id, unit_price, created_at, CURRENT_TIMESTAMP
+FROM items;
@@ -0,0 +1,17 @@
+-- Stock held for a source document, such as a draft sales order, that has not yet been issued.
+-- Reserved stock is still on hand but is not available to sell. A reservation past its
+-- expires_at no longer counts and is purged.
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    source_type VARCHAR(50) NOT NULL,
+    source_id UUID NOT NULL,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (source_type, source_id, item_id, warehouse_id)
+);
+
+CREATE INDEX idx_stock_reservations_item_id ON stock_reservations(item_id);
+CREATE INDEX idx_stock_reservations_expires_at ON stock_reservations(expires_at);
@@ -0,0 +1,10 @@
+-- Stock changes already applied for an event, keyed by the event type, the document the event
+-- is about and the item. A row is written in the same transaction as the stock change, so a
+-- redelivered or republished event is applied only once.
+CREATE TABLE processed_events (
+    event_type VARCHAR(100) NOT NULL,
+    source_id UUID NOT NULL,
+    item_id UUID NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_type, source_id, item_id)
+);
@@ -0,0 +1,9 @@
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_lot_id_fkey,
+    ADD CONSTRAINT stock_movements_lot_id_fkey FOREIGN KEY (lot_id) REFERENCES stock_lots(id) ON DELETE CASCADE;
+
+DROP TRIGGER IF EXISTS stock_movements_immutable ON stock_movements;
+
+CREATE TRIGGER stock_movements_immutable
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_update();
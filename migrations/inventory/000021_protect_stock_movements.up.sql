@@ -0,0 +1,11 @@
+-- Movements must not be deleted either, and a lot that movements refer to must not take them
+-- with it. Items are archived rather than deleted, so the item cascade never removes movements.
+DROP TRIGGER IF EXISTS stock_movements_immutable ON stock_movements;
+
+CREATE TRIGGER stock_movements_immutable
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_update();
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_lot_id_fkey,
+    ADD CONSTRAINT stock_movements_lot_id_fkey FOREIGN KEY (lot_id) REFERENCES stock_lots(id) ON DELETE RESTRICT;
@@ -0,0 +1,15 @@
+CREATE TABLE vendor_catalog_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    vendor_id UUID NOT NULL,
+    item_id UUID NOT NULL,
+    vendor_sku VARCHAR(100),
+    cost DECIMAL(10, 2) NOT NULL CHECK (cost >= 0),
+    min_order_quantity INTEGER NOT NULL DEFAULT 1 CHECK (min_order_quantity >= 1),
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(vendor_id, item_id)
+);
+
+CREATE INDEX idx_vendor_catalog_items_vendor_id ON vendor_catalog_items(vendor_id);
+CREATE INDEX idx_vendor_catalog_items_item_id ON vendor_catalog_items(item_id);
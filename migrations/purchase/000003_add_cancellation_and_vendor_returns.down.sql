@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS debit_notes;
+DROP TABLE IF EXISTS purchase_return_items;
+DROP TABLE IF EXISTS purchase_returns;
+
+UPDATE purchase_orders SET status = 'Draft' WHERE status = 'Cancelled';
+
+ALTER TABLE purchase_orders DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+ALTER TABLE purchase_orders
+    ADD CONSTRAINT purchase_orders_status_check CHECK (status IN ('Draft', 'Received', 'Paid'));
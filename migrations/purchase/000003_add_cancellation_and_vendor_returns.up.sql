@@ -0,0 +1,41 @@
+ALTER TABLE purchase_orders DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+ALTER TABLE purchase_orders
+    ADD CONSTRAINT purchase_orders_status_check CHECK (status IN ('Draft', 'Received', 'Paid', 'Cancelled'));
+
+CREATE TABLE purchase_returns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    vendor_id UUID NOT NULL,
+    reason TEXT,
+    total_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_purchase_returns_order_id ON purchase_returns(order_id);
+CREATE INDEX idx_purchase_returns_vendor_id ON purchase_returns(vendor_id);
+
+CREATE TABLE purchase_return_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    return_id UUID NOT NULL REFERENCES purchase_returns(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    subtotal DECIMAL(10, 2) NOT NULL CHECK (subtotal >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_purchase_return_items_return_id ON purchase_return_items(return_id);
+
+CREATE TABLE debit_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    vendor_id UUID NOT NULL,
+    order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    return_id UUID NOT NULL UNIQUE REFERENCES purchase_returns(id),
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'Open' CHECK (status IN ('Open', 'Applied')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_debit_notes_vendor_id ON debit_notes(vendor_id);
+CREATE INDEX idx_debit_notes_status ON debit_notes(status);
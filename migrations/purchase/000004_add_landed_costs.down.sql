@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS purchase_order_charges;
+
+ALTER TABLE purchase_order_items
+    DROP COLUMN IF EXISTS landed_unit_cost,
+    DROP COLUMN IF EXISTS unit_weight;
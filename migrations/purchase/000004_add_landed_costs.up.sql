@@ -0,0 +1,16 @@
+ALTER TABLE purchase_order_items
+    ADD COLUMN unit_weight DECIMAL(10, 3) NOT NULL DEFAULT 0 CHECK (unit_weight >= 0),
+    ADD COLUMN landed_unit_cost DECIMAL(12, 4) CHECK (landed_unit_cost >= 0);
+
+CREATE TABLE purchase_order_charges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    charge_type VARCHAR(20) NOT NULL CHECK (charge_type IN ('Freight', 'Duty', 'Insurance', 'Other')),
+    description TEXT,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    allocation_method VARCHAR(20) NOT NULL DEFAULT 'Value' CHECK (allocation_method IN ('Value', 'Quantity', 'Weight')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_purchase_order_charges_order_id ON purchase_order_charges(order_id);
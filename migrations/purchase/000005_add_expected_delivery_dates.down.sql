@@ -0,0 +1,9 @@
+ALTER TABLE purchase_order_items
+    DROP COLUMN IF EXISTS expected_delivery_date;
+
+DROP INDEX IF EXISTS idx_purchase_orders_expected_delivery_date;
+
+ALTER TABLE purchase_orders
+    DROP COLUMN IF EXISTS overdue_notified_at,
+    DROP COLUMN IF EXISTS received_at,
+    DROP COLUMN IF EXISTS expected_delivery_date;
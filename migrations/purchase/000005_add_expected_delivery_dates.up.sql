@@ -0,0 +1,12 @@
+ALTER TABLE purchase_orders
+    ADD COLUMN expected_delivery_date DATE,
+    ADD COLUMN received_at TIMESTAMP,
+    ADD COLUMN overdue_notified_at TIMESTAMP;
+
+-- Orders received before this migration have no receipt date; use their last update as the best estimate
+UPDATE purchase_orders SET received_at = updated_at WHERE status IN ('Received', 'Paid');
+
+CREATE INDEX idx_purchase_orders_expected_delivery_date ON purchase_orders(expected_delivery_date);
+
+ALTER TABLE purchase_order_items
+    ADD COLUMN expected_delivery_date DATE;
@@ -0,0 +1,3 @@
+-- Warehouse the order is received into; NULL means the service's configured default warehouse
+ALTER TABLE purchase_orders
+    ADD COLUMN warehouse_id UUID;
@@ -0,0 +1,12 @@
+CREATE TABLE purchase_order_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_purchase_order_lots_order_id ON purchase_order_lots(order_id);
+CREATE INDEX idx_purchase_order_lots_item_id ON purchase_order_lots(item_id);
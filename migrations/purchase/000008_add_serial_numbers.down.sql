@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS purchase_return_serials;
+DROP TABLE IF EXISTS purchase_order_serials;
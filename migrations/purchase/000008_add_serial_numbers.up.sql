@@ -0,0 +1,19 @@
+CREATE TABLE purchase_order_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (order_id, item_id, serial_number)
+);
+
+CREATE INDEX idx_purchase_order_serials_serial_number ON purchase_order_serials(serial_number);
+
+CREATE TABLE purchase_return_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    return_id UUID NOT NULL REFERENCES purchase_returns(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (return_id, item_id, serial_number)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE purchase_order_items
+    DROP COLUMN IF EXISTS base_quantity,
+    DROP COLUMN IF EXISTS conversion_factor,
+    DROP COLUMN IF EXISTS unit;
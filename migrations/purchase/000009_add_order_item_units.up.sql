@@ -0,0 +1,11 @@
+-- Unit of measure an order line was entered in. quantity and unit_price are per entered unit;
+-- base_quantity is the quantity in the item's base unit, the unit stock is kept in.
+ALTER TABLE purchase_order_items
+    ADD COLUMN unit VARCHAR(20) NOT NULL DEFAULT 'each',
+    ADD COLUMN conversion_factor INTEGER NOT NULL DEFAULT 1 CHECK (conversion_factor > 0),
+    ADD COLUMN base_quantity INTEGER;
+
+UPDATE purchase_order_items SET base_quantity = quantity;
+
+ALTER TABLE purchase_order_items
+    ALTER COLUMN base_quantity SET NOT NULL;
@@ -0,0 +1,3 @@
+-- Warehouse the order ships from; NULL means the service's configured default warehouse
+ALTER TABLE sales_orders
+    ADD COLUMN warehouse_id UUID;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sales_order_serials;
@@ -0,0 +1,11 @@
+-- Serial numbers picked for the serialized items an order ships
+CREATE TABLE sales_order_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES sales_orders(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (order_id, item_id, serial_number)
+);
+
+CREATE INDEX idx_sales_order_serials_serial_number ON sales_order_serials(serial_number);
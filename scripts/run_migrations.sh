@@ -24,28 +24,55 @@ services=("auth" "contact" "inventory" "sales" "purchase")
 ports=(5432 5433 5434 5435 5436)
 dbs=("auth" "contact" "inventory" "sales" "purchase")
 
+# run_psql feeds stdin to psql for the given service, stopping on the first
+# error. It uses docker exec when the database container is running and falls
+# back to a direct psql connection otherwise.
+run_psql() {
+    local service=$1 port=$2 db=$3
+    shift 3
+    if docker ps 2> /dev/null | grep -q "db-$service"; then
+        docker exec -i db-$service psql -U $DB_USER -d $db -v ON_ERROR_STOP=1 -q "$@"
+    elif command -v psql > /dev/null; then
+        PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $port -U $DB_USER -d $db -v ON_ERROR_STOP=1 -q "$@"
+    else
+        echo "      Cannot run migrations for $service - psql not available and container not running" >&2
+        return 1
+    fi
+}
+
 for i in "${!services[@]}"; do
     service=${services[$i]}
     port=${ports[$i]}
     db=${dbs[$i]}
-    
+
     echo "  Running migrations for $service service (database: $db)..."
-    
-    # Use docker exec to run migrations if database is in container
-    if docker ps | grep -q "db-$service"; then
-        docker exec -i db-$service psql -U $DB_USER -d $db <<EOF > /dev/null 2>&1 || true
-$(cat migrations/$service/000001_*.up.sql)
+
+    # Applied versions are tracked in the same table package/migration uses
+    run_psql $service $port $db <<EOF
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
 EOF
-        echo "     $service migrations applied"
-    else
-        # Fallback to direct psql if available
-        if command -v psql > /dev/null; then
-            PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $port -U $DB_USER -d $db -f migrations/$service/000001_*.up.sql > /dev/null 2>&1 || echo "    ⚠️  $service migration may already exist"
-        else
-            echo "      Cannot run migrations for $service - psql not available and container not running"
+    applied=$(echo "SELECT version FROM schema_migrations ORDER BY version;" | run_psql $service $port $db -t -A)
+
+    # Apply every pending up migration in version order. Each file runs in
+    # one transaction together with the insert that records its version, so
+    # a failing migration leaves nothing behind and is retried next time.
+    for migration in $(ls migrations/$service/*.up.sql | sort); do
+        name=$(basename $migration)
+        version=$((10#${name%%_*}))
+        if grep -qx "$version" <<< "$applied"; then
+            continue
         fi
-    fi
+
+        if ! { cat $migration; echo; echo "INSERT INTO schema_migrations (version) VALUES ($version);"; } \
+            | run_psql $service $port $db --single-transaction -f -; then
+            echo "     $name failed"
+            exit 1
+        fi
+        echo "     $name applied"
+    done
 done
 
 echo " All migrations completed"
-
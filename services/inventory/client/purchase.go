@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"microservice-challenge/package/client"
+	"microservice-challenge/services/purchase/model"
+	"net/url"
+)
+
+type PurchaseClient struct {
+	*client.BaseClient
+}
+
+func NewPurchaseClient(baseURL string) *PurchaseClient {
+	return &PurchaseClient{
+		BaseClient: client.NewBaseClient(baseURL),
+	}
+}
+
+// ListOpenOrdersByItemID lists up to limit draft purchase orders that include the item
+func (c *PurchaseClient) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit int, token string) ([]model.PurchaseOrder, error) {
+	var orders []model.PurchaseOrder
+	path := fmt.Sprintf("/orders?open_for_item=%s&limit=%d", url.QueryEscape(itemID), limit)
+	if err := c.Get(ctx, path, token, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"microservice-challenge/package/client"
+	"microservice-challenge/services/sales/model"
+	"net/url"
+)
+
+type SalesClient struct {
+	*client.BaseClient
+}
+
+func NewSalesClient(baseURL string) *SalesClient {
+	return &SalesClient{
+		BaseClient: client.NewBaseClient(baseURL),
+	}
+}
+
+// ListOpenOrdersByItemID lists up to limit draft sales orders that include the item
+func (c *SalesClient) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit int, token string) ([]model.SalesOrder, error) {
+	var orders []model.SalesOrder
+	path := fmt.Sprintf("/orders?open_for_item=%s&limit=%d", url.QueryEscape(itemID), limit)
+	if err := c.Get(ctx, path, token, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -22,7 +22,9 @@ import (
 	"microservice-challenge/package/database"
 	"microservice-challenge/package/log"
 	natsclient "microservice-challenge/package/nats"
+	"microservice-challenge/services/inventory/client"
 	"microservice-challenge/services/inventory/httphandler"
+	"microservice-challenge/services/inventory/model"
 	"microservice-challenge/services/inventory/router"
 	inventoryservice "microservice-challenge/services/inventory/service/inventory"
 	"microservice-challenge/services/inventory/storage/postgresql"
@@ -32,6 +34,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -93,9 +96,46 @@ func main() {
 
 	logger.Info(ctx, "connected to NATS")
 
+	salesServiceURL := os.Getenv("SALES_SERVICE_URL")
+	if salesServiceURL == "" {
+		salesServiceURL = cfg.Services.Sales.URL
+	}
+	purchaseServiceURL := os.Getenv("PURCHASE_SERVICE_URL")
+	if purchaseServiceURL == "" {
+		purchaseServiceURL = cfg.Services.Purchase.URL
+	}
+	authServiceURL := os.Getenv("AUTH_SERVICE_URL")
+	if authServiceURL == "" {
+		authServiceURL = cfg.Services.Auth.URL
+	}
+
+	salesClient := client.NewSalesClient(salesServiceURL)
+	purchaseClient := client.NewPurchaseClient(purchaseServiceURL)
+
+	serviceSecret := cfg.JWT.Secret + "_inventory"
+	authClient := client.NewAuthClient(authServiceURL, "inventory", serviceSecret)
+
 	storage := postgresql.NewStorage(db)
 
-	service := inventoryservice.NewService(storage, natsClient, logger)
+	defaultWarehouseID := model.DefaultWarehouseID
+	if value := os.Getenv("DEFAULT_WAREHOUSE_ID"); value != "" {
+		warehouseID, err := uuid.Parse(value)
+		if err != nil {
+			logger.Fatal(ctx, "invalid DEFAULT_WAREHOUSE_ID", zap.String("value", value))
+		}
+		defaultWarehouseID = warehouseID
+	}
+
+	reservationTTL := 7 * 24 * time.Hour
+	if value := os.Getenv("RESERVATION_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			logger.Fatal(ctx, "invalid RESERVATION_TTL", zap.String("value", value))
+		}
+		reservationTTL = ttl
+	}
+
+	service := inventoryservice.NewService(storage, natsClient, salesClient, purchaseClient, authClient, defaultWarehouseID, reservationTTL, logger)
 
 	if err := service.StartEventSubscriptions(ctx); err != nil {
 		logger.Fatal(ctx, "failed to start NATS subscriptions", zap.Error(err))
@@ -103,6 +143,45 @@ func main() {
 
 	logger.Info(ctx, "NATS event subscriptions started")
 
+	reorderCheckInterval := 15 * time.Minute
+	if value := os.Getenv("REORDER_CHECK_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Fatal(ctx, "invalid REORDER_CHECK_INTERVAL", zap.String("value", value))
+		}
+		reorderCheckInterval = interval
+	}
+
+	go service.StartReorderMonitor(ctx, reorderCheckInterval)
+
+	logger.Info(ctx, "reorder monitor started", zap.Duration("interval", reorderCheckInterval))
+
+	priceCheckInterval := time.Minute
+	if value := os.Getenv("PRICE_CHECK_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Fatal(ctx, "invalid PRICE_CHECK_INTERVAL", zap.String("value", value))
+		}
+		priceCheckInterval = interval
+	}
+
+	go service.StartPriceScheduler(ctx, priceCheckInterval)
+
+	logger.Info(ctx, "price scheduler started", zap.Duration("interval", priceCheckInterval))
+
+	reservationCheckInterval := 5 * time.Minute
+	if value := os.Getenv("RESERVATION_CHECK_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Fatal(ctx, "invalid RESERVATION_CHECK_INTERVAL", zap.String("value", value))
+		}
+		reservationCheckInterval = interval
+	}
+
+	go service.StartReservationExpiry(ctx, reservationCheckInterval)
+
+	logger.Info(ctx, "reservation expiry started", zap.Duration("interval", reservationCheckInterval))
+
 	handler := httphandler.NewHandler(service, logger)
 	r := router.NewRouter(handler, logger, cfg.JWT.Secret, db)
 
@@ -2,6 +2,7 @@ package httphandler
 
 import (
 	"encoding/json"
+	"math"
 	"microservice-challenge/package/errors"
 	"microservice-challenge/package/log"
 	"microservice-challenge/package/pagination"
@@ -9,6 +10,8 @@ import (
 	"microservice-challenge/services/inventory/model"
 	inventoryservice "microservice-challenge/services/inventory/service/inventory"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -16,6 +19,10 @@ import (
 
 const (
 	maxRequestBodySize = 1 << 20
+	maxImportBodySize  = 10 << 20
+
+	defaultExpiryWindowDays = 30
+	maxExpiryWindowDays     = 3650
 )
 
 type Handler struct {
@@ -51,7 +58,11 @@ func (h *Handler) ListItems(w http.ResponseWriter, r *http.Request) {
 
 	limit, offset := pagination.GetLimitOffset(r)
 
-	items, err := h.service.ListItems(ctx, limit, offset)
+	filter := model.ItemFilter{
+		CategoryID: r.URL.Query().Get("category_id"),
+	}
+
+	items, err := h.service.ListItems(ctx, filter, limit, offset)
 	if err != nil {
 		h.logger.Error(ctx, "failed to list items", zap.Error(err))
 		response.SendErrorResponse(w, err)
@@ -61,6 +72,123 @@ func (h *Handler) ListItems(w http.ResponseWriter, r *http.Request) {
 	response.SendSuccessResponse(w, http.StatusOK, "Items retrieved successfully", items, nil)
 }
 
+// SearchItems finds items matching the q query parameter, optionally narrowed by min_price,
+// max_price, in_stock and category_id
+func (h *Handler) SearchItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.ItemSearchFilter{
+		Query:      r.URL.Query().Get("q"),
+		CategoryID: r.URL.Query().Get("category_id"),
+	}
+
+	var err error
+	if filter.MinPrice, err = parsePriceQueryParam(r, "min_price"); err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	if filter.MaxPrice, err = parsePriceQueryParam(r, "max_price"); err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	if value := r.URL.Query().Get("in_stock"); value != "" {
+		inStock, err := strconv.ParseBool(value)
+		if err != nil {
+			response.SendErrorResponse(w, errors.ErrBadRequest)
+			return
+		}
+		filter.InStock = &inStock
+	}
+
+	results, err := h.service.SearchItems(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to search items", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Items retrieved successfully", results, nil)
+}
+
+// parsePriceQueryParam parses an optional non-negative price query parameter, returning nil when
+// it is absent
+func parsePriceQueryParam(r *http.Request, name string) (*float64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, errors.ErrBadRequest
+	}
+
+	return &price, nil
+}
+
+// ImportItems creates or updates items from a text/csv body. With dry_run=true the rows are only
+// checked.
+func (h *Handler) ImportItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.SendErrorResponse(w, errors.ErrBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBodySize)
+
+	result, err := h.service.ImportItems(ctx, r.Body, dryRun)
+	if err != nil {
+		h.logger.Error(ctx, "failed to import items", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	message := "Items imported successfully"
+	if dryRun {
+		message = "Items import checked successfully"
+	}
+	response.SendSuccessResponse(w, http.StatusOK, message, result, nil)
+}
+
+// ExportItems streams every item with its stock on hand as CSV
+func (h *Handler) ExportItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cw := &csvResponseWriter{ResponseWriter: w, filename: "items.csv"}
+	if err := h.service.ExportItems(ctx, cw); err != nil {
+		h.logger.Error(ctx, "failed to export items", zap.Error(err))
+		if !cw.started {
+			response.SendErrorResponse(w, err)
+		}
+	}
+}
+
+// csvResponseWriter sets the CSV download headers on the first write, so that an error before
+// anything is written can still be sent as JSON
+type csvResponseWriter struct {
+	http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (w *csvResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
 func (h *Handler) GetItem(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id := chi.URLParam(r, "id")
@@ -122,38 +250,1062 @@ func (h *Handler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.SendSuccessResponse(w, http.StatusOK, "Item deleted successfully", nil, nil)
+	response.SendSuccessResponse(w, http.StatusOK, "Item archived successfully", nil, nil)
 }
 
-func (h *Handler) GetStock(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.CategoryFilter{
+		ParentID: r.URL.Query().Get("parent_id"),
+	}
+
+	categories, err := h.service.ListCategories(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list categories", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Categories retrieved successfully", categories, nil)
+}
+
+func (h *Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	category, err := h.service.GetCategory(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get category", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Category retrieved successfully", category, nil)
+}
+
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateCategoryRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	category, err := h.service.CreateCategory(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create category", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Category created successfully", category, nil)
+}
+
+func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateCategoryRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	category, err := h.service.UpdateCategory(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to update category", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Category updated successfully", category, nil)
+}
+
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	if err := h.service.DeleteCategory(ctx, id); err != nil {
+		h.logger.Error(ctx, "failed to delete category", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Category deleted successfully", nil, nil)
+}
+
+func (h *Handler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	products, err := h.service.ListProducts(ctx, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list products", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Products retrieved successfully", products, nil)
+}
+
+func (h *Handler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	product, err := h.service.GetProduct(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get product", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Product retrieved successfully", product, nil)
+}
+
+func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateProductRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	product, err := h.service.CreateProduct(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create product", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Product created successfully", product, nil)
+}
+
+func (h *Handler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateProductRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	product, err := h.service.UpdateProduct(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to update product", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Product updated successfully", product, nil)
+}
+
+func (h *Handler) GetBillOfMaterials(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	itemID := chi.URLParam(r, "item_id")
 
-	stock, err := h.service.GetStockByItemID(ctx, itemID)
+	bom, err := h.service.GetBillOfMaterials(ctx, itemID)
 	if err != nil {
-		h.logger.Error(ctx, "failed to get stock", zap.Error(err))
+		h.logger.Error(ctx, "failed to get bill of materials", zap.Error(err))
 		response.SendErrorResponse(w, err)
 		return
 	}
 
-	response.SendSuccessResponse(w, http.StatusOK, "Stock retrieved successfully", stock, nil)
+	response.SendSuccessResponse(w, http.StatusOK, "Bill of materials retrieved successfully", bom, nil)
 }
 
-func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) SaveBillOfMaterials(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	itemID := chi.URLParam(r, "item_id")
 
-	var req model.AdjustStockRequest
+	var req model.UpdateBillOfMaterialsRequest
 	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
 		return
 	}
 
-	stock, err := h.service.AdjustStock(ctx, itemID, req.Quantity)
+	bom, err := h.service.SaveBillOfMaterials(ctx, itemID, req)
 	if err != nil {
-		h.logger.Error(ctx, "failed to adjust stock", zap.Error(err))
+		h.logger.Error(ctx, "failed to save bill of materials", zap.Error(err))
 		response.SendErrorResponse(w, err)
 		return
 	}
 
-	response.SendSuccessResponse(w, http.StatusOK, "Stock adjusted successfully", stock, nil)
+	response.SendSuccessResponse(w, http.StatusOK, "Bill of materials saved successfully", bom, nil)
+}
+
+func (h *Handler) DeleteBillOfMaterials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	if err := h.service.DeleteBillOfMaterials(ctx, itemID); err != nil {
+		h.logger.Error(ctx, "failed to delete bill of materials", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Bill of materials deleted successfully", nil, nil)
+}
+
+func (h *Handler) ListItemBarcodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	barcodes, err := h.service.ListItemBarcodes(ctx, itemID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list item barcodes", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Barcodes retrieved successfully", barcodes, nil)
+}
+
+func (h *Handler) CreateItemBarcode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	var req model.CreateItemBarcodeRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	barcode, err := h.service.CreateItemBarcode(ctx, itemID, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create item barcode", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Barcode created successfully", barcode, nil)
+}
+
+func (h *Handler) DeleteItemBarcode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+	code := chi.URLParam(r, "code")
+
+	if err := h.service.DeleteItemBarcode(ctx, itemID, code); err != nil {
+		h.logger.Error(ctx, "failed to delete item barcode", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Barcode deleted successfully", nil, nil)
+}
+
+func (h *Handler) ListItemPrices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	prices, err := h.service.ListItemPrices(ctx, itemID, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list item prices", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Item prices retrieved successfully", prices, nil)
+}
+
+// GetItemPrice returns the price in effect at the time in the optional at parameter, by default
+// now
+func (h *Handler) GetItemPrice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	at, err := parseTimeQueryParam(r, "at", false)
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	if at == nil {
+		now := time.Now()
+		at = &now
+	}
+
+	price, err := h.service.GetItemPriceAt(ctx, itemID, *at)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get item price", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Item price retrieved successfully", price, nil)
+}
+
+func (h *Handler) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	var req model.SchedulePriceChangeRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	price, err := h.service.SchedulePriceChange(ctx, itemID, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to schedule price change", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Price change scheduled successfully", price, nil)
+}
+
+func (h *Handler) CancelPriceChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+	priceID := chi.URLParam(r, "price_id")
+
+	if err := h.service.CancelPriceChange(ctx, itemID, priceID); err != nil {
+		h.logger.Error(ctx, "failed to cancel price change", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Price change cancelled successfully", nil, nil)
+}
+
+func (h *Handler) ListItemReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	reservations, err := h.service.ListItemReservations(ctx, itemID, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list item reservations", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Item reservations retrieved successfully", reservations, nil)
+}
+
+func (h *Handler) ListSourceReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sourceType := chi.URLParam(r, "source_type")
+	sourceID := chi.URLParam(r, "source_id")
+
+	reservations, err := h.service.ListSourceReservations(ctx, sourceType, sourceID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock reservations", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock reservations retrieved successfully", reservations, nil)
+}
+
+// ReserveStock replaces the reservations held for a source document
+func (h *Handler) ReserveStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sourceType := chi.URLParam(r, "source_type")
+	sourceID := chi.URLParam(r, "source_id")
+
+	var req model.ReserveStockRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	reservations, err := h.service.ReserveStock(ctx, sourceType, sourceID, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to reserve stock", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock reserved successfully", reservations, nil)
+}
+
+func (h *Handler) ReleaseReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sourceType := chi.URLParam(r, "source_type")
+	sourceID := chi.URLParam(r, "source_id")
+
+	if err := h.service.ReleaseReservations(ctx, sourceType, sourceID); err != nil {
+		h.logger.Error(ctx, "failed to release stock reservations", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock reservations released successfully", nil, nil)
+}
+
+// GetBarcodeLabel renders a printable label for one of an item's barcodes, as SVG by default or
+// as PNG with format=png
+func (h *Handler) GetBarcodeLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+	code := chi.URLParam(r, "code")
+
+	format := model.LabelFormat(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = model.LabelFormatSVG
+	case model.LabelFormatSVG, model.LabelFormatPNG:
+	default:
+		response.SendErrorResponse(w, errors.ErrBadRequest)
+		return
+	}
+
+	label, err := h.service.RenderBarcodeLabel(ctx, itemID, code, format)
+	if err != nil {
+		h.logger.Error(ctx, "failed to render barcode label", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	contentType := "image/svg+xml"
+	if format == model.LabelFormatPNG {
+		contentType = "image/png"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+code+"."+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(label)
+}
+
+// GetItemByBarcode finds the item a scanned EAN-13 or UPC-A barcode belongs to
+func (h *Handler) GetItemByBarcode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	code := chi.URLParam(r, "code")
+
+	lookup, err := h.service.GetItemByBarcode(ctx, code)
+	if err != nil {
+		h.logger.Error(ctx, "failed to look up barcode", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Item retrieved successfully", lookup, nil)
+}
+
+func (h *Handler) GetStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	stock, err := h.service.GetStockByItemID(ctx, itemID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get stock", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock retrieved successfully", stock, nil)
+}
+
+func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	var req model.AdjustStockRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	stock, err := h.service.AdjustStock(ctx, itemID, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to adjust stock", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock adjusted successfully", stock, nil)
+}
+
+// parseTimeQueryParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date query parameter,
+// returning nil when it is absent. A bare date used as an upper bound covers that whole day.
+func parseTimeQueryParam(r *http.Request, name string, upperBound bool) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+	if upperBound {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
+
+func (h *Handler) ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	from, err := parseTimeQueryParam(r, "from", false)
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	to, err := parseTimeQueryParam(r, "to", true)
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	filter := model.StockMovementFilter{
+		ItemID: itemID,
+		From:   from,
+		To:     to,
+	}
+
+	movements, err := h.service.ListStockMovements(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock movements", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock movements retrieved successfully", movements, nil)
+}
+
+func (h *Handler) ListStockLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	lots, err := h.service.ListStockLots(ctx, itemID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock lots", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock lots retrieved successfully", lots, nil)
+}
+
+func (h *Handler) ListExpiringLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := defaultExpiryWindowDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || parsed > maxExpiryWindowDays {
+			response.SendErrorResponse(w, errors.ErrBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	lots, err := h.service.ListExpiringLots(ctx, days)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list expiring lots", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Expiring lots retrieved successfully", lots, nil)
+}
+
+func (h *Handler) ListStockSerials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "item_id")
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.StockSerialFilter{
+		ItemID: itemID,
+		Status: model.StockSerialStatus(r.URL.Query().Get("status")),
+	}
+	switch filter.Status {
+	case "", model.StockSerialStatusInStock, model.StockSerialStatusInTransit, model.StockSerialStatusSold, model.StockSerialStatusReturned, model.StockSerialStatusRemoved:
+	default:
+		response.SendErrorResponse(w, errors.ErrBadRequest)
+		return
+	}
+
+	serials, err := h.service.ListStockSerials(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock serials", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock serials retrieved successfully", serials, nil)
+}
+
+func (h *Handler) LookupSerial(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serialNumber := chi.URLParam(r, "serial_number")
+
+	serials, err := h.service.LookupSerial(ctx, serialNumber)
+	if err != nil {
+		h.logger.Error(ctx, "failed to look up serial number", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Serial number retrieved successfully", serials, nil)
+}
+
+func (h *Handler) GetValuation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// A date values the stock at the end of that day
+	asOf, err := parseTimeQueryParam(r, "as_of", true)
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	before := time.Now()
+	if asOf != nil {
+		before = *asOf
+	}
+
+	report, err := h.service.GetValuation(ctx, before)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get inventory valuation", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Inventory valuation retrieved successfully", report, nil)
+}
+
+func (h *Handler) GetOrderCostOfGoodsSold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orderID := chi.URLParam(r, "order_id")
+
+	report, err := h.service.GetOrderCostOfGoodsSold(ctx, orderID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get cost of goods sold", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Cost of goods sold retrieved successfully", report, nil)
+}
+
+func (h *Handler) ListStockCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.StockCountFilter{
+		Status: model.StockCountStatus(r.URL.Query().Get("status")),
+	}
+	switch filter.Status {
+	case "", model.StockCountStatusOpen, model.StockCountStatusPosted, model.StockCountStatusCancelled:
+	default:
+		response.SendErrorResponse(w, errors.ErrBadRequest)
+		return
+	}
+
+	counts, err := h.service.ListStockCounts(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock counts", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock counts retrieved successfully", counts, nil)
+}
+
+func (h *Handler) GetStockCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	count, err := h.service.GetStockCount(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get stock count", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock count retrieved successfully", count, nil)
+}
+
+func (h *Handler) CreateStockCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateStockCountRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	count, err := h.service.CreateStockCount(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create stock count", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Stock count created successfully", count, nil)
+}
+
+func (h *Handler) RecordStockCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.RecordStockCountsRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	count, err := h.service.RecordStockCounts(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to record stock counts", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock counts recorded successfully", count, nil)
+}
+
+func (h *Handler) PostStockCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	count, err := h.service.PostStockCount(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to post stock count", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock count posted successfully", count, nil)
+}
+
+func (h *Handler) CancelStockCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	count, err := h.service.CancelStockCount(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to cancel stock count", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock count cancelled successfully", count, nil)
+}
+
+func (h *Handler) ListStockTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.StockTransferFilter{
+		Status: model.StockTransferStatus(r.URL.Query().Get("status")),
+	}
+	switch filter.Status {
+	case "", model.StockTransferStatusDraft, model.StockTransferStatusInTransit, model.StockTransferStatusReceived, model.StockTransferStatusCancelled:
+	default:
+		response.SendErrorResponse(w, errors.ErrBadRequest)
+		return
+	}
+
+	transfers, err := h.service.ListStockTransfers(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list stock transfers", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock transfers retrieved successfully", transfers, nil)
+}
+
+func (h *Handler) GetStockTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	transfer, err := h.service.GetStockTransfer(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get stock transfer", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock transfer retrieved successfully", transfer, nil)
+}
+
+func (h *Handler) CreateStockTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateStockTransferRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	transfer, err := h.service.CreateStockTransfer(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create stock transfer", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Stock transfer created successfully", transfer, nil)
+}
+
+func (h *Handler) ShipStockTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	transfer, err := h.service.ShipStockTransfer(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to ship stock transfer", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock transfer shipped successfully", transfer, nil)
+}
+
+func (h *Handler) ReceiveStockTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	transfer, err := h.service.ReceiveStockTransfer(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to receive stock transfer", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock transfer received successfully", transfer, nil)
+}
+
+func (h *Handler) CancelStockTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	transfer, err := h.service.CancelStockTransfer(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to cancel stock transfer", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Stock transfer cancelled successfully", transfer, nil)
+}
+
+func (h *Handler) ListAssemblyOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	filter := model.AssemblyOrderFilter{
+		Status: model.AssemblyOrderStatus(r.URL.Query().Get("status")),
+		ItemID: r.URL.Query().Get("item_id"),
+	}
+	switch filter.Status {
+	case "", model.AssemblyOrderStatusDraft, model.AssemblyOrderStatusCompleted, model.AssemblyOrderStatusCancelled:
+	default:
+		response.SendErrorResponse(w, errors.ErrBadRequest)
+		return
+	}
+
+	orders, err := h.service.ListAssemblyOrders(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list assembly orders", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Assembly orders retrieved successfully", orders, nil)
+}
+
+func (h *Handler) GetAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.GetAssemblyOrder(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get assembly order", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Assembly order retrieved successfully", order, nil)
+}
+
+func (h *Handler) CreateAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateAssemblyOrderRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	order, err := h.service.CreateAssemblyOrder(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create assembly order", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Assembly order created successfully", order, nil)
+}
+
+func (h *Handler) CompleteAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.CompleteAssemblyOrder(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to complete assembly order", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Assembly order completed successfully", order, nil)
+}
+
+func (h *Handler) CancelAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.CancelAssemblyOrder(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to cancel assembly order", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Assembly order cancelled successfully", order, nil)
+}
+
+func (h *Handler) ListReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	suggestions, err := h.service.ListReorderSuggestions(ctx)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list reorder suggestions", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Reorder suggestions retrieved successfully", suggestions, nil)
+}
+
+func (h *Handler) ListWarehouses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	warehouses, err := h.service.ListWarehouses(ctx, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list warehouses", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Warehouses retrieved successfully", warehouses, nil)
+}
+
+func (h *Handler) GetWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	warehouse, err := h.service.GetWarehouseByID(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get warehouse", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Warehouse retrieved successfully", warehouse, nil)
+}
+
+func (h *Handler) CreateWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateWarehouseRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	warehouse, err := h.service.CreateWarehouse(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create warehouse", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Warehouse created successfully", warehouse, nil)
+}
+
+func (h *Handler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateWarehouseRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	warehouse, err := h.service.UpdateWarehouse(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to update warehouse", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Warehouse updated successfully", warehouse, nil)
+}
+
+func (h *Handler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	warehouseID := chi.URLParam(r, "id")
+
+	locations, err := h.service.ListLocations(ctx, warehouseID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list locations", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Locations retrieved successfully", locations, nil)
+}
+
+func (h *Handler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	warehouseID := chi.URLParam(r, "id")
+
+	var req model.CreateLocationRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	location, err := h.service.CreateLocation(ctx, warehouseID, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create location", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Location created successfully", location, nil)
+}
+
+func (h *Handler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateLocationRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	location, err := h.service.UpdateLocation(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to update location", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Location updated successfully", location, nil)
 }
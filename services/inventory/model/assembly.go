@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssemblyOrderStatus string
+
+const (
+	AssemblyOrderStatusDraft     AssemblyOrderStatus = "draft"
+	AssemblyOrderStatusCompleted AssemblyOrderStatus = "completed"
+	AssemblyOrderStatusCancelled AssemblyOrderStatus = "cancelled"
+)
+
+func (s AssemblyOrderStatus) String() string {
+	return string(s)
+}
+
+// AssemblyOrder builds a quantity of an item from the components on its bill of materials.
+// Completing it issues the components from the warehouse and receives the finished items into
+// the warehouse's default location.
+type AssemblyOrder struct {
+	ID          uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440100"`
+	ItemID      uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU         string    `json:"sku" example:"SKU-001"`
+	Name        string    `json:"name" example:"Laptop Computer"`
+	WarehouseID uuid.UUID `json:"warehouse_id" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	Quantity    int       `json:"quantity" db:"quantity" example:"5"`
+
+	Status    AssemblyOrderStatus `json:"status" db:"status" example:"completed"`
+	Notes     string              `json:"notes,omitempty" db:"notes" example:"Build for the holiday promotion"`
+	CreatedBy string              `json:"created_by,omitempty" db:"created_by" example:"550e8400-e29b-41d4-a716-446655440030"`
+
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at" example:"2025-12-01T15:30:00Z"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-12-01T08:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-12-01T15:30:00Z"`
+}
+
+// AssemblyOrderLine is the total quantity of a component an assembly order consumes, taken from
+// the bill of materials when the order was drafted
+type AssemblyOrderLine struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440101"`
+	ItemID   uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	SKU      string    `json:"sku" example:"SKU-002"`
+	Name     string    `json:"name" example:"Laptop Battery"`
+	Quantity int       `json:"quantity" db:"quantity" example:"10"`
+}
+
+type AssemblyOrderWithLines struct {
+	AssemblyOrder
+	Lines []AssemblyOrderLine `json:"lines"`
+}
+
+// AssemblyOrderFilter narrows the assembly orders listed. ItemID matches orders that build the
+// item or consume it as a component.
+type AssemblyOrderFilter struct {
+	Status AssemblyOrderStatus
+	ItemID string
+}
+
+type CreateAssemblyOrderRequest struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Quantity int       `json:"quantity" example:"5"`
+
+	// WarehouseID is where the components are taken from and the items built; the default
+	// warehouse when left out
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Notes       string     `json:"notes,omitempty" example:"Build for the holiday promotion"`
+}
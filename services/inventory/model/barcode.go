@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BarcodeType string
+
+const (
+	BarcodeTypeEAN13    BarcodeType = "ean13"
+	BarcodeTypeUPCA     BarcodeType = "upca"
+	BarcodeTypeInternal BarcodeType = "internal"
+)
+
+func (t BarcodeType) String() string {
+	return string(t)
+}
+
+// ItemBarcode is a barcode that identifies an item. Code is the 13-digit GTIN, so a UPC-A
+// barcode is stored with a leading zero. A barcode printed on a pack identifies PackQuantity
+// units of the item's base unit.
+type ItemBarcode struct {
+	ID           uuid.UUID   `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440100"`
+	ItemID       uuid.UUID   `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Code         string      `json:"code" db:"code" example:"4006381333931"`
+	Type         BarcodeType `json:"type" db:"type" example:"ean13"`
+	PackQuantity int         `json:"pack_quantity" db:"pack_quantity" example:"1"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// BarcodeLookup is the item a scanned barcode identifies
+type BarcodeLookup struct {
+	Barcode ItemBarcode `json:"barcode"`
+	Item    Item        `json:"item"`
+}
+
+type CreateItemBarcodeRequest struct {
+	// Code is an EAN-13 or UPC-A barcode with its check digit; an internal barcode is generated
+	// when it is empty
+	Code string `json:"code,omitempty" example:"4006381333931"`
+
+	// PackQuantity defaults to 1
+	PackQuantity int `json:"pack_quantity,omitempty" example:"12"`
+}
+
+// LabelFormat is the image format a barcode label is rendered in
+type LabelFormat string
+
+const (
+	LabelFormatSVG LabelFormat = "svg"
+	LabelFormatPNG LabelFormat = "png"
+)
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BillOfMaterials lists the components one unit of an item is made of. A kit is sold as a bundle
+// and never stocked: selling it issues its components. Other items are built from their
+// components by assembly orders.
+type BillOfMaterials struct {
+	ItemID     uuid.UUID      `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kit        bool           `json:"kit" db:"kit" example:"false"`
+	Components []BOMComponent `json:"components"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// BOMComponent is the quantity of a component, in its base unit, that goes into one unit of the
+// item
+type BOMComponent struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	SKU      string    `json:"sku" example:"SKU-002"`
+	Name     string    `json:"name" example:"Laptop Battery"`
+	Quantity int       `json:"quantity" example:"2"`
+}
+
+// UpdateBillOfMaterialsRequest replaces the bill of materials of an item
+type UpdateBillOfMaterialsRequest struct {
+	Kit        bool                  `json:"kit" example:"false"`
+	Components []BOMComponentRequest `json:"components"`
+}
+
+type BOMComponentRequest struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Quantity int       `json:"quantity" example:"2"`
+}
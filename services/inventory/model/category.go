@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "text"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+func (t AttributeType) String() string {
+	return string(t)
+}
+
+// Category files items in a tree. A category without a parent is a root.
+type Category struct {
+	ID          uuid.UUID  `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440080"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty" db:"parent_id" example:"550e8400-e29b-41d4-a716-446655440081"`
+	Name        string     `json:"name" db:"name" example:"Laptops"`
+	Description string     `json:"description,omitempty" db:"description" example:"Portable computers"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// CategoryAttribute is a custom attribute of the items in a category and its descendants. Values
+// of an enum attribute must be one of its options.
+type CategoryAttribute struct {
+	ID         uuid.UUID     `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440082"`
+	CategoryID uuid.UUID     `json:"category_id" db:"category_id" example:"550e8400-e29b-41d4-a716-446655440080"`
+	Name       string        `json:"name" db:"name" example:"ram_gb"`
+	Type       AttributeType `json:"type" db:"type" example:"number"`
+	Required   bool          `json:"required" db:"required" example:"true"`
+	Options    []string      `json:"options,omitempty" db:"options" example:"black,silver"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// CategoryWithAttributes lists a category's own attributes and those it inherits from its
+// ancestors, root first
+type CategoryWithAttributes struct {
+	Category
+	Attributes          []CategoryAttribute `json:"attributes"`
+	InheritedAttributes []CategoryAttribute `json:"inherited_attributes,omitempty"`
+}
+
+// CategoryFilter narrows the categories listed
+type CategoryFilter struct {
+	ParentID string
+}
+
+type CreateCategoryRequest struct {
+	ParentID    *uuid.UUID                 `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440081"`
+	Name        string                     `json:"name" example:"Laptops"`
+	Description string                     `json:"description,omitempty" example:"Portable computers"`
+	Attributes  []CategoryAttributeRequest `json:"attributes,omitempty"`
+}
+
+// UpdateCategoryRequest replaces a category's attributes, matched by name. Values of attributes
+// left out are removed from the items, as are values of attributes whose type changes.
+type UpdateCategoryRequest struct {
+	ParentID    *uuid.UUID                 `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440081"`
+	Name        string                     `json:"name" example:"Laptops"`
+	Description string                     `json:"description,omitempty" example:"Portable computers"`
+	Attributes  []CategoryAttributeRequest `json:"attributes,omitempty"`
+}
+
+type CategoryAttributeRequest struct {
+	Name     string        `json:"name" example:"ram_gb"`
+	Type     AttributeType `json:"type" example:"number"`
+	Required bool          `json:"required" example:"true"`
+
+	// Options are required for enum attributes and not allowed otherwise
+	Options []string `json:"options,omitempty" example:"black,silver"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+type CostingMethod string
+
+const (
+	CostingMethodFIFO    CostingMethod = "fifo"
+	CostingMethodAverage CostingMethod = "average"
+)
+
+func (m CostingMethod) String() string {
+	return string(m)
+}
+
+// UnitCostQuantity is a quantity received at a unit cost
+type UnitCostQuantity struct {
+	Quantity int
+	UnitCost float64
+}
+
+// ItemValuation is the quantity and cost of an item's stock on the valuation date
+type ItemValuation struct {
+	ItemID        uuid.UUID     `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU           string        `json:"sku" example:"SKU-001"`
+	Name          string        `json:"name" example:"Laptop Computer"`
+	CostingMethod CostingMethod `json:"costing_method" example:"fifo"`
+	Quantity      int           `json:"quantity" example:"24"`
+	Value         float64       `json:"value" example:"21600.00"`
+	UnitCost      float64       `json:"unit_cost" example:"900.00"`
+}
+
+// ValuationReport values the stock of every item on a date
+type ValuationReport struct {
+	Items      []ItemValuation `json:"items"`
+	TotalValue float64         `json:"total_value" example:"21600.00"`
+}
+
+// ItemCostOfGoodsSold is the cost of the units of an item shipped on a sales order
+type ItemCostOfGoodsSold struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU      string    `json:"sku" example:"SKU-001"`
+	Name     string    `json:"name" example:"Laptop Computer"`
+	Quantity int       `json:"quantity" example:"2"`
+	Cost     float64   `json:"cost" example:"1800.00"`
+}
+
+// OrderCostOfGoodsSold is the cost of the goods shipped on a sales order
+type OrderCostOfGoodsSold struct {
+	OrderID   uuid.UUID             `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	Items     []ItemCostOfGoodsSold `json:"items"`
+	TotalCost float64               `json:"total_cost" example:"1800.00"`
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockCountStatus string
+
+const (
+	StockCountStatusOpen      StockCountStatus = "open"
+	StockCountStatusPosted    StockCountStatus = "posted"
+	StockCountStatusCancelled StockCountStatus = "cancelled"
+)
+
+func (s StockCountStatus) String() string {
+	return string(s)
+}
+
+// StockCount is a count session over the stock of one warehouse. The expected quantities are
+// snapshotted when the session opens at SnapshotAt.
+type StockCount struct {
+	ID            uuid.UUID        `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440060"`
+	WarehouseID   uuid.UUID        `json:"warehouse_id" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	WarehouseCode string           `json:"warehouse_code" example:"MAIN"`
+	Status        StockCountStatus `json:"status" db:"status" example:"open"`
+	Notes         string           `json:"notes,omitempty" db:"notes" example:"Year-end stocktake"`
+
+	SnapshotAt time.Time  `json:"snapshot_at" db:"snapshot_at" example:"2025-12-31T08:00:00Z"`
+	CreatedBy  string     `json:"created_by,omitempty" db:"created_by" example:"550e8400-e29b-41d4-a716-446655440030"`
+	PostedBy   string     `json:"posted_by,omitempty" db:"posted_by" example:"550e8400-e29b-41d4-a716-446655440030"`
+	PostedAt   *time.Time `json:"posted_at,omitempty" db:"posted_at" example:"2025-12-31T17:00:00Z"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-12-31T08:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-12-31T17:00:00Z"`
+}
+
+// StockCountLine is the count of an item at one location. ExpectedAtCount is the snapshot
+// quantity moved on by the stock movements booked after SnapshotAt up to and including the
+// line's CountedAt, so stock moved while counting is not mistaken for a variance. The variance is
+// measured against that cutoff; movements after it are left to stand when the count is posted.
+type StockCountLine struct {
+	ID           uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440061"`
+	ItemID       uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU          string    `json:"sku" example:"SKU-001"`
+	Name         string    `json:"name" example:"Laptop Computer"`
+	LocationID   uuid.UUID `json:"location_id" db:"location_id" example:"00000000-0000-0000-0000-000000000002"`
+	LocationCode string    `json:"location_code" example:"DEFAULT"`
+
+	ExpectedQuantity int        `json:"expected_quantity" db:"expected_quantity" example:"100"`
+	ExpectedAtCount  int        `json:"expected_at_count" example:"98"`
+	CountedQuantity  *int       `json:"counted_quantity,omitempty" db:"counted_quantity" example:"95"`
+	Variance         *int       `json:"variance,omitempty" example:"-3"`
+	CountedBy        string     `json:"counted_by,omitempty" db:"counted_by" example:"550e8400-e29b-41d4-a716-446655440030"`
+	CountedAt        *time.Time `json:"counted_at,omitempty" db:"counted_at" example:"2025-12-31T10:15:00Z"`
+
+	// AdjustmentQuantity is the change posted for the line
+	AdjustmentQuantity *int `json:"adjustment_quantity,omitempty" db:"adjustment_quantity" example:"-3"`
+}
+
+type StockCountWithLines struct {
+	StockCount
+	Lines []StockCountLine `json:"lines"`
+}
+
+// StockCountFilter narrows the count sessions listed
+type StockCountFilter struct {
+	Status StockCountStatus
+}
+
+// StockCountEntry is a quantity counted by a counter
+type StockCountEntry struct {
+	ItemID     uuid.UUID
+	LocationID uuid.UUID
+	Quantity   int
+}
+
+type CreateStockCountRequest struct {
+	// WarehouseID defaults to the default warehouse
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+
+	// ItemIDs limits the count to some items; without them every item that is not serialized is counted
+	ItemIDs []uuid.UUID `json:"item_ids,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Notes   string      `json:"notes,omitempty" example:"Year-end stocktake"`
+}
+
+type RecordStockCountsRequest struct {
+	Counts []StockCountEntryRequest `json:"counts"`
+}
+
+type StockCountEntryRequest struct {
+	ItemID uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// LocationID defaults to the warehouse's default location
+	LocationID *uuid.UUID `json:"location_id,omitempty" example:"00000000-0000-0000-0000-000000000002"`
+	Quantity   int        `json:"quantity" example:"95"`
+}
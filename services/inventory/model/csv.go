@@ -0,0 +1,49 @@
+package model
+
+import "github.com/google/uuid"
+
+// ItemImportResult reports the outcome of a CSV item import. In a dry run nothing is written and
+// Created and Updated count the items the import would create and update. An import with any
+// invalid row writes nothing.
+type ItemImportResult struct {
+	DryRun  bool              `json:"dry_run" example:"true"`
+	Rows    int               `json:"rows" example:"120"`
+	Created int               `json:"created" example:"100"`
+	Updated int               `json:"updated" example:"20"`
+	Errors  []ItemImportError `json:"errors"`
+}
+
+// ItemImportError is a problem with one row of an import. Row is the 1-based record number in the
+// file, counting the header as row 1.
+type ItemImportError struct {
+	Row     int    `json:"row" example:"7"`
+	SKU     string `json:"sku,omitempty" example:"SKU-001"`
+	Field   string `json:"field,omitempty" example:"unit_price"`
+	Message string `json:"message" example:"must be no less than 0"`
+}
+
+// ItemImport is an item written by an import: a new item with the opening stock to book into
+// the default warehouse, or an existing item with its changes applied
+type ItemImport struct {
+	Item         Item
+	New          bool
+	OpeningStock int
+	UnitCost     *float64
+}
+
+// ItemExportRow is an item as written to a CSV export, with its stock on hand
+type ItemExportRow struct {
+	ItemID          uuid.UUID
+	SKU             string
+	Name            string
+	Description     string
+	UnitPrice       float64
+	ReorderPoint    int
+	ReorderQuantity int
+	MinStock        int
+	CostingMethod   CostingMethod
+	BaseUnit        string
+	Serialized      bool
+	CategoryID      *uuid.UUID
+	Quantity        int
+}
@@ -0,0 +1,33 @@
+package model
+
+// StockEventLine is the stock change an event makes to one item in a warehouse. A positive
+// Quantity is received with its lots and costs; a negative one is issued.
+type StockEventLine struct {
+	ItemID        string
+	Quantity      int
+	Lots          []LotQuantity
+	SerialNumbers []string
+	Costs         []UnitCostQuantity
+}
+
+type StockLineFailureReason string
+
+const (
+	StockLineFailureInsufficientStock StockLineFailureReason = "insufficient_stock"
+	StockLineFailureNotFound          StockLineFailureReason = "not_found"
+	StockLineFailureRejected          StockLineFailureReason = "rejected"
+	StockLineFailureConflict          StockLineFailureReason = "conflict"
+)
+
+func (r StockLineFailureReason) String() string {
+	return string(r)
+}
+
+// StockLineFailure names a line of an event that could not be applied. Quantity is the quantity
+// of the line; Available is the warehouse's stock of the item when it was short.
+type StockLineFailure struct {
+	ItemID    string                 `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Quantity  int                    `json:"quantity" example:"5"`
+	Available *int                   `json:"available,omitempty" example:"3"`
+	Reason    StockLineFailureReason `json:"reason" example:"insufficient_stock"`
+}
@@ -14,13 +14,47 @@ type Item struct {
 	Description string  `json:"description" db:"description" example:"High-performance laptop with 16GB RAM and 512GB SSD"`
 	UnitPrice   float64 `json:"unit_price" db:"unit_price" example:"1299.99"`
 
+	ReorderPoint      int        `json:"reorder_point" db:"reorder_point" example:"10"`
+	ReorderQuantity   int        `json:"reorder_quantity" db:"reorder_quantity" example:"50"`
+	PreferredVendorID *uuid.UUID `json:"preferred_vendor_id,omitempty" db:"preferred_vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+
+	// MinStock is the quantity at or below which inventory.stock.low is published
+	MinStock int `json:"min_stock" db:"min_stock" example:"5"`
+
+	// Serialized items are tracked unit by unit; every stock change must name the serial numbers
+	Serialized bool `json:"serialized" db:"serialized" example:"true"`
+
+	// CostingMethod decides how issues are valued: fifo draws down the oldest cost layers first,
+	// average uses the moving average cost of the stock on hand
+	CostingMethod CostingMethod `json:"costing_method" db:"costing_method" example:"fifo"`
+
+	// BaseUnit is the unit stock is kept in; Units are the other units the item is bought and
+	// sold in
+	BaseUnit string     `json:"base_unit" db:"base_unit" example:"each"`
+	Units    []ItemUnit `json:"units,omitempty"`
+
+	// Attributes holds the values of the custom attributes of the item's category and its
+	// ancestors, keyed by attribute name
+	CategoryID *uuid.UUID             `json:"category_id,omitempty" db:"category_id" example:"550e8400-e29b-41d4-a716-446655440080"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// ProductID is set on variant items; VariantOptions holds the item's value on each of the
+	// product's axes, keyed by axis name
+	ProductID      *uuid.UUID        `json:"product_id,omitempty" db:"product_id" example:"550e8400-e29b-41d4-a716-446655440090"`
+	VariantOptions map[string]string `json:"variant_options,omitempty" db:"variant_options"`
+
+	// ArchivedAt is set once the item is deleted. Archived items stay fetchable by ID for the
+	// orders that refer to them but are left out of lists and cannot be put on new orders.
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at" example:"2025-11-20T12:00:00Z"`
+
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
 }
 
 type Stock struct {
-	ID     uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	ItemID uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ID         uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ItemID     uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LocationID uuid.UUID `json:"location_id" db:"location_id" example:"00000000-0000-0000-0000-000000000002"`
 
 	Quantity int `json:"quantity" db:"quantity" example:"100"`
 
@@ -28,20 +62,139 @@ type Stock struct {
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
 }
 
+// ItemStock is the stock of an item across all locations. Quantity is the stock on hand, of which
+// Reserved is held for open documents such as draft sales orders; Available is what remains to
+// sell. Available goes negative when stock is taken out below what is reserved, so that the
+// shortfall shows. InTransit is stock shipped on transfers that has not yet been
+// received, and is not counted in Quantity.
+type ItemStock struct {
+	ItemID    uuid.UUID       `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Quantity  int             `json:"quantity" example:"100"`
+	Reserved  int             `json:"reserved" example:"10"`
+	Available int             `json:"available" example:"90"`
+	InTransit int             `json:"in_transit" example:"0"`
+	Locations []LocationStock `json:"locations"`
+}
+
+type LocationStock struct {
+	LocationID    uuid.UUID `json:"location_id" example:"00000000-0000-0000-0000-000000000002"`
+	LocationCode  string    `json:"location_code" example:"DEFAULT"`
+	WarehouseID   uuid.UUID `json:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	WarehouseCode string    `json:"warehouse_code" example:"MAIN"`
+	Quantity      int       `json:"quantity" example:"100"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type ReorderSuggestion struct {
+	ItemID            uuid.UUID  `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU               string     `json:"sku" example:"SKU-001"`
+	Name              string     `json:"name" example:"Laptop Computer"`
+	Quantity          int        `json:"quantity" example:"4"`
+	ReorderPoint      int        `json:"reorder_point" example:"10"`
+	ReorderQuantity   int        `json:"reorder_quantity" example:"50"`
+	PreferredVendorID *uuid.UUID `json:"preferred_vendor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	RequestedAt       *time.Time `json:"requested_at,omitempty" example:"2025-11-20T12:00:00Z"`
+}
+
+// StockAlertLevel is the stock alert last raised for an item. It is cleared once stock rises above
+// the item's minimum again.
+type StockAlertLevel string
+
+const (
+	StockAlertLevelLow      StockAlertLevel = "low"
+	StockAlertLevelDepleted StockAlertLevel = "depleted"
+)
+
+func (l StockAlertLevel) String() string {
+	return string(l)
+}
+
 type CreateItemRequest struct {
-	Name        string  `json:"name" example:"Laptop Computer"`
-	Description string  `json:"description" example:"High-performance laptop with 16GB RAM and 512GB SSD"`
-	SKU         string  `json:"sku" example:"SKU-001"`
-	UnitPrice   float64 `json:"unit_price" example:"1299.99"`
+	Name              string     `json:"name" example:"Laptop Computer"`
+	Description       string     `json:"description" example:"High-performance laptop with 16GB RAM and 512GB SSD"`
+	SKU               string     `json:"sku" example:"SKU-001"`
+	UnitPrice         float64    `json:"unit_price" example:"1299.99"`
+	ReorderPoint      int        `json:"reorder_point" example:"10"`
+	ReorderQuantity   int        `json:"reorder_quantity" example:"50"`
+	PreferredVendorID *uuid.UUID `json:"preferred_vendor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	MinStock          int        `json:"min_stock" example:"5"`
+	Serialized        bool       `json:"serialized" example:"true"`
+
+	// CostingMethod defaults to fifo
+	CostingMethod CostingMethod `json:"costing_method,omitempty" example:"fifo"`
+
+	// BaseUnit defaults to each
+	BaseUnit string     `json:"base_unit,omitempty" example:"each"`
+	Units    []ItemUnit `json:"units,omitempty"`
+
+	// Attributes are keyed by attribute name and need a category
+	CategoryID *uuid.UUID             `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440080"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
 }
 
 type UpdateItemRequest struct {
-	Name        string  `json:"name" example:"Laptop Computer Updated"`
-	Description string  `json:"description" example:"Updated description for laptop"`
-	SKU         string  `json:"sku" example:"SKU-001-UPDATED"`
-	UnitPrice   float64 `json:"unit_price" example:"1199.99"`
+	Name              string     `json:"name" example:"Laptop Computer Updated"`
+	Description       string     `json:"description" example:"Updated description for laptop"`
+	SKU               string     `json:"sku" example:"SKU-001-UPDATED"`
+	UnitPrice         float64    `json:"unit_price" example:"1199.99"`
+	ReorderPoint      int        `json:"reorder_point" example:"10"`
+	ReorderQuantity   int        `json:"reorder_quantity" example:"50"`
+	PreferredVendorID *uuid.UUID `json:"preferred_vendor_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	MinStock          int        `json:"min_stock" example:"5"`
+	Serialized        bool       `json:"serialized" example:"true"`
+
+	// CostingMethod defaults to fifo
+	CostingMethod CostingMethod `json:"costing_method,omitempty" example:"fifo"`
+
+	// BaseUnit defaults to each
+	BaseUnit string     `json:"base_unit,omitempty" example:"each"`
+	Units    []ItemUnit `json:"units,omitempty"`
+
+	// Attributes are keyed by attribute name and need a category
+	CategoryID *uuid.UUID             `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440080"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ItemFilter narrows the items listed. CategoryID matches the category and its descendants.
+type ItemFilter struct {
+	CategoryID string
+}
+
+// ItemSearchFilter narrows an item search. Query is matched against the SKU, name and
+// description; the other fields are optional.
+type ItemSearchFilter struct {
+	Query      string
+	MinPrice   *float64
+	MaxPrice   *float64
+	InStock    *bool
+	CategoryID string
+}
+
+// ItemSearchResult is an item matching a search, with its stock on hand. Results are ordered by
+// Rank, highest first.
+type ItemSearchResult struct {
+	ItemID      uuid.UUID  `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU         string     `json:"sku" example:"SKU-001"`
+	Name        string     `json:"name" example:"Laptop Computer"`
+	Description string     `json:"description" example:"High-performance laptop with 16GB RAM and 512GB SSD"`
+	UnitPrice   float64    `json:"unit_price" example:"1299.99"`
+	CategoryID  *uuid.UUID `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440050"`
+	Quantity    int        `json:"quantity" example:"100"`
+	Rank        float64    `json:"rank" example:"0.42"`
 }
 
 type AdjustStockRequest struct {
-	Quantity int `json:"quantity" example:"10"`
+	Quantity   int        `json:"quantity" example:"10"`
+	LocationID *uuid.UUID `json:"location_id,omitempty" example:"00000000-0000-0000-0000-000000000002"`
+
+	// LotNumber books the change to a lot; increases create the lot with ExpiryDate when it is new
+	LotNumber  string     `json:"lot_number,omitempty" example:"LOT-2025-114"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" example:"2026-05-31T00:00:00Z"`
+
+	// SerialNumbers name the units added or removed; required for serialized items, one per unit
+	SerialNumbers []string `json:"serial_numbers,omitempty" example:"SN-5CD1234XYZ"`
+
+	// Reason defaults to adjustment; count records a correction found by a stock count
+	Reason           StockMovementReason `json:"reason,omitempty" example:"adjustment"`
+	SourceDocumentID *uuid.UUID          `json:"source_document_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440020"`
 }
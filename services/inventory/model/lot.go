@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockLot is the quantity of an item held at a location under one lot number
+type StockLot struct {
+	ID         uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440040"`
+	ItemID     uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LocationID uuid.UUID `json:"location_id" db:"location_id" example:"00000000-0000-0000-0000-000000000002"`
+
+	LocationCode  string    `json:"location_code" example:"DEFAULT"`
+	WarehouseID   uuid.UUID `json:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	WarehouseCode string    `json:"warehouse_code" example:"MAIN"`
+
+	LotNumber  string     `json:"lot_number" db:"lot_number" example:"LOT-2025-114"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" db:"expiry_date" example:"2026-05-31T00:00:00Z"`
+	Quantity   int        `json:"quantity" db:"quantity" example:"24"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// ExpiringLot is a lot reported by the expiry report
+type ExpiringLot struct {
+	StockLot
+	SKU             string `json:"sku" example:"SKU-001"`
+	Name            string `json:"name" example:"Laptop Computer"`
+	DaysUntilExpiry int    `json:"days_until_expiry" example:"12"`
+}
+
+// LotRef names the lot a stock change applies to. The expiry date is only used when the lot is
+// first received.
+type LotRef struct {
+	LotNumber  string
+	ExpiryDate *time.Time
+}
+
+// LotQuantity is a quantity received under a lot
+type LotQuantity struct {
+	LotRef
+	Quantity int
+}
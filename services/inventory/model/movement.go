@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovementReason string
+
+const (
+	StockMovementReasonSale       StockMovementReason = "sale"
+	StockMovementReasonPurchase   StockMovementReason = "purchase"
+	StockMovementReasonAdjustment StockMovementReason = "adjustment"
+	StockMovementReasonCount      StockMovementReason = "count"
+	StockMovementReasonReturn     StockMovementReason = "return"
+	StockMovementReasonTransfer   StockMovementReason = "transfer"
+	StockMovementReasonAssembly   StockMovementReason = "assembly"
+)
+
+func (r StockMovementReason) String() string {
+	return string(r)
+}
+
+// StockMovement is an immutable ledger entry recording one change to the stock held at a location
+type StockMovement struct {
+	ID         uuid.UUID  `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	ItemID     uuid.UUID  `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LocationID uuid.UUID  `json:"location_id" db:"location_id" example:"00000000-0000-0000-0000-000000000002"`
+	LotID      *uuid.UUID `json:"lot_id,omitempty" db:"lot_id" example:"550e8400-e29b-41d4-a716-446655440040"`
+
+	QuantityDelta int `json:"quantity_delta" db:"quantity_delta" example:"-2"`
+	BalanceAfter  int `json:"balance_after" db:"balance_after" example:"98"`
+
+	Reason           StockMovementReason `json:"reason" db:"reason" example:"sale"`
+	SourceDocumentID *uuid.UUID          `json:"source_document_id,omitempty" db:"source_document_id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	UserID           string              `json:"user_id,omitempty" db:"user_id" example:"550e8400-e29b-41d4-a716-446655440030"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// StockMovementSource describes why stock changed; it is written to every movement the change
+// produces. EventType names the event a change was made for, if any; the change is then recorded
+// as processed for the event and source document so that the event is applied only once.
+type StockMovementSource struct {
+	Reason           StockMovementReason
+	SourceDocumentID *uuid.UUID
+	UserID           string
+	EventType        string
+}
+
+// StockMovementFilter narrows the movements listed for an item. From is inclusive and To exclusive.
+type StockMovementFilter struct {
+	ItemID string
+	From   *time.Time
+	To     *time.Time
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ItemPrice is an item's unit price from EffectiveFrom until the next price takes effect. A price
+// effective in the future is a scheduled price change.
+type ItemPrice struct {
+	ID            uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440110"`
+	ItemID        uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UnitPrice     float64   `json:"unit_price" db:"unit_price" example:"1349.99"`
+	EffectiveFrom time.Time `json:"effective_from" db:"effective_from" example:"2026-01-01T00:00:00Z"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// SchedulePriceChangeRequest sets the price an item changes to at a future time
+type SchedulePriceChangeRequest struct {
+	UnitPrice     float64   `json:"unit_price" example:"1349.99"`
+	EffectiveFrom time.Time `json:"effective_from" example:"2026-01-01T00:00:00Z"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product is the parent of a family of variant items, one per combination of the values of its
+// axes. Stock, sales and purchases are kept on the variant items.
+type Product struct {
+	ID          uuid.UUID     `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440090"`
+	SKU         string        `json:"sku" db:"sku" example:"TSHIRT"`
+	Name        string        `json:"name" db:"name" example:"T-Shirt"`
+	Description string        `json:"description,omitempty" db:"description" example:"Cotton t-shirt"`
+	UnitPrice   float64       `json:"unit_price" db:"unit_price" example:"19.99"`
+	Axes        []ProductAxis `json:"axes"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// ProductAxis is a dimension the variants of a product differ in, such as size or colour
+type ProductAxis struct {
+	Name   string   `json:"name" example:"size"`
+	Values []string `json:"values" example:"S,M,L"`
+}
+
+// ProductVariant is a variant item of a product with its stock on hand and in transit
+type ProductVariant struct {
+	ItemID    uuid.UUID         `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU       string            `json:"sku" example:"TSHIRT-M-RED"`
+	Name      string            `json:"name" example:"T-Shirt (M, red)"`
+	UnitPrice float64           `json:"unit_price" example:"19.99"`
+	Options   map[string]string `json:"options"`
+	Quantity  int               `json:"quantity" example:"25"`
+	InTransit int               `json:"in_transit" example:"5"`
+}
+
+// ProductWithVariants is the variant matrix of a product
+type ProductWithVariants struct {
+	Product
+	Variants []ProductVariant `json:"variants"`
+}
+
+// CreateProductRequest creates a product and a variant item for every combination of the
+// values of its axes
+type CreateProductRequest struct {
+	SKU         string                  `json:"sku" example:"TSHIRT"`
+	Name        string                  `json:"name" example:"T-Shirt"`
+	Description string                  `json:"description,omitempty" example:"Cotton t-shirt"`
+	UnitPrice   float64                 `json:"unit_price" example:"19.99"`
+	Axes        []ProductAxis           `json:"axes"`
+	Variants    []ProductVariantRequest `json:"variants,omitempty"`
+}
+
+// UpdateProductRequest updates a product. Axes keep their names and order; new values may be
+// added to them, and a variant item is created for every combination that has none yet. The
+// unit price is the default for new variants and does not reprice existing ones.
+type UpdateProductRequest struct {
+	Name        string                  `json:"name" example:"T-Shirt"`
+	Description string                  `json:"description,omitempty" example:"Cotton t-shirt"`
+	UnitPrice   float64                 `json:"unit_price" example:"19.99"`
+	Axes        []ProductAxis           `json:"axes"`
+	Variants    []ProductVariantRequest `json:"variants,omitempty"`
+}
+
+// ProductVariantRequest overrides the SKU or price of the generated variant with the given
+// options. By default a variant's SKU is the product SKU followed by its values and its price
+// is the product's.
+type ProductVariantRequest struct {
+	Options   map[string]string `json:"options"`
+	SKU       string            `json:"sku,omitempty" example:"TSHIRT-M-RED"`
+	UnitPrice *float64          `json:"unit_price,omitempty" example:"21.99"`
+}
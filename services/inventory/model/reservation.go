@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationSourceType string
+
+const (
+	ReservationSourceSalesOrder ReservationSourceType = "sales_order"
+)
+
+func (t ReservationSourceType) String() string {
+	return string(t)
+}
+
+// StockReservation holds stock in a warehouse for a source document until the stock is issued
+// or the reservation expires. Reserved stock stays on hand but is not available to sell.
+type StockReservation struct {
+	ID          uuid.UUID             `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440120"`
+	ItemID      uuid.UUID             `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	WarehouseID uuid.UUID             `json:"warehouse_id" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	Quantity    int                   `json:"quantity" db:"quantity" example:"2"`
+	SourceType  ReservationSourceType `json:"source_type" db:"source_type" example:"sales_order"`
+	SourceID    uuid.UUID             `json:"source_id" db:"source_id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	ExpiresAt   *time.Time            `json:"expires_at,omitempty" db:"expires_at" example:"2025-11-27T12:00:00Z"`
+	CreatedAt   time.Time             `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// ReserveStockRequest replaces the reservations held for a source document. Kits are reserved as
+// their components. Without an expiry the configured reservation lifetime applies; an empty list
+// of items releases the reservations.
+type ReserveStockRequest struct {
+	WarehouseID *uuid.UUID                `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	ExpiresAt   *time.Time                `json:"expires_at,omitempty" example:"2025-11-27T12:00:00Z"`
+	Items       []ReserveStockItemRequest `json:"items"`
+}
+
+type ReserveStockItemRequest struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Quantity int       `json:"quantity" example:"2"`
+}
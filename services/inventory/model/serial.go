@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockSerialStatus string
+
+const (
+	StockSerialStatusInStock   StockSerialStatus = "in_stock"
+	StockSerialStatusInTransit StockSerialStatus = "in_transit"
+	StockSerialStatusSold      StockSerialStatus = "sold"
+	StockSerialStatusReturned  StockSerialStatus = "returned"
+	StockSerialStatusRemoved   StockSerialStatus = "removed"
+)
+
+func (s StockSerialStatus) String() string {
+	return string(s)
+}
+
+// StockSerial is one unit of a serialized item. LocationID is where the unit is held, or where it
+// was last held once it has left stock or while it is in transit.
+type StockSerial struct {
+	ID           uuid.UUID         `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440050"`
+	ItemID       uuid.UUID         `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SerialNumber string            `json:"serial_number" db:"serial_number" example:"SN-5CD1234XYZ"`
+	Status       StockSerialStatus `json:"status" db:"status" example:"in_stock"`
+
+	LocationID    uuid.UUID `json:"location_id" db:"location_id" example:"00000000-0000-0000-0000-000000000002"`
+	LocationCode  string    `json:"location_code" example:"DEFAULT"`
+	WarehouseID   uuid.UUID `json:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	WarehouseCode string    `json:"warehouse_code" example:"MAIN"`
+
+	// OrderID is the sales order a sold unit went out on; ReturnID the purchase return of a returned unit
+	OrderID  *uuid.UUID `json:"order_id,omitempty" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	ReturnID *uuid.UUID `json:"return_id,omitempty" db:"return_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// SerialLookup is a serial found by serial number, together with the item it belongs to
+type SerialLookup struct {
+	StockSerial
+	SKU  string `json:"sku" example:"SKU-001"`
+	Name string `json:"name" example:"Laptop Computer"`
+}
+
+// StockSerialFilter narrows the serials listed for an item
+type StockSerialFilter struct {
+	ItemID string
+	Status StockSerialStatus
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockTransferStatus string
+
+const (
+	StockTransferStatusDraft     StockTransferStatus = "draft"
+	StockTransferStatusInTransit StockTransferStatus = "in_transit"
+	StockTransferStatusReceived  StockTransferStatus = "received"
+	StockTransferStatusCancelled StockTransferStatus = "cancelled"
+)
+
+func (s StockTransferStatus) String() string {
+	return string(s)
+}
+
+// StockTransfer moves stock from one location to another. Shipping takes the stock out of the
+// source location and holds it in transit on the transfer until it is received at the destination.
+type StockTransfer struct {
+	ID uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440070"`
+
+	SourceLocationID        uuid.UUID `json:"source_location_id" db:"source_location_id" example:"00000000-0000-0000-0000-000000000002"`
+	SourceLocationCode      string    `json:"source_location_code" example:"DEFAULT"`
+	SourceWarehouseID       uuid.UUID `json:"source_warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	DestinationLocationID   uuid.UUID `json:"destination_location_id" db:"destination_location_id" example:"550e8400-e29b-41d4-a716-446655440041"`
+	DestinationLocationCode string    `json:"destination_location_code" example:"A-01"`
+	DestinationWarehouseID  uuid.UUID `json:"destination_warehouse_id" example:"550e8400-e29b-41d4-a716-446655440040"`
+
+	Status    StockTransferStatus `json:"status" db:"status" example:"in_transit"`
+	Notes     string              `json:"notes,omitempty" db:"notes" example:"Restock the east store"`
+	CreatedBy string              `json:"created_by,omitempty" db:"created_by" example:"550e8400-e29b-41d4-a716-446655440030"`
+
+	ShippedAt  *time.Time `json:"shipped_at,omitempty" db:"shipped_at" example:"2025-12-01T09:00:00Z"`
+	ReceivedAt *time.Time `json:"received_at,omitempty" db:"received_at" example:"2025-12-02T15:30:00Z"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-12-01T08:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-12-02T15:30:00Z"`
+}
+
+// StockTransferLine is the quantity of an item on a transfer. Lots are the lots the quantity was
+// shipped from, known once the transfer has shipped; it arrives under the same lots.
+type StockTransferLine struct {
+	ID            uuid.UUID          `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440071"`
+	ItemID        uuid.UUID          `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SKU           string             `json:"sku" example:"SKU-001"`
+	Name          string             `json:"name" example:"Laptop Computer"`
+	Quantity      int                `json:"quantity" db:"quantity" example:"10"`
+	Lots          []StockTransferLot `json:"lots,omitempty"`
+	SerialNumbers []string           `json:"serial_numbers,omitempty" example:"SN-5CD1234XYZ"`
+}
+
+type StockTransferLot struct {
+	LotNumber  string     `json:"lot_number" example:"LOT-2025-11"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" example:"2026-05-31T00:00:00Z"`
+	Quantity   int        `json:"quantity" example:"10"`
+}
+
+type StockTransferWithLines struct {
+	StockTransfer
+	Lines []StockTransferLine `json:"lines"`
+}
+
+// StockTransferFilter narrows the transfers listed
+type StockTransferFilter struct {
+	Status StockTransferStatus
+}
+
+type CreateStockTransferRequest struct {
+	SourceLocationID      uuid.UUID                  `json:"source_location_id" example:"00000000-0000-0000-0000-000000000002"`
+	DestinationLocationID uuid.UUID                  `json:"destination_location_id" example:"550e8400-e29b-41d4-a716-446655440041"`
+	Lines                 []StockTransferLineRequest `json:"lines"`
+	Notes                 string                     `json:"notes,omitempty" example:"Restock the east store"`
+}
+
+type StockTransferLineRequest struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Quantity int       `json:"quantity" example:"10"`
+
+	// SerialNumbers names each unit moved; required for serialized items
+	SerialNumbers []string `json:"serial_numbers,omitempty" example:"SN-5CD1234XYZ"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "strings"
+
+// DefaultBaseUnit is the base unit of items created without one
+const DefaultBaseUnit = "each"
+
+// ItemUnit is a unit an item is bought or sold in other than its base unit. One of the unit holds
+// ConversionFactor base units, such as a case of 12.
+type ItemUnit struct {
+	Unit             string `json:"unit" example:"case"`
+	ConversionFactor int    `json:"conversion_factor" example:"12"`
+}
+
+// ConversionFactor returns the number of base units in one of the given unit. An empty unit is
+// the base unit. It reports false for a unit the item is not kept in.
+func (i Item) ConversionFactor(unit string) (int, bool) {
+	unit = strings.TrimSpace(unit)
+	if unit == "" || strings.EqualFold(unit, i.BaseUnit) {
+		return 1, true
+	}
+	for _, u := range i.Units {
+		if strings.EqualFold(unit, u.Unit) {
+			return u.ConversionFactor, true
+		}
+	}
+	return 0, false
+}
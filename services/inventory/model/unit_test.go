@@ -0,0 +1,37 @@
+package model
+
+import "testing"
+
+func TestItemConversionFactor(t *testing.T) {
+	item := Item{
+		BaseUnit: "each",
+		Units: []ItemUnit{
+			{Unit: "case", ConversionFactor: 12},
+			{Unit: "Pallet", ConversionFactor: 480},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		unit       string
+		wantFactor int
+		wantOK     bool
+	}{
+		{name: "empty unit is the base unit", unit: "", wantFactor: 1, wantOK: true},
+		{name: "blank unit is the base unit", unit: "  ", wantFactor: 1, wantOK: true},
+		{name: "base unit", unit: "each", wantFactor: 1, wantOK: true},
+		{name: "base unit in another case", unit: "EACH", wantFactor: 1, wantOK: true},
+		{name: "larger unit", unit: "case", wantFactor: 12, wantOK: true},
+		{name: "larger unit ignoring case and spaces", unit: " pallet ", wantFactor: 480, wantOK: true},
+		{name: "unit the item is not kept in", unit: "box", wantFactor: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor, ok := item.ConversionFactor(tt.unit)
+			if factor != tt.wantFactor || ok != tt.wantOK {
+				t.Errorf("ConversionFactor(%q) = %d, %v, want %d, %v", tt.unit, factor, ok, tt.wantFactor, tt.wantOK)
+			}
+		})
+	}
+}
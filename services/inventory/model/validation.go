@@ -1,29 +1,353 @@
 package model
 
 import (
+	"fmt"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 func (r *CreateItemRequest) Validate() error {
-	return validation.ValidateStruct(r,
+	if err := validation.ValidateStruct(r,
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
 		validation.Field(&r.Description, validation.Length(0, 1000)),
 		validation.Field(&r.SKU, validation.Required, validation.Length(1, 100)),
 		validation.Field(&r.UnitPrice, validation.Required, validation.Min(0.0)),
-	)
+		validation.Field(&r.ReorderPoint, validation.Min(0)),
+		validation.Field(&r.ReorderQuantity, validation.Min(0)),
+		validation.Field(&r.MinStock, validation.Min(0)),
+		validation.Field(&r.CostingMethod, validation.In(CostingMethodFIFO, CostingMethodAverage)),
+		validation.Field(&r.BaseUnit, validation.Length(0, 20)),
+		validation.Field(&r.Units, validation.Length(0, 20)),
+		validation.Field(&r.Attributes, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	return validateItemUnits(r.Units)
 }
 
 func (r *UpdateItemRequest) Validate() error {
-	return validation.ValidateStruct(r,
+	if err := validation.ValidateStruct(r,
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
 		validation.Field(&r.Description, validation.Length(0, 1000)),
 		validation.Field(&r.SKU, validation.Required, validation.Length(1, 100)),
 		validation.Field(&r.UnitPrice, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.ReorderPoint, validation.Min(0)),
+		validation.Field(&r.ReorderQuantity, validation.Min(0)),
+		validation.Field(&r.MinStock, validation.Min(0)),
+		validation.Field(&r.CostingMethod, validation.In(CostingMethodFIFO, CostingMethodAverage)),
+		validation.Field(&r.BaseUnit, validation.Length(0, 20)),
+		validation.Field(&r.Units, validation.Length(0, 20)),
+		validation.Field(&r.Attributes, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	return validateItemUnits(r.Units)
+}
+
+// validateItemUnits validates each unit in the units slice
+func validateItemUnits(units []ItemUnit) error {
+	for i, unit := range units {
+		if err := unit.Validate(); err != nil {
+			return validation.NewError("units", fmt.Sprintf("unit[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (u *ItemUnit) Validate() error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Unit, validation.Required, validation.Length(1, 20)),
+		validation.Field(&u.ConversionFactor, validation.Required, validation.Min(1)),
 	)
 }
 
 func (r *AdjustStockRequest) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(&r.Quantity, validation.Required),
+		validation.Field(&r.LotNumber, validation.Length(0, 100)),
+		validation.Field(&r.ExpiryDate, validation.When(r.LotNumber == "", validation.Nil.Error("requires lot_number"))),
+		validation.Field(&r.SerialNumbers, validation.Length(0, 1000), validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&r.Reason, validation.In(StockMovementReasonAdjustment, StockMovementReasonCount)),
+	)
+}
+
+func (r *CreateStockCountRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemIDs, validation.Length(0, 1000)),
+		validation.Field(&r.Notes, validation.Length(0, 1000)),
+	)
+}
+
+func (r *RecordStockCountsRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Counts, validation.Required, validation.Length(1, 1000)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each entry in the counts slice
+	for i, entry := range r.Counts {
+		if err := entry.Validate(); err != nil {
+			return validation.NewError("counts", fmt.Sprintf("count[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *StockCountEntryRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Min(0)),
+	)
+}
+
+func (r *CreateStockTransferRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.SourceLocationID, validation.Required),
+		validation.Field(&r.DestinationLocationID, validation.Required),
+		validation.Field(&r.Lines, validation.Required, validation.Length(1, 1000)),
+		validation.Field(&r.Notes, validation.Length(0, 1000)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each line in the lines slice
+	for i, line := range r.Lines {
+		if err := line.Validate(); err != nil {
+			return validation.NewError("lines", fmt.Sprintf("line[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *StockTransferLineRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+		validation.Field(&r.SerialNumbers, validation.Each(validation.Required, validation.Length(1, 100))),
+	)
+}
+
+func (r *CreateWarehouseRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Code, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func (r *UpdateWarehouseRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Code, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func (r *CreateLocationRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Code, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func (r *UpdateLocationRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Code, validation.Required, validation.Length(1, 50)),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func (r *CreateCategoryRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.Attributes, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	return validateCategoryAttributes(r.Attributes)
+}
+
+func (r *UpdateCategoryRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.Attributes, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	return validateCategoryAttributes(r.Attributes)
+}
+
+// validateCategoryAttributes validates each attribute in the attributes slice
+func validateCategoryAttributes(attributes []CategoryAttributeRequest) error {
+	for i, attribute := range attributes {
+		if err := attribute.Validate(); err != nil {
+			return validation.NewError("attributes", fmt.Sprintf("attribute[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *CategoryAttributeRequest) Validate() error {
+	isEnum := r.Type == AttributeTypeEnum
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Type, validation.Required, validation.In(AttributeTypeText, AttributeTypeNumber, AttributeTypeBoolean, AttributeTypeEnum)),
+		validation.Field(&r.Options,
+			validation.When(isEnum, validation.Required, validation.Length(1, 100), validation.Each(validation.Required, validation.Length(1, 100))),
+			validation.When(!isEnum, validation.Empty),
+		),
+	)
+}
+
+func (r *CreateProductRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.SKU, validation.Required, validation.Length(1, 80)),
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 200)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.UnitPrice, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.Axes, validation.Required, validation.Length(1, 5)),
+		validation.Field(&r.Variants, validation.Length(0, 500)),
+	); err != nil {
+		return err
+	}
+
+	if err := validateProductAxes(r.Axes); err != nil {
+		return err
+	}
+
+	return validateProductVariants(r.Variants)
+}
+
+func (r *UpdateProductRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 200)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.UnitPrice, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.Axes, validation.Required, validation.Length(1, 5)),
+		validation.Field(&r.Variants, validation.Length(0, 500)),
+	); err != nil {
+		return err
+	}
+
+	if err := validateProductAxes(r.Axes); err != nil {
+		return err
+	}
+
+	return validateProductVariants(r.Variants)
+}
+
+// validateProductAxes validates each axis in the axes slice
+func validateProductAxes(axes []ProductAxis) error {
+	for i, axis := range axes {
+		if err := axis.Validate(); err != nil {
+			return validation.NewError("axes", fmt.Sprintf("axis[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (a *ProductAxis) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&a.Values, validation.Required, validation.Length(1, 50), validation.Each(validation.Required, validation.Length(1, 20))),
+	)
+}
+
+// validateProductVariants validates each variant override in the variants slice
+func validateProductVariants(variants []ProductVariantRequest) error {
+	for i, variant := range variants {
+		if err := variant.Validate(); err != nil {
+			return validation.NewError("variants", fmt.Sprintf("variant[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *ProductVariantRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Options, validation.Required),
+		validation.Field(&r.SKU, validation.Length(0, 100)),
+		validation.Field(&r.UnitPrice, validation.Min(0.0)),
+	)
+}
+
+func (r *UpdateBillOfMaterialsRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Components, validation.Required, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each component in the components slice
+	for i, component := range r.Components {
+		if err := component.Validate(); err != nil {
+			return validation.NewError("components", fmt.Sprintf("component[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *BOMComponentRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+func (r *CreateAssemblyOrderRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+		validation.Field(&r.Notes, validation.Length(0, 1000)),
+	)
+}
+
+func (r *CreateItemBarcodeRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Code, is.Digit, validation.Length(12, 13)),
+		validation.Field(&r.PackQuantity, validation.Min(1)),
+	)
+}
+
+func (r *SchedulePriceChangeRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.UnitPrice, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.EffectiveFrom, validation.Required),
+	)
+}
+
+func (r *ReserveStockRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Items, validation.Length(0, 1000)),
+	); err != nil {
+		return err
+	}
+
+	for i, item := range r.Items {
+		if err := item.Validate(); err != nil {
+			return validation.NewError("items", fmt.Sprintf("item[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *ReserveStockItemRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
 	)
 }
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWarehouseID is the main warehouse created by the migrations. Stock movements that do not
+// name a warehouse are booked there unless DEFAULT_WAREHOUSE_ID points elsewhere.
+var DefaultWarehouseID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+type Warehouse struct {
+	ID   uuid.UUID `json:"id" db:"id" example:"00000000-0000-0000-0000-000000000001"`
+	Code string    `json:"code" db:"code" example:"MAIN"`
+	Name string    `json:"name" db:"name" example:"Main Warehouse"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type Location struct {
+	ID          uuid.UUID `json:"id" db:"id" example:"00000000-0000-0000-0000-000000000002"`
+	WarehouseID uuid.UUID `json:"warehouse_id" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+	Code        string    `json:"code" db:"code" example:"A-01-03"`
+	Name        string    `json:"name" db:"name" example:"Aisle A, rack 1, shelf 3"`
+
+	// IsDefault marks the location that receives stock booked against the warehouse as a whole
+	IsDefault bool `json:"is_default" db:"is_default" example:"false"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type WarehouseWithLocations struct {
+	Warehouse
+	Locations []Location `json:"locations"`
+}
+
+type CreateWarehouseRequest struct {
+	Code string `json:"code" example:"SHOP"`
+	Name string `json:"name" example:"Shop Floor"`
+}
+
+type UpdateWarehouseRequest struct {
+	Code string `json:"code" example:"SHOP"`
+	Name string `json:"name" example:"Shop Floor"`
+}
+
+type CreateLocationRequest struct {
+	Code string `json:"code" example:"A-01-03"`
+	Name string `json:"name" example:"Aisle A, rack 1, shelf 3"`
+}
+
+type UpdateLocationRequest struct {
+	Code string `json:"code" example:"A-01-03"`
+	Name string `json:"name" example:"Aisle A, rack 1, shelf 3"`
+}
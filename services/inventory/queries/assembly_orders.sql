@@ -0,0 +1,48 @@
+-- name: CreateAssemblyOrder :exec
+INSERT INTO assembly_orders (id, item_id, warehouse_id, quantity, status, notes, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: CreateAssemblyOrderLine :exec
+INSERT INTO assembly_order_lines (id, assembly_order_id, item_id, quantity)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetAssemblyOrderByID :one
+SELECT ao.id, ao.item_id, ao.warehouse_id, ao.quantity, ao.status, ao.notes, ao.created_by, ao.completed_at, ao.created_at, ao.updated_at,
+       i.sku, i.name
+FROM assembly_orders ao
+JOIN items i ON i.id = ao.item_id
+WHERE ao.id = $1;
+
+-- name: GetAssemblyOrderForUpdate :one
+SELECT id, item_id, warehouse_id, quantity, status, notes, created_by, completed_at, created_at, updated_at
+FROM assembly_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListAssemblyOrders :many
+SELECT ao.id, ao.item_id, ao.warehouse_id, ao.quantity, ao.status, ao.notes, ao.created_by, ao.completed_at, ao.created_at, ao.updated_at,
+       i.sku, i.name
+FROM assembly_orders ao
+JOIN items i ON i.id = ao.item_id
+WHERE (sqlc.narg('status')::varchar IS NULL OR ao.status = sqlc.narg('status'))
+  AND (sqlc.narg('item_id')::uuid IS NULL OR ao.item_id = sqlc.narg('item_id')::uuid OR EXISTS (
+    SELECT 1 FROM assembly_order_lines ln
+    WHERE ln.assembly_order_id = ao.id AND ln.item_id = sqlc.narg('item_id')::uuid
+  ))
+ORDER BY ao.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListAssemblyOrderLines :many
+SELECT ln.id, ln.assembly_order_id, ln.item_id, ln.quantity,
+       i.sku, i.name
+FROM assembly_order_lines ln
+JOIN items i ON i.id = ln.item_id
+WHERE ln.assembly_order_id = $1
+ORDER BY i.sku ASC;
+
+-- name: UpdateAssemblyOrderStatus :exec
+UPDATE assembly_orders
+SET status = $2,
+    completed_at = $3,
+    updated_at = $4
+WHERE id = $1;
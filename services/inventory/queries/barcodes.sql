@@ -0,0 +1,21 @@
+-- name: CreateItemBarcode :exec
+INSERT INTO item_barcodes (id, item_id, code, type, pack_quantity, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetItemBarcodeByCode :one
+SELECT id, item_id, code, type, pack_quantity, created_at
+FROM item_barcodes
+WHERE code = $1;
+
+-- name: ListItemBarcodesByItemID :many
+SELECT id, item_id, code, type, pack_quantity, created_at
+FROM item_barcodes
+WHERE item_id = $1
+ORDER BY created_at ASC, code ASC;
+
+-- name: DeleteItemBarcode :exec
+DELETE FROM item_barcodes
+WHERE id = $1;
+
+-- name: NextInternalBarcodeNumber :one
+SELECT nextval('item_barcode_numbers')::bigint AS number;
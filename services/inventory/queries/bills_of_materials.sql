@@ -0,0 +1,49 @@
+-- name: UpsertBillOfMaterials :exec
+INSERT INTO bills_of_materials (item_id, kit, created_at, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_id) DO UPDATE
+SET kit = EXCLUDED.kit,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetBillOfMaterials :one
+SELECT item_id, kit, created_at, updated_at
+FROM bills_of_materials
+WHERE item_id = $1;
+
+-- name: DeleteBillOfMaterials :exec
+DELETE FROM bills_of_materials
+WHERE item_id = $1;
+
+-- name: CreateBOMComponent :exec
+INSERT INTO bom_components (id, item_id, component_item_id, quantity)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteBOMComponentsByItemID :exec
+DELETE FROM bom_components
+WHERE item_id = $1;
+
+-- name: ListBOMComponents :many
+SELECT bc.id, bc.item_id, bc.component_item_id, bc.quantity,
+       i.sku, i.name
+FROM bom_components bc
+JOIN items i ON i.id = bc.component_item_id
+WHERE bc.item_id = $1
+ORDER BY i.sku ASC;
+
+-- name: ListBOMParentItemIDs :many
+WITH RECURSIVE parents AS (
+    SELECT bc.item_id
+    FROM bom_components bc
+    WHERE bc.component_item_id = $1
+    UNION
+    SELECT bc.item_id
+    FROM bom_components bc
+    JOIN parents p ON bc.component_item_id = p.item_id
+)
+SELECT item_id FROM parents;
+
+-- name: IsBOMComponent :one
+SELECT EXISTS (
+    SELECT 1 FROM bom_components
+    WHERE component_item_id = $1
+) AS is_component;
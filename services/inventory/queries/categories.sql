@@ -0,0 +1,87 @@
+-- name: CreateCategory :exec
+INSERT INTO categories (id, parent_id, name, description, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetCategoryByID :one
+SELECT id, parent_id, name, description, created_at, updated_at
+FROM categories
+WHERE id = $1;
+
+-- name: ListCategories :many
+SELECT id, parent_id, name, description, created_at, updated_at
+FROM categories
+WHERE (sqlc.narg('parent_id')::uuid IS NULL OR parent_id = sqlc.narg('parent_id')::uuid)
+ORDER BY name ASC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateCategory :exec
+UPDATE categories
+SET parent_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: DeleteCategory :exec
+DELETE FROM categories
+WHERE id = $1;
+
+-- name: ListCategoryDescendantIDs :many
+WITH RECURSIVE tree AS (
+    SELECT categories.id FROM categories WHERE categories.id = $1
+    UNION ALL
+    SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+)
+SELECT id FROM tree;
+
+-- name: CreateCategoryAttribute :exec
+INSERT INTO category_attributes (id, category_id, name, type, required, options, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: UpdateCategoryAttribute :exec
+UPDATE category_attributes
+SET type = $2,
+    required = $3,
+    options = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: DeleteCategoryAttribute :exec
+DELETE FROM category_attributes
+WHERE id = $1;
+
+-- name: ListCategoryAttributesByCategoryID :many
+SELECT id, category_id, name, type, required, options, created_at, updated_at
+FROM category_attributes
+WHERE category_id = $1
+ORDER BY name ASC;
+
+-- name: ListInheritedCategoryAttributes :many
+WITH RECURSIVE path AS (
+    SELECT categories.id, categories.parent_id, 0 AS depth FROM categories WHERE categories.id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, p.depth + 1 FROM categories c JOIN path p ON c.id = p.parent_id
+)
+SELECT a.id, a.category_id, a.name, a.type, a.required, a.options, a.created_at, a.updated_at
+FROM category_attributes a
+JOIN path p ON p.id = a.category_id
+ORDER BY p.depth DESC, a.name ASC;
+
+-- name: CreateItemAttributeValue :exec
+INSERT INTO item_attribute_values (item_id, attribute_id, value)
+VALUES ($1, $2, $3);
+
+-- name: DeleteItemAttributeValuesByItemID :exec
+DELETE FROM item_attribute_values
+WHERE item_id = $1;
+
+-- name: DeleteItemAttributeValuesByAttributeID :exec
+DELETE FROM item_attribute_values
+WHERE attribute_id = $1;
+
+-- name: ListItemAttributeValuesByItemIDs :many
+SELECT v.item_id, v.attribute_id, a.name, v.value
+FROM item_attribute_values v
+JOIN category_attributes a ON a.id = v.attribute_id
+WHERE v.item_id = ANY(sqlc.arg('item_ids')::uuid[])
+ORDER BY a.name ASC;
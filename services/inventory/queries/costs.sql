@@ -0,0 +1,52 @@
+-- name: GetItemCostingMethodForUpdate :one
+SELECT costing_method
+FROM items
+WHERE id = $1
+FOR NO KEY UPDATE;
+
+-- name: SumItemCost :one
+SELECT COALESCE(SUM(quantity_delta), 0)::integer AS quantity,
+       COALESCE(SUM(value_delta), 0)::numeric AS value
+FROM cost_entries
+WHERE item_id = $1;
+
+-- name: CreateCostLayer :exec
+INSERT INTO cost_layers (id, item_id, quantity, remaining_quantity, unit_cost, source_document_id, created_at)
+VALUES ($1, $2, $3, $3, $4, $5, $6);
+
+-- name: ListOpenCostLayersForUpdate :many
+SELECT id, item_id, quantity, remaining_quantity, unit_cost, source_document_id, created_at
+FROM cost_layers
+WHERE item_id = $1 AND remaining_quantity > 0
+ORDER BY created_at ASC, id ASC
+FOR UPDATE;
+
+-- name: ConsumeCostLayer :exec
+UPDATE cost_layers
+SET remaining_quantity = remaining_quantity - $2
+WHERE id = $1;
+
+-- name: CreateCostEntry :exec
+INSERT INTO cost_entries (id, item_id, quantity_delta, value_delta, reason, source_document_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListItemValuations :many
+SELECT i.id, i.sku, i.name, i.costing_method,
+       SUM(ce.quantity_delta)::integer AS quantity,
+       SUM(ce.value_delta)::numeric AS value
+FROM cost_entries ce
+JOIN items i ON i.id = ce.item_id
+WHERE ce.created_at < sqlc.arg(before)::timestamp
+GROUP BY i.id
+HAVING SUM(ce.quantity_delta) <> 0 OR SUM(ce.value_delta) <> 0
+ORDER BY i.sku ASC;
+
+-- name: ListOrderCostOfGoodsSold :many
+SELECT i.id, i.sku, i.name,
+       (-SUM(ce.quantity_delta))::integer AS quantity,
+       (-SUM(ce.value_delta))::numeric AS cost
+FROM cost_entries ce
+JOIN items i ON i.id = ce.item_id
+WHERE ce.source_document_id = $1 AND ce.reason = 'sale'
+GROUP BY i.id
+ORDER BY i.sku ASC;
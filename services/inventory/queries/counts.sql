@@ -0,0 +1,99 @@
+-- name: CreateStockCount :exec
+INSERT INTO stock_counts (id, warehouse_id, status, notes, snapshot_at, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetStockCountByID :one
+SELECT sc.id, sc.warehouse_id, sc.status, sc.notes, sc.snapshot_at, sc.created_by, sc.posted_by, sc.posted_at, sc.created_at, sc.updated_at,
+       w.code AS warehouse_code
+FROM stock_counts sc
+JOIN warehouses w ON w.id = sc.warehouse_id
+WHERE sc.id = $1;
+
+-- name: GetStockCountForUpdate :one
+SELECT id, warehouse_id, status, notes, snapshot_at, created_by, posted_by, posted_at, created_at, updated_at
+FROM stock_counts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStockCounts :many
+SELECT sc.id, sc.warehouse_id, sc.status, sc.notes, sc.snapshot_at, sc.created_by, sc.posted_by, sc.posted_at, sc.created_at, sc.updated_at,
+       w.code AS warehouse_code
+FROM stock_counts sc
+JOIN warehouses w ON w.id = sc.warehouse_id
+WHERE (sqlc.narg('status')::varchar IS NULL OR sc.status = sqlc.narg('status'))
+ORDER BY sc.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateStockCountStatus :exec
+UPDATE stock_counts
+SET status = $2,
+    posted_by = $3,
+    posted_at = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: SnapshotStockCountLines :exec
+INSERT INTO stock_count_lines (id, count_id, item_id, location_id, expected_quantity)
+SELECT uuid_generate_v4(), sqlc.arg(count_id), s.item_id, s.location_id, s.quantity
+FROM stock s
+JOIN locations l ON l.id = s.location_id
+JOIN items i ON i.id = s.item_id
+WHERE l.warehouse_id = sqlc.arg(warehouse_id)
+  AND NOT i.serialized
+  AND i.archived_at IS NULL
+  AND (sqlc.narg('item_ids')::uuid[] IS NULL OR s.item_id = ANY(sqlc.narg('item_ids')::uuid[]))
+FOR SHARE OF s;
+
+-- name: SnapshotUnstockedCountLines :exec
+INSERT INTO stock_count_lines (id, count_id, item_id, location_id, expected_quantity)
+SELECT uuid_generate_v4(), sqlc.arg(count_id), i.id, sqlc.arg(location_id), 0
+FROM items i
+WHERE NOT i.serialized
+  AND i.archived_at IS NULL
+  AND (sqlc.narg('item_ids')::uuid[] IS NULL OR i.id = ANY(sqlc.narg('item_ids')::uuid[]))
+  AND NOT EXISTS (
+      SELECT 1 FROM stock_count_lines scl
+      WHERE scl.count_id = sqlc.arg(count_id) AND scl.item_id = i.id
+  );
+
+-- name: StockCountIncludesItem :one
+SELECT EXISTS (
+    SELECT 1 FROM stock_count_lines
+    WHERE count_id = $1 AND item_id = $2
+) AS included;
+
+-- name: RecordStockCountLine :exec
+INSERT INTO stock_count_lines (id, count_id, item_id, location_id, expected_quantity, counted_quantity, counted_by, counted_at)
+VALUES ($1, $2, $3, $4, 0, $5, $6, $7)
+ON CONFLICT (count_id, item_id, location_id) DO UPDATE
+SET counted_quantity = EXCLUDED.counted_quantity,
+    counted_by = EXCLUDED.counted_by,
+    counted_at = EXCLUDED.counted_at;
+
+-- name: ListStockCountLines :many
+SELECT scl.id, scl.count_id, scl.item_id, scl.location_id, scl.expected_quantity, scl.counted_quantity, scl.counted_by, scl.counted_at, scl.adjustment_quantity,
+       i.sku, i.name, l.code AS location_code,
+       (scl.expected_quantity + COALESCE((
+           SELECT SUM(sm.quantity_delta)
+           FROM stock_movements sm
+           WHERE sm.item_id = scl.item_id
+             AND sm.location_id = scl.location_id
+             AND sm.created_at > sc.snapshot_at
+             AND sm.created_at <= scl.counted_at
+       ), 0))::integer AS expected_at_count
+FROM stock_count_lines scl
+JOIN stock_counts sc ON sc.id = scl.count_id
+JOIN items i ON i.id = scl.item_id
+JOIN locations l ON l.id = scl.location_id
+WHERE scl.count_id = $1
+ORDER BY i.sku ASC, l.code ASC;
+
+-- name: SetStockCountLineAdjustment :exec
+UPDATE stock_count_lines
+SET adjustment_quantity = $2
+WHERE id = $1;
+
+-- name: SetStockCountSnapshotAt :exec
+UPDATE stock_counts
+SET snapshot_at = $2
+WHERE id = $1;
@@ -0,0 +1,4 @@
+-- name: CreateProcessedEvent :execrows
+INSERT INTO processed_events (event_type, source_id, item_id, processed_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
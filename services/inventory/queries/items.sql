@@ -1,22 +1,31 @@
 -- name: CreateItem :exec
-INSERT INTO items (id, name, description, sku, unit_price, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO items (id, name, description, sku, unit_price, reorder_point, reorder_quantity, preferred_vendor_id, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17);
 
 -- name: GetItemByID :one
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
 WHERE id = $1;
 
 -- name: GetItemBySKU :one
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
 WHERE sku = $1;
 
 -- name: ListItems :many
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
+WHERE archived_at IS NULL
+  AND (sqlc.narg('category_id')::uuid IS NULL OR category_id IN (
+    WITH RECURSIVE tree AS (
+        SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')::uuid
+        UNION ALL
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+    )
+    SELECT id FROM tree
+  ))
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT $2 OFFSET $3;
 
 -- name: UpdateItem :exec
 UPDATE items
@@ -24,10 +33,115 @@ SET name = $2,
     description = $3,
     sku = $4,
     unit_price = $5,
-    updated_at = $6
+    reorder_point = $6,
+    reorder_quantity = $7,
+    preferred_vendor_id = $8,
+    serialized = $9,
+    costing_method = $10,
+    base_unit = $11,
+    category_id = $12,
+    min_stock = $13,
+    updated_at = $14
 WHERE id = $1;
 
--- name: DeleteItem :exec
-DELETE FROM items
+-- name: LockItem :one
+SELECT id FROM items WHERE id = $1 FOR UPDATE;
+
+-- name: ArchiveItem :execrows
+UPDATE items
+SET archived_at = $2,
+    updated_at = $2
+WHERE id = $1 AND archived_at IS NULL;
+
+-- name: ListItemsForExport :many
+SELECT i.id, i.sku, i.name, i.description, i.unit_price, i.reorder_point, i.reorder_quantity, i.min_stock,
+       i.costing_method, i.base_unit, i.serialized, i.category_id,
+       COALESCE((SELECT SUM(s.quantity) FROM stock s WHERE s.item_id = i.id), 0)::integer AS quantity
+FROM items i
+WHERE i.sku > $1
+  AND i.archived_at IS NULL
+ORDER BY i.sku ASC
+LIMIT $2;
+
+-- name: ListItemsBelowReorderPoint :many
+SELECT i.id, i.name, i.sku, i.reorder_point, i.reorder_quantity, i.preferred_vendor_id, i.reorder_requested_at,
+       COALESCE(SUM(s.quantity), 0)::integer AS quantity
+FROM items i
+LEFT JOIN stock s ON s.item_id = i.id
+WHERE i.reorder_point > 0
+  AND i.archived_at IS NULL
+GROUP BY i.id
+HAVING COALESCE(SUM(s.quantity), 0) <= i.reorder_point
+ORDER BY i.sku ASC;
+
+-- name: MarkReorderRequested :exec
+UPDATE items
+SET reorder_requested_at = $2
 WHERE id = $1;
 
+-- name: ClearReorderRequested :exec
+UPDATE items
+SET reorder_requested_at = NULL
+WHERE id = $1;
+
+-- name: ResetRecoveredReorderRequests :exec
+UPDATE items
+SET reorder_requested_at = NULL
+WHERE reorder_requested_at IS NOT NULL
+  AND (SELECT COALESCE(SUM(stock.quantity), 0) FROM stock WHERE stock.item_id = items.id) > reorder_point;
+
+-- name: SearchItems :many
+SELECT i.id, i.sku, i.name, i.description, i.unit_price, i.category_id,
+       COALESCE(s.quantity, 0)::integer AS quantity,
+       (ts_rank(
+           setweight(to_tsvector('english', i.sku), 'A') ||
+           setweight(to_tsvector('english', i.name), 'A') ||
+           setweight(to_tsvector('english', COALESCE(i.description, '')), 'B'),
+           websearch_to_tsquery('english', sqlc.arg('query'))
+       ) + similarity(i.sku, sqlc.arg('query')))::real AS rank
+FROM items i
+LEFT JOIN (
+    SELECT stock.item_id, SUM(stock.quantity) AS quantity
+    FROM stock
+    GROUP BY stock.item_id
+) s ON s.item_id = i.id
+LEFT JOIN (
+    SELECT stock_reservations.item_id, SUM(stock_reservations.quantity) AS quantity
+    FROM stock_reservations
+    WHERE stock_reservations.expires_at IS NULL OR stock_reservations.expires_at > sqlc.arg('as_of')::timestamp
+    GROUP BY stock_reservations.item_id
+) r ON r.item_id = i.id
+WHERE i.archived_at IS NULL
+  AND (
+        setweight(to_tsvector('english', i.sku), 'A') ||
+        setweight(to_tsvector('english', i.name), 'A') ||
+        setweight(to_tsvector('english', COALESCE(i.description, '')), 'B')
+        @@ websearch_to_tsquery('english', sqlc.arg('query'))
+     OR i.sku % sqlc.arg('query')
+     OR i.sku ILIKE '%' || replace(replace(replace(sqlc.arg('query'), '\', '\\'), '%', '\%'), '_', '\_') || '%'
+  )
+  AND (sqlc.narg('min_price')::numeric IS NULL OR i.unit_price >= sqlc.narg('min_price')::numeric)
+  AND (sqlc.narg('max_price')::numeric IS NULL OR i.unit_price <= sqlc.narg('max_price')::numeric)
+  AND (sqlc.narg('in_stock')::boolean IS NULL OR (COALESCE(s.quantity, 0) - COALESCE(r.quantity, 0) > 0) = sqlc.narg('in_stock')::boolean)
+  AND (sqlc.narg('category_id')::uuid IS NULL OR i.category_id IN (
+    WITH RECURSIVE tree AS (
+        SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')::uuid
+        UNION ALL
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+    )
+    SELECT id FROM tree
+  ))
+ORDER BY rank DESC, i.sku ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: RaiseItemStockAlert :one
+UPDATE items
+SET stock_alert = $2
+WHERE id = $1
+  AND (stock_alert IS NULL OR (stock_alert = 'low' AND $2 = 'depleted'))
+RETURNING id;
+
+-- name: ClearItemStockAlert :exec
+UPDATE items
+SET stock_alert = NULL
+WHERE id = $1 AND stock_alert IS NOT NULL;
@@ -0,0 +1,60 @@
+-- name: UpsertStockLot :one
+INSERT INTO stock_lots (id, item_id, location_id, lot_number, expiry_date, quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, 0, $6, $7)
+ON CONFLICT (item_id, location_id, lot_number) DO UPDATE
+SET expiry_date = COALESCE(EXCLUDED.expiry_date, stock_lots.expiry_date),
+    updated_at = EXCLUDED.updated_at
+RETURNING id;
+
+-- name: GetStockLotByNumberForUpdate :one
+SELECT id, item_id, location_id, lot_number, expiry_date, quantity, created_at, updated_at
+FROM stock_lots
+WHERE item_id = $1 AND location_id = $2 AND lot_number = $3
+FOR UPDATE;
+
+-- name: ListLocationLotsForUpdate :many
+SELECT id, item_id, location_id, lot_number, expiry_date, quantity, created_at, updated_at
+FROM stock_lots
+WHERE item_id = $1 AND location_id = $2 AND quantity > 0
+ORDER BY expiry_date ASC NULLS LAST, created_at ASC
+FOR UPDATE;
+
+-- name: ListWarehouseLotsForUpdate :many
+SELECT sl.id, sl.item_id, sl.location_id, sl.lot_number, sl.expiry_date, sl.quantity, sl.created_at, sl.updated_at
+FROM stock_lots sl
+JOIN locations l ON l.id = sl.location_id
+WHERE sl.item_id = $1 AND l.warehouse_id = $2 AND sl.quantity > 0
+ORDER BY sl.expiry_date ASC NULLS LAST, sl.created_at ASC
+FOR UPDATE OF sl;
+
+-- name: SumLocationLotQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::integer AS quantity
+FROM stock_lots
+WHERE item_id = $1 AND location_id = $2;
+
+-- name: AdjustStockLot :exec
+UPDATE stock_lots
+SET quantity = quantity + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: ListStockLotsByItemID :many
+SELECT sl.id, sl.item_id, sl.location_id, sl.lot_number, sl.expiry_date, sl.quantity, sl.created_at, sl.updated_at,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code
+FROM stock_lots sl
+JOIN locations l ON l.id = sl.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE sl.item_id = $1 AND sl.quantity > 0
+ORDER BY sl.expiry_date ASC NULLS LAST, w.code ASC, l.code ASC;
+
+-- name: ListExpiringLots :many
+SELECT sl.id, sl.item_id, sl.location_id, sl.lot_number, sl.expiry_date, sl.quantity, sl.created_at, sl.updated_at,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code, i.sku, i.name
+FROM stock_lots sl
+JOIN items i ON i.id = sl.item_id
+JOIN locations l ON l.id = sl.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE sl.quantity > 0
+  AND sl.expiry_date IS NOT NULL
+  AND sl.expiry_date <= sqlc.arg(expires_on_or_before)::date
+ORDER BY sl.expiry_date ASC, i.sku ASC;
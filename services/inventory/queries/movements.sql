@@ -0,0 +1,12 @@
+-- name: CreateStockMovement :exec
+INSERT INTO stock_movements (id, item_id, location_id, quantity_delta, balance_after, reason, source_document_id, user_id, created_at, lot_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListStockMovementsByItemID :many
+SELECT id, item_id, location_id, quantity_delta, balance_after, reason, source_document_id, user_id, created_at, lot_id
+FROM stock_movements
+WHERE item_id = $1
+  AND (sqlc.narg('from_date')::timestamp IS NULL OR created_at >= sqlc.narg('from_date'))
+  AND (sqlc.narg('to_date')::timestamp IS NULL OR created_at < sqlc.narg('to_date'))
+ORDER BY created_at DESC, id DESC
+LIMIT $4 OFFSET $5;
@@ -0,0 +1,40 @@
+-- name: CreateItemPrice :exec
+INSERT INTO item_prices (id, item_id, unit_price, effective_from, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetItemPriceByID :one
+SELECT id, item_id, unit_price, effective_from, created_at
+FROM item_prices
+WHERE id = $1;
+
+-- name: GetItemPriceAt :one
+SELECT id, item_id, unit_price, effective_from, created_at
+FROM item_prices
+WHERE item_id = $1 AND effective_from <= $2
+ORDER BY effective_from DESC
+LIMIT 1;
+
+-- name: ListItemPrices :many
+SELECT id, item_id, unit_price, effective_from, created_at
+FROM item_prices
+WHERE item_id = $1
+ORDER BY effective_from DESC
+LIMIT $2 OFFSET $3;
+
+-- name: DeleteItemPrice :exec
+DELETE FROM item_prices
+WHERE id = $1;
+
+-- name: ApplyDueItemPrices :many
+UPDATE items
+SET unit_price = due.unit_price,
+    updated_at = sqlc.arg(as_of)
+FROM (
+    SELECT DISTINCT ON (item_prices.item_id) item_prices.item_id, item_prices.unit_price
+    FROM item_prices
+    WHERE item_prices.effective_from <= sqlc.arg(as_of)
+    ORDER BY item_prices.item_id, item_prices.effective_from DESC
+) due
+WHERE items.id = due.item_id
+  AND items.unit_price <> due.unit_price
+RETURNING items.id, items.unit_price;
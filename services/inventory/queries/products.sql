@@ -0,0 +1,49 @@
+-- name: CreateProduct :exec
+INSERT INTO products (id, sku, name, description, unit_price, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetProductByID :one
+SELECT id, sku, name, description, unit_price, created_at, updated_at
+FROM products
+WHERE id = $1;
+
+-- name: ListProducts :many
+SELECT id, sku, name, description, unit_price, created_at, updated_at
+FROM products
+ORDER BY sku ASC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateProduct :exec
+UPDATE products
+SET name = $2,
+    description = $3,
+    unit_price = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: CreateProductAxis :exec
+INSERT INTO product_axes (id, product_id, name, position, axis_values)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteProductAxesByProductID :exec
+DELETE FROM product_axes
+WHERE product_id = $1;
+
+-- name: ListProductAxesByProductIDs :many
+SELECT id, product_id, name, position, axis_values
+FROM product_axes
+WHERE product_id = ANY(sqlc.arg('product_ids')::uuid[])
+ORDER BY product_id, position ASC;
+
+-- name: ListProductVariants :many
+SELECT i.id, i.sku, i.name, i.unit_price, i.variant_options,
+       COALESCE((SELECT SUM(s.quantity) FROM stock s WHERE s.item_id = i.id), 0)::integer AS quantity,
+       COALESCE((
+           SELECT SUM(ln.quantity)
+           FROM stock_transfer_lines ln
+           JOIN stock_transfers st ON st.id = ln.transfer_id
+           WHERE ln.item_id = i.id AND st.status = 'in_transit'
+       ), 0)::integer AS in_transit
+FROM items i
+WHERE i.product_id = $1
+ORDER BY i.sku ASC;
@@ -0,0 +1,40 @@
+-- name: CreateStockReservation :exec
+INSERT INTO stock_reservations (id, item_id, warehouse_id, quantity, source_type, source_id, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListStockReservationsBySource :many
+SELECT id, item_id, warehouse_id, quantity, source_type, source_id, expires_at, created_at
+FROM stock_reservations
+WHERE source_type = $1 AND source_id = $2
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamp)
+ORDER BY created_at, item_id;
+
+-- name: ListStockReservationsByItemID :many
+SELECT id, item_id, warehouse_id, quantity, source_type, source_id, expires_at, created_at
+FROM stock_reservations
+WHERE item_id = $1
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamp)
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: SumReservedQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::integer AS reserved
+FROM stock_reservations
+WHERE item_id = $1
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamp);
+
+-- name: SumWarehouseReservedQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::integer AS reserved
+FROM stock_reservations
+WHERE item_id = sqlc.arg(item_id)
+  AND warehouse_id = sqlc.arg(warehouse_id)
+  AND NOT (source_type = sqlc.arg(exclude_source_type) AND source_id = sqlc.arg(exclude_source_id))
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamp);
+
+-- name: DeleteStockReservationsBySource :execrows
+DELETE FROM stock_reservations
+WHERE source_type = $1 AND source_id = $2;
+
+-- name: DeleteExpiredStockReservations :execrows
+DELETE FROM stock_reservations
+WHERE expires_at <= sqlc.arg(as_of)::timestamp;
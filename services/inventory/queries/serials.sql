@@ -0,0 +1,55 @@
+-- name: ReceiveStockSerial :one
+INSERT INTO stock_serials (id, item_id, serial_number, status, location_id, created_at, updated_at)
+VALUES ($1, $2, $3, 'in_stock', $4, $5, $6)
+ON CONFLICT (item_id, serial_number) DO UPDATE
+SET status = 'in_stock',
+    location_id = EXCLUDED.location_id,
+    order_id = NULL,
+    return_id = NULL,
+    updated_at = EXCLUDED.updated_at
+WHERE stock_serials.status NOT IN ('in_stock', 'in_transit')
+RETURNING id;
+
+-- name: GetStockSerialForUpdate :one
+SELECT ss.id, ss.item_id, ss.serial_number, ss.status, ss.location_id, ss.order_id, ss.return_id, ss.created_at, ss.updated_at,
+       l.warehouse_id
+FROM stock_serials ss
+JOIN locations l ON l.id = ss.location_id
+WHERE ss.item_id = $1 AND ss.serial_number = $2
+FOR UPDATE OF ss;
+
+-- name: IssueStockSerial :exec
+UPDATE stock_serials
+SET status = $2,
+    order_id = $3,
+    return_id = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: MoveStockSerial :exec
+UPDATE stock_serials
+SET status = $2,
+    location_id = $3,
+    updated_at = $4
+WHERE id = $1;
+
+-- name: ListStockSerialsByItemID :many
+SELECT ss.id, ss.item_id, ss.serial_number, ss.status, ss.location_id, ss.order_id, ss.return_id, ss.created_at, ss.updated_at,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code
+FROM stock_serials ss
+JOIN locations l ON l.id = ss.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE ss.item_id = $1
+  AND (sqlc.narg('status')::varchar IS NULL OR ss.status = sqlc.narg('status'))
+ORDER BY ss.serial_number ASC
+LIMIT $3 OFFSET $4;
+
+-- name: ListStockSerialsBySerialNumber :many
+SELECT ss.id, ss.item_id, ss.serial_number, ss.status, ss.location_id, ss.order_id, ss.return_id, ss.created_at, ss.updated_at,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code, i.sku, i.name
+FROM stock_serials ss
+JOIN items i ON i.id = ss.item_id
+JOIN locations l ON l.id = ss.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE ss.serial_number = $1
+ORDER BY i.sku ASC;
@@ -1,24 +1,41 @@
 -- name: CreateStock :exec
-INSERT INTO stock (id, item_id, quantity, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO stock (id, item_id, location_id, quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
 
--- name: GetStockByItemID :one
-SELECT id, item_id, quantity, created_at, updated_at
-FROM stock
-WHERE item_id = $1;
+-- name: EnsureStock :exec
+INSERT INTO stock (id, item_id, location_id, quantity, created_at, updated_at)
+VALUES ($1, $2, $3, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (item_id, location_id) DO NOTHING;
+
+-- name: ListStockByItemID :many
+SELECT s.id, s.item_id, s.quantity, s.created_at, s.updated_at, s.location_id,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code
+FROM stock s
+JOIN locations l ON l.id = s.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE s.item_id = $1
+ORDER BY w.code ASC, l.code ASC;
 
 -- name: GetStockQuantityForUpdate :one
-SELECT quantity FROM stock WHERE item_id = $1 FOR UPDATE;
+SELECT quantity FROM stock WHERE item_id = $1 AND location_id = $2 FOR UPDATE;
 
--- name: UpdateStock :exec
-UPDATE stock
-SET quantity = $2,
-    updated_at = $3
-WHERE item_id = $1;
+-- name: ListItemStockForUpdate :many
+SELECT location_id, quantity
+FROM stock
+WHERE item_id = $1
+ORDER BY location_id
+FOR UPDATE;
+
+-- name: ListWarehouseStockForUpdate :many
+SELECT s.location_id, s.quantity
+FROM stock s
+JOIN locations l ON l.id = s.location_id
+WHERE s.item_id = $1 AND l.warehouse_id = $2
+ORDER BY l.is_default DESC, l.code ASC
+FOR UPDATE OF s;
 
 -- name: AdjustStock :exec
 UPDATE stock
-SET quantity = quantity + $2,
+SET quantity = quantity + $3,
     updated_at = CURRENT_TIMESTAMP
-WHERE item_id = $1;
-
+WHERE item_id = $1 AND location_id = $2;
@@ -0,0 +1,77 @@
+-- name: CreateStockTransfer :exec
+INSERT INTO stock_transfers (id, source_location_id, destination_location_id, status, notes, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: CreateStockTransferLine :exec
+INSERT INTO stock_transfer_lines (id, transfer_id, item_id, quantity, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CreateStockTransferLot :exec
+INSERT INTO stock_transfer_lots (id, line_id, lot_number, expiry_date, quantity)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CreateStockTransferSerial :exec
+INSERT INTO stock_transfer_serials (id, line_id, serial_number)
+VALUES ($1, $2, $3);
+
+-- name: GetStockTransferByID :one
+SELECT st.id, st.source_location_id, st.destination_location_id, st.status, st.notes, st.created_by, st.shipped_at, st.received_at, st.created_at, st.updated_at,
+       sl.code AS source_location_code, sl.warehouse_id AS source_warehouse_id,
+       dl.code AS destination_location_code, dl.warehouse_id AS destination_warehouse_id
+FROM stock_transfers st
+JOIN locations sl ON sl.id = st.source_location_id
+JOIN locations dl ON dl.id = st.destination_location_id
+WHERE st.id = $1;
+
+-- name: GetStockTransferForUpdate :one
+SELECT id, source_location_id, destination_location_id, status, notes, created_by, shipped_at, received_at, created_at, updated_at
+FROM stock_transfers
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStockTransfers :many
+SELECT st.id, st.source_location_id, st.destination_location_id, st.status, st.notes, st.created_by, st.shipped_at, st.received_at, st.created_at, st.updated_at,
+       sl.code AS source_location_code, sl.warehouse_id AS source_warehouse_id,
+       dl.code AS destination_location_code, dl.warehouse_id AS destination_warehouse_id
+FROM stock_transfers st
+JOIN locations sl ON sl.id = st.source_location_id
+JOIN locations dl ON dl.id = st.destination_location_id
+WHERE (sqlc.narg('status')::varchar IS NULL OR st.status = sqlc.narg('status'))
+ORDER BY st.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListStockTransferLines :many
+SELECT ln.id, ln.transfer_id, ln.item_id, ln.quantity, ln.created_at,
+       i.sku, i.name
+FROM stock_transfer_lines ln
+JOIN items i ON i.id = ln.item_id
+WHERE ln.transfer_id = $1
+ORDER BY i.sku ASC;
+
+-- name: ListStockTransferLotsByTransferID :many
+SELECT tl.id, tl.line_id, tl.lot_number, tl.expiry_date, tl.quantity
+FROM stock_transfer_lots tl
+JOIN stock_transfer_lines ln ON ln.id = tl.line_id
+WHERE ln.transfer_id = $1
+ORDER BY tl.expiry_date ASC NULLS LAST, tl.lot_number ASC;
+
+-- name: ListStockTransferSerialsByTransferID :many
+SELECT ts.id, ts.line_id, ts.serial_number
+FROM stock_transfer_serials ts
+JOIN stock_transfer_lines ln ON ln.id = ts.line_id
+WHERE ln.transfer_id = $1
+ORDER BY ts.serial_number ASC;
+
+-- name: UpdateStockTransferStatus :exec
+UPDATE stock_transfers
+SET status = $2,
+    shipped_at = $3,
+    received_at = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: SumInTransitQuantity :one
+SELECT COALESCE(SUM(ln.quantity), 0)::integer AS in_transit
+FROM stock_transfer_lines ln
+JOIN stock_transfers st ON st.id = ln.transfer_id
+WHERE ln.item_id = $1 AND st.status = 'in_transit';
@@ -0,0 +1,13 @@
+-- name: CreateItemUnit :exec
+INSERT INTO item_units (id, item_id, unit, conversion_factor)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteItemUnitsByItemID :exec
+DELETE FROM item_units
+WHERE item_id = $1;
+
+-- name: ListItemUnitsByItemIDs :many
+SELECT id, item_id, unit, conversion_factor
+FROM item_units
+WHERE item_id = ANY(sqlc.arg('item_ids')::uuid[])
+ORDER BY conversion_factor ASC, unit ASC;
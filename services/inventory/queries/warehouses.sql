@@ -0,0 +1,48 @@
+-- name: CreateWarehouse :exec
+INSERT INTO warehouses (id, code, name, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetWarehouseByID :one
+SELECT id, code, name, created_at, updated_at
+FROM warehouses
+WHERE id = $1;
+
+-- name: ListWarehouses :many
+SELECT id, code, name, created_at, updated_at
+FROM warehouses
+ORDER BY code ASC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateWarehouse :exec
+UPDATE warehouses
+SET code = $2,
+    name = $3,
+    updated_at = $4
+WHERE id = $1;
+
+-- name: CreateLocation :exec
+INSERT INTO locations (id, warehouse_id, code, name, is_default, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetLocationByID :one
+SELECT id, warehouse_id, code, name, is_default, created_at, updated_at
+FROM locations
+WHERE id = $1;
+
+-- name: GetDefaultLocationByWarehouseID :one
+SELECT id, warehouse_id, code, name, is_default, created_at, updated_at
+FROM locations
+WHERE warehouse_id = $1 AND is_default;
+
+-- name: ListLocationsByWarehouseID :many
+SELECT id, warehouse_id, code, name, is_default, created_at, updated_at
+FROM locations
+WHERE warehouse_id = $1
+ORDER BY is_default DESC, code ASC;
+
+-- name: UpdateLocation :exec
+UPDATE locations
+SET code = $2,
+    name = $3,
+    updated_at = $4
+WHERE id = $1;
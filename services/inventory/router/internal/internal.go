@@ -17,6 +17,30 @@ func InitInventoryRoutes(router chi.Router, handler *httphandler.Handler, authMi
 			Handler:     handler.ListItems,
 			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/search",
+			Handler:     handler.SearchItems,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/export",
+			Handler:     handler.ExportItems,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/items/import",
+			Handler:     handler.ImportItems,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/reorder-suggestions",
+			Handler:     handler.ListReorderSuggestions,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
 		{
 			Method:      http.MethodGet,
 			Path:        "/items/{id}",
@@ -41,6 +65,60 @@ func InitInventoryRoutes(router chi.Router, handler *httphandler.Handler, authMi
 			Handler:     handler.DeleteItem,
 			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/categories",
+			Handler:     handler.ListCategories,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/categories/{id}",
+			Handler:     handler.GetCategory,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/categories",
+			Handler:     handler.CreateCategory,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/categories/{id}",
+			Handler:     handler.UpdateCategory,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/categories/{id}",
+			Handler:     handler.DeleteCategory,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/products",
+			Handler:     handler.ListProducts,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/products/{id}",
+			Handler:     handler.GetProduct,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/products",
+			Handler:     handler.CreateProduct,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/products/{id}",
+			Handler:     handler.UpdateProduct,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
 		{
 			Method:      http.MethodGet,
 			Path:        "/items/{item_id}/stock",
@@ -53,6 +131,288 @@ func InitInventoryRoutes(router chi.Router, handler *httphandler.Handler, authMi
 			Handler:     handler.AdjustStock,
 			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/movements",
+			Handler:     handler.ListStockMovements,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/lots",
+			Handler:     handler.ListStockLots,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/serials",
+			Handler:     handler.ListStockSerials,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/by-barcode/{code}",
+			Handler:     handler.GetItemByBarcode,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/barcodes",
+			Handler:     handler.ListItemBarcodes,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/items/{item_id}/barcodes",
+			Handler:     handler.CreateItemBarcode,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/items/{item_id}/barcodes/{code}",
+			Handler:     handler.DeleteItemBarcode,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/barcodes/{code}/label",
+			Handler:     handler.GetBarcodeLabel,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/prices",
+			Handler:     handler.ListItemPrices,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/items/{item_id}/prices",
+			Handler:     handler.SchedulePriceChange,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/items/{item_id}/prices/{price_id}",
+			Handler:     handler.CancelPriceChange,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/price",
+			Handler:     handler.GetItemPrice,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/reservations",
+			Handler:     handler.ListItemReservations,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/reservations/{source_type}/{source_id}",
+			Handler:     handler.ListSourceReservations,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/reservations/{source_type}/{source_id}",
+			Handler:     handler.ReserveStock,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/reservations/{source_type}/{source_id}",
+			Handler:     handler.ReleaseReservations,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/items/{item_id}/bom",
+			Handler:     handler.GetBillOfMaterials,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/items/{item_id}/bom",
+			Handler:     handler.SaveBillOfMaterials,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/items/{item_id}/bom",
+			Handler:     handler.DeleteBillOfMaterials,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/serials/{serial_number}",
+			Handler:     handler.LookupSerial,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/lots/expiring",
+			Handler:     handler.ListExpiringLots,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/counts",
+			Handler:     handler.ListStockCounts,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/counts",
+			Handler:     handler.CreateStockCount,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/counts/{id}",
+			Handler:     handler.GetStockCount,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/counts/{id}/lines",
+			Handler:     handler.RecordStockCounts,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/counts/{id}/post",
+			Handler:     handler.PostStockCount,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/counts/{id}/cancel",
+			Handler:     handler.CancelStockCount,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/transfers",
+			Handler:     handler.ListStockTransfers,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/transfers",
+			Handler:     handler.CreateStockTransfer,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/transfers/{id}",
+			Handler:     handler.GetStockTransfer,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/transfers/{id}/ship",
+			Handler:     handler.ShipStockTransfer,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/transfers/{id}/receive",
+			Handler:     handler.ReceiveStockTransfer,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/transfers/{id}/cancel",
+			Handler:     handler.CancelStockTransfer,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/assembly-orders",
+			Handler:     handler.ListAssemblyOrders,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/assembly-orders",
+			Handler:     handler.CreateAssemblyOrder,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/assembly-orders/{id}",
+			Handler:     handler.GetAssemblyOrder,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/assembly-orders/{id}/complete",
+			Handler:     handler.CompleteAssemblyOrder,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/assembly-orders/{id}/cancel",
+			Handler:     handler.CancelAssemblyOrder,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/valuation",
+			Handler:     handler.GetValuation,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/sales-orders/{order_id}/cogs",
+			Handler:     handler.GetOrderCostOfGoodsSold,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/warehouses",
+			Handler:     handler.ListWarehouses,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/warehouses/{id}",
+			Handler:     handler.GetWarehouse,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/warehouses",
+			Handler:     handler.CreateWarehouse,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/warehouses/{id}",
+			Handler:     handler.UpdateWarehouse,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/warehouses/{id}/locations",
+			Handler:     handler.ListLocations,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/warehouses/{id}/locations",
+			Handler:     handler.CreateLocation,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/locations/{id}",
+			Handler:     handler.UpdateLocation,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
 	}
 
 	routerpkg.RegisterRoutes(router, routes)
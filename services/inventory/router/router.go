@@ -27,7 +27,7 @@ func NewRouter(handler *httphandler.Handler, logger log.Logger, jwtSecret string
 	r.Use(middleware.TraceMiddleware(logger))
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
-	r.Use(chiMiddleware.AllowContentType("application/json"))
+	r.Use(chiMiddleware.AllowContentType("application/json", "text/csv"))
 	r.Use(middleware.TimeoutMiddleware(30 * time.Second))
 
 	healthCheck := health.New("inventory-service", "1.0.0")
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkStockLevel compares an item's stock on hand with its minimum after a stock change. It
+// publishes inventory.stock.depleted when nothing is left and inventory.stock.low when stock is
+// at or below the minimum. An alert is published once; it is not repeated until stock rises above
+// the minimum again, except that a low item running out is still reported as depleted.
+func (s *Service) checkStockLevel(ctx context.Context, itemID string) {
+	item, err := s.storage.GetItemByID(ctx, itemID)
+	if err != nil {
+		s.logger.Error(ctx, "failed to get item for stock level check", zap.String("item_id", itemID), zap.Error(err))
+		return
+	}
+
+	stock, err := s.storage.GetStockByItemID(ctx, itemID)
+	if err != nil {
+		s.logger.Error(ctx, "failed to get stock for stock level check", zap.String("item_id", itemID), zap.Error(err))
+		return
+	}
+
+	var level model.StockAlertLevel
+	switch {
+	case stock.Quantity <= 0:
+		level = model.StockAlertLevelDepleted
+	case stock.Quantity <= item.MinStock:
+		level = model.StockAlertLevelLow
+	default:
+		if err := s.storage.ClearStockAlert(ctx, item.ID); err != nil {
+			s.logger.Error(ctx, "failed to clear stock alert", zap.String("item_id", itemID), zap.Error(err))
+		}
+		return
+	}
+
+	raised, err := s.storage.RaiseStockAlert(ctx, item.ID, level)
+	if err != nil {
+		s.logger.Error(ctx, "failed to raise stock alert", zap.String("item_id", itemID), zap.Error(err))
+		return
+	}
+	if !raised {
+		return
+	}
+
+	subject := "inventory.stock." + level.String()
+	event := map[string]interface{}{
+		"event_type": subject,
+		"item_id":    item.ID.String(),
+		"sku":        item.SKU,
+		"name":       item.Name,
+		"quantity":   stock.Quantity,
+		"min_stock":  item.MinStock,
+		"timestamp":  time.Now().Format(time.RFC3339),
+	}
+
+	if err := s.natsClient.Publish(subject, event); err != nil {
+		s.logger.Error(ctx, "failed to publish stock alert event",
+			zap.String("event_type", subject),
+			zap.String("item_id", itemID),
+			zap.Error(err),
+		)
+		// Let the next stock change raise the alert again
+		if err := s.storage.ClearStockAlert(ctx, item.ID); err != nil {
+			s.logger.Error(ctx, "failed to clear stock alert", zap.String("item_id", itemID), zap.Error(err))
+		}
+		return
+	}
+
+	s.logger.Info(ctx, "published stock alert event",
+		zap.String("event_type", subject),
+		zap.String("item_id", itemID),
+		zap.Int("quantity", stock.Quantity),
+	)
+}
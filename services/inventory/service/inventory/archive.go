@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeleteItem archives an item rather than deleting it, so the orders and movements that refer to
+// it can still look it up. It is refused while the item has stock on hand or in transit, or while
+// a draft sales, purchase or assembly order includes it. The stock and assembly checks are made
+// again when the item is archived, in the same transaction, so a change made meanwhile is caught.
+func (s *Service) DeleteItem(ctx context.Context, id string) error {
+	item, err := s.storage.GetItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item.ArchivedAt != nil {
+		return nil
+	}
+
+	stock, err := s.storage.GetStockByItemID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if stock.Quantity != 0 || stock.InTransit != 0 {
+		return errors.ErrConflict
+	}
+
+	open, err := s.hasOpenOrders(ctx, id)
+	if err != nil {
+		return err
+	}
+	if open {
+		return errors.ErrConflict
+	}
+
+	if err := s.storage.ArchiveItem(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "archived item", zap.String("item_id", id), zap.String("sku", item.SKU))
+
+	return nil
+}
+
+// hasOpenOrders reports whether a draft assembly, sales or purchase order includes the item
+func (s *Service) hasOpenOrders(ctx context.Context, itemID string) (bool, error) {
+	filter := model.AssemblyOrderFilter{
+		Status: model.AssemblyOrderStatusDraft,
+		ItemID: itemID,
+	}
+	assemblyOrders, err := s.storage.ListAssemblyOrders(ctx, filter, 1, 0)
+	if err != nil {
+		return false, err
+	}
+	if len(assemblyOrders) > 0 {
+		return true, nil
+	}
+
+	token, err := s.getTokenFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	salesOrders, err := s.salesClient.ListOpenOrdersByItemID(ctx, itemID, 1, token)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list open sales orders", zap.String("item_id", itemID), zap.Error(err))
+		return false, errors.ErrInternalServerError
+	}
+	if len(salesOrders) > 0 {
+		return true, nil
+	}
+
+	purchaseOrders, err := s.purchaseClient.ListOpenOrdersByItemID(ctx, itemID, 1, token)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list open purchase orders", zap.String("item_id", itemID), zap.Error(err))
+		return false, errors.ErrInternalServerError
+	}
+
+	return len(purchaseOrders) > 0, nil
+}
+
+// getTokenFromContext returns the caller's token, or a service token when the call did not come
+// from an HTTP request
+func (s *Service) getTokenFromContext(ctx context.Context) (string, error) {
+	token := ctx.Value(middleware.GetTokenKey())
+	if tokenStr, ok := token.(string); ok && tokenStr != "" {
+		return tokenStr, nil
+	}
+
+	serviceToken, err := s.authClient.GetServiceToken(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to get service token from auth service", zap.Error(err))
+		return "", errors.ErrInternalServerError
+	}
+
+	return serviceToken, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAssemblyOrder drafts an order to build a quantity of an item from the components on its
+// bill of materials. The component quantities are fixed when the order is drafted; nothing
+// moves until it is completed.
+func (s *Service) CreateAssemblyOrder(ctx context.Context, req model.CreateAssemblyOrderRequest) (model.AssemblyOrderWithLines, error) {
+	item, err := s.storage.GetItemByID(ctx, req.ItemID.String())
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return model.AssemblyOrderWithLines{}, errors.ErrBadRequest
+		}
+		return model.AssemblyOrderWithLines{}, err
+	}
+	if item.Serialized || item.ArchivedAt != nil {
+		return model.AssemblyOrderWithLines{}, errors.ErrBadRequest
+	}
+
+	bom, err := s.storage.GetBillOfMaterials(ctx, item.ID.String())
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return model.AssemblyOrderWithLines{}, errors.ErrBadRequest
+		}
+		return model.AssemblyOrderWithLines{}, err
+	}
+	if bom.Kit {
+		return model.AssemblyOrderWithLines{}, errors.ErrBadRequest
+	}
+
+	warehouseID := s.defaultWarehouseID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+	if _, err := s.storage.GetWarehouseByID(ctx, warehouseID.String()); err != nil {
+		if err == errors.ErrNotFound {
+			return model.AssemblyOrderWithLines{}, errors.ErrBadRequest
+		}
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	lines := make([]model.AssemblyOrderLine, 0, len(bom.Components))
+	for _, component := range bom.Components {
+		lines = append(lines, model.AssemblyOrderLine{
+			ID:       uuid.New(),
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity * req.Quantity,
+		})
+	}
+
+	order := model.AssemblyOrder{
+		ID:          uuid.New(),
+		ItemID:      item.ID,
+		WarehouseID: warehouseID,
+		Quantity:    req.Quantity,
+		Status:      model.AssemblyOrderStatusDraft,
+		Notes:       strings.TrimSpace(req.Notes),
+		CreatedBy:   middleware.GetUserIDFromContext(ctx),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.storage.CreateAssemblyOrder(ctx, order, lines); err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	return s.GetAssemblyOrder(ctx, order.ID.String())
+}
+
+func (s *Service) GetAssemblyOrder(ctx context.Context, id string) (model.AssemblyOrderWithLines, error) {
+	order, err := s.storage.GetAssemblyOrderByID(ctx, id)
+	if err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	lines, err := s.storage.ListAssemblyOrderLines(ctx, id)
+	if err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	return model.AssemblyOrderWithLines{
+		AssemblyOrder: order,
+		Lines:         lines,
+	}, nil
+}
+
+func (s *Service) ListAssemblyOrders(ctx context.Context, filter model.AssemblyOrderFilter, limit, offset int) ([]model.AssemblyOrder, error) {
+	return s.storage.ListAssemblyOrders(ctx, filter, limit, offset)
+}
+
+// CompleteAssemblyOrder consumes the order's components and receives the finished items
+func (s *Service) CompleteAssemblyOrder(ctx context.Context, id string) (model.AssemblyOrderWithLines, error) {
+	if err := s.storage.CompleteAssemblyOrder(ctx, id, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	order, err := s.GetAssemblyOrder(ctx, id)
+	if err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	for _, line := range order.Lines {
+		s.checkStockLevel(ctx, line.ItemID.String())
+	}
+	s.checkStockLevel(ctx, order.ItemID.String())
+
+	return order, nil
+}
+
+// CancelAssemblyOrder cancels a draft assembly order
+func (s *Service) CancelAssemblyOrder(ctx context.Context, id string) (model.AssemblyOrderWithLines, error) {
+	if err := s.storage.CancelAssemblyOrder(ctx, id); err != nil {
+		return model.AssemblyOrderWithLines{}, err
+	}
+
+	return s.GetAssemblyOrder(ctx, id)
+}
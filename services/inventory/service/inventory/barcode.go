@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// internalBarcodePrefix starts the generated barcodes. GS1 reserves prefix 20 for numbers
+// assigned within a company, so they cannot clash with manufacturers' barcodes.
+const internalBarcodePrefix = "20"
+
+func (s *Service) ListItemBarcodes(ctx context.Context, itemID string) ([]model.ItemBarcode, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListItemBarcodes(ctx, itemID)
+}
+
+// CreateItemBarcode adds a barcode to an item. An EAN-13 or UPC-A code must have a valid check
+// digit; without a code an internal EAN-13 barcode is generated. Archived items take no new
+// barcodes.
+func (s *Service) CreateItemBarcode(ctx context.Context, itemID string, req model.CreateItemBarcodeRequest) (model.ItemBarcode, error) {
+	item, err := s.storage.GetItemByID(ctx, itemID)
+	if err != nil {
+		return model.ItemBarcode{}, err
+	}
+	if item.ArchivedAt != nil {
+		return model.ItemBarcode{}, errors.ErrBadRequest
+	}
+
+	barcode := model.ItemBarcode{
+		ID:           uuid.New(),
+		ItemID:       item.ID,
+		PackQuantity: req.PackQuantity,
+		CreatedAt:    time.Now(),
+	}
+	if barcode.PackQuantity == 0 {
+		barcode.PackQuantity = 1
+	}
+
+	if code := strings.TrimSpace(req.Code); code != "" {
+		gtin, barcodeType, ok := normalizeBarcode(code)
+		if !ok {
+			return model.ItemBarcode{}, errors.ErrBadRequest
+		}
+		barcode.Code = gtin
+		barcode.Type = barcodeType
+	} else {
+		number, err := s.storage.NextInternalBarcodeNumber(ctx)
+		if err != nil {
+			return model.ItemBarcode{}, err
+		}
+		barcode.Code, err = internalBarcode(number)
+		if err != nil {
+			return model.ItemBarcode{}, err
+		}
+		barcode.Type = model.BarcodeTypeInternal
+	}
+
+	if err := s.storage.CreateItemBarcode(ctx, barcode); err != nil {
+		return model.ItemBarcode{}, err
+	}
+
+	return barcode, nil
+}
+
+// DeleteItemBarcode removes one of an item's barcodes
+func (s *Service) DeleteItemBarcode(ctx context.Context, itemID, code string) error {
+	barcode, err := s.itemBarcode(ctx, itemID, code)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.DeleteItemBarcode(ctx, barcode.ID)
+}
+
+// GetItemByBarcode finds the item a scanned EAN-13 or UPC-A barcode identifies. A code with a
+// wrong check digit is rejected rather than looked up, as it is most likely a misread.
+func (s *Service) GetItemByBarcode(ctx context.Context, code string) (model.BarcodeLookup, error) {
+	gtin, _, ok := normalizeBarcode(strings.TrimSpace(code))
+	if !ok {
+		return model.BarcodeLookup{}, errors.ErrBadRequest
+	}
+
+	barcode, err := s.storage.GetItemBarcodeByCode(ctx, gtin)
+	if err != nil {
+		return model.BarcodeLookup{}, err
+	}
+
+	item, err := s.storage.GetItemByID(ctx, barcode.ItemID.String())
+	if err != nil {
+		return model.BarcodeLookup{}, err
+	}
+
+	return model.BarcodeLookup{
+		Barcode: barcode,
+		Item:    item,
+	}, nil
+}
+
+// RenderBarcodeLabel renders a printable label for one of an item's barcodes
+func (s *Service) RenderBarcodeLabel(ctx context.Context, itemID, code string, format model.LabelFormat) ([]byte, error) {
+	barcode, err := s.itemBarcode(ctx, itemID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.storage.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == model.LabelFormatPNG {
+		return renderLabelPNG(barcode)
+	}
+	return renderLabelSVG(item, barcode), nil
+}
+
+// itemBarcode finds a barcode by code, as long as it belongs to the item
+func (s *Service) itemBarcode(ctx context.Context, itemID, code string) (model.ItemBarcode, error) {
+	gtin, _, ok := normalizeBarcode(strings.TrimSpace(code))
+	if !ok {
+		return model.ItemBarcode{}, errors.ErrBadRequest
+	}
+
+	barcode, err := s.storage.GetItemBarcodeByCode(ctx, gtin)
+	if err != nil {
+		return model.ItemBarcode{}, err
+	}
+	if barcode.ItemID.String() != itemID {
+		return model.ItemBarcode{}, errors.ErrNotFound
+	}
+
+	return barcode, nil
+}
+
+// normalizeBarcode checks an EAN-13 or UPC-A code and returns it as a 13-digit GTIN. A UPC-A
+// code is an EAN-13 code with a leading zero, so both forms of it find the same item.
+func normalizeBarcode(code string) (string, model.BarcodeType, bool) {
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", "", false
+		}
+	}
+
+	barcodeType := model.BarcodeTypeEAN13
+	switch len(code) {
+	case 12:
+		code = "0" + code
+		barcodeType = model.BarcodeTypeUPCA
+	case 13:
+	default:
+		return "", "", false
+	}
+
+	if gtinCheckDigit(code[:12]) != code[12] {
+		return "", "", false
+	}
+
+	return code, barcodeType, true
+}
+
+// internalBarcode builds the EAN-13 code for an internal barcode number
+func internalBarcode(number int64) (string, error) {
+	body := fmt.Sprintf("%s%010d", internalBarcodePrefix, number)
+	if len(body) != 12 {
+		return "", errors.ErrInternalServerError
+	}
+
+	return body + string(gtinCheckDigit(body)), nil
+}
+
+// gtinCheckDigit computes the GS1 check digit for the digits before it. Weights alternate 3 and
+// 1, starting with 3 at the rightmost digit.
+func gtinCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
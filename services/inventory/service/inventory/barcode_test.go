@@ -0,0 +1,165 @@
+package service
+
+import (
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"testing"
+)
+
+func TestGTINCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{digits: "400638133393", want: '1'},
+		{digits: "978014300723", want: '4'},
+		{digits: "590123412345", want: '7'},
+		{digits: "03600029145", want: '2'},
+		{digits: "000000000000", want: '0'},
+		{digits: "200000000001", want: '5'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.digits, func(t *testing.T) {
+			if got := gtinCheckDigit(tt.digits); got != tt.want {
+				t.Errorf("gtinCheckDigit(%q) = %c, want %c", tt.digits, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+		wantType model.BarcodeType
+		wantOK   bool
+	}{
+		{name: "EAN-13", code: "4006381333931", wantCode: "4006381333931", wantType: model.BarcodeTypeEAN13, wantOK: true},
+		{name: "UPC-A gets a leading zero", code: "036000291452", wantCode: "0036000291452", wantType: model.BarcodeTypeUPCA, wantOK: true},
+		{name: "UPC-A written as EAN-13", code: "0036000291452", wantCode: "0036000291452", wantType: model.BarcodeTypeEAN13, wantOK: true},
+		{name: "wrong check digit", code: "4006381333932", wantOK: false},
+		{name: "wrong UPC-A check digit", code: "036000291453", wantOK: false},
+		{name: "too short", code: "40063813339", wantOK: false},
+		{name: "too long", code: "40063813339310", wantOK: false},
+		{name: "not digits", code: "40063813339a1", wantOK: false},
+		{name: "spaces", code: "4006381 333931", wantOK: false},
+		{name: "empty", code: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, barcodeType, ok := normalizeBarcode(tt.code)
+			if ok != tt.wantOK {
+				t.Fatalf("normalizeBarcode(%q) ok = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if code != tt.wantCode || barcodeType != tt.wantType {
+				t.Errorf("normalizeBarcode(%q) = %q, %q, want %q, %q", tt.code, code, barcodeType, tt.wantCode, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestInternalBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  int64
+		want    string
+		wantErr bool
+	}{
+		{name: "first number", number: 1, want: "2000000000015"},
+		{name: "largest number", number: 9999999999, want: "2099999999998"},
+		{name: "number too large for the prefix", number: 10000000000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := internalBarcode(tt.number)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("internalBarcode(%d) error = %v, want error %v", tt.number, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("internalBarcode(%d) = %q, want %q", tt.number, got, tt.want)
+			}
+			if _, _, ok := normalizeBarcode(got); !ok {
+				t.Errorf("internalBarcode(%d) = %q is not a valid EAN-13", tt.number, got)
+			}
+		})
+	}
+}
+
+func TestEAN13Modules(t *testing.T) {
+	tests := []struct {
+		code string
+		// parity of the left-hand digits, L (odd number of bars) or G (even)
+		wantParity string
+	}{
+		{code: "0036000291452", wantParity: "LLLLLL"},
+		{code: "4006381333931", wantParity: "LGLLGG"},
+		{code: "5901234123457", wantParity: "LGGLLG"},
+		{code: "9780143007234", wantParity: "LGGLGL"},
+	}
+
+	bits := func(modules []bool) string {
+		var b strings.Builder
+		for _, m := range modules {
+			if m {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		}
+		return b.String()
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			modules, guard := ean13Modules(tt.code)
+			if len(modules) != 95 || len(guard) != 95 {
+				t.Fatalf("ean13Modules() returned %d modules and %d guard flags, want 95", len(modules), len(guard))
+			}
+
+			encoded := bits(modules)
+			if encoded[:3] != "101" || encoded[45:50] != "01010" || encoded[92:] != "101" {
+				t.Errorf("guards = %s %s %s, want 101 01010 101", encoded[:3], encoded[45:50], encoded[92:])
+			}
+			for i, g := range guard {
+				wantGuard := i < 3 || (i >= 45 && i < 50) || i >= 92
+				if g != wantGuard {
+					t.Errorf("module %d guard = %v, want %v", i, g, wantGuard)
+				}
+			}
+
+			for digit := 0; digit < 12; digit++ {
+				start := 3 + 7*digit
+				if digit >= 6 {
+					start += 5
+				}
+				pattern := encoded[start : start+7]
+				bars := strings.Count(pattern, "1")
+
+				if digit >= 6 {
+					// Right-hand digits start with a bar and have an even number of bars
+					if pattern[0] != '1' || pattern[6] != '0' || bars%2 != 0 {
+						t.Errorf("digit %d pattern %s is not a right-hand code", digit+1, pattern)
+					}
+					continue
+				}
+				if pattern[0] != '0' || pattern[6] != '1' {
+					t.Errorf("digit %d pattern %s is not a left-hand code", digit+1, pattern)
+				}
+				parity := "L"
+				if bars%2 == 0 {
+					parity = "G"
+				}
+				if parity[0] != tt.wantParity[digit] {
+					t.Errorf("digit %d pattern %s has parity %s, want %c", digit+1, pattern, parity, tt.wantParity[digit])
+				}
+			}
+		})
+	}
+}
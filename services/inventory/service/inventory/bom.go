@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *Service) GetBillOfMaterials(ctx context.Context, itemID string) (model.BillOfMaterials, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return model.BillOfMaterials{}, err
+	}
+
+	return s.storage.GetBillOfMaterials(ctx, itemID)
+}
+
+// SaveBillOfMaterials creates or replaces the bill of materials of an item. Components are
+// counted in their base unit and cannot be serialized, as assembly and kit sales issue them
+// without naming units; neither can a kit. Archived items cannot be added to one.
+func (s *Service) SaveBillOfMaterials(ctx context.Context, itemID string, req model.UpdateBillOfMaterialsRequest) (model.BillOfMaterials, error) {
+	item, err := s.storage.GetItemByID(ctx, itemID)
+	if err != nil {
+		return model.BillOfMaterials{}, err
+	}
+
+	if item.ArchivedAt != nil || (req.Kit && item.Serialized) {
+		return model.BillOfMaterials{}, errors.ErrBadRequest
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Components))
+	components := make([]model.BOMComponent, 0, len(req.Components))
+	for _, component := range req.Components {
+		if seen[component.ItemID] {
+			return model.BillOfMaterials{}, errors.ErrBadRequest
+		}
+		seen[component.ItemID] = true
+
+		componentItem, err := s.storage.GetItemByID(ctx, component.ItemID.String())
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return model.BillOfMaterials{}, errors.ErrBadRequest
+			}
+			return model.BillOfMaterials{}, err
+		}
+		if componentItem.Serialized || componentItem.ArchivedAt != nil {
+			return model.BillOfMaterials{}, errors.ErrBadRequest
+		}
+
+		components = append(components, model.BOMComponent{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity,
+		})
+	}
+
+	bom := model.BillOfMaterials{
+		ItemID:     item.ID,
+		Kit:        req.Kit,
+		Components: components,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := s.storage.SaveBillOfMaterials(ctx, bom); err != nil {
+		return model.BillOfMaterials{}, err
+	}
+
+	return s.storage.GetBillOfMaterials(ctx, itemID)
+}
+
+func (s *Service) DeleteBillOfMaterials(ctx context.Context, itemID string) error {
+	return s.storage.DeleteBillOfMaterials(ctx, itemID)
+}
+
+// explodeKits replaces the kits among the items of an order event by their components, so that
+// selling a kit issues its components. Item IDs keep the order they first appear in.
+func (s *Service) explodeKits(ctx context.Context, itemIDs []string, quantities map[string]int) ([]string, map[string]int) {
+	exploded := make([]string, 0, len(itemIDs))
+	explodedQuantities := make(map[string]int, len(quantities))
+	add := func(itemID string, quantity int) {
+		if _, seen := explodedQuantities[itemID]; !seen {
+			exploded = append(exploded, itemID)
+		}
+		explodedQuantities[itemID] += quantity
+	}
+
+	for _, itemID := range itemIDs {
+		quantity := quantities[itemID]
+
+		bom, err := s.storage.GetBillOfMaterials(ctx, itemID)
+		if err != nil && err != errors.ErrNotFound {
+			s.logger.Error(ctx, "failed to get bill of materials",
+				zap.String("item_id", itemID),
+				zap.Error(err),
+			)
+		}
+		if err != nil || !bom.Kit {
+			add(itemID, quantity)
+			continue
+		}
+
+		for _, component := range bom.Components {
+			add(component.ItemID.String(), quantity*component.Quantity)
+		}
+	}
+
+	return exploded, explodedQuantities
+}
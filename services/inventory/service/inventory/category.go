@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateCategory creates a category, under a parent when one is given, with its own attributes.
+// An attribute may not share its name with one inherited from the parent's chain.
+func (s *Service) CreateCategory(ctx context.Context, req model.CreateCategoryRequest) (model.CategoryWithAttributes, error) {
+	inherited, err := s.parentCategoryAttributes(ctx, req.ParentID)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	category := model.Category{
+		ID:          uuid.New(),
+		ParentID:    req.ParentID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	attributes, err := newCategoryAttributes(category.ID, req.Attributes, inherited)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	if err := s.storage.CreateCategory(ctx, category, attributes); err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	return s.GetCategory(ctx, category.ID.String())
+}
+
+func (s *Service) GetCategory(ctx context.Context, id string) (model.CategoryWithAttributes, error) {
+	category, err := s.storage.GetCategoryByID(ctx, id)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	attributes, err := s.storage.ListCategoryAttributes(ctx, id)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	result := model.CategoryWithAttributes{
+		Category:   category,
+		Attributes: make([]model.CategoryAttribute, 0, len(attributes)),
+	}
+	for _, attribute := range attributes {
+		if attribute.CategoryID == category.ID {
+			result.Attributes = append(result.Attributes, attribute)
+		} else {
+			result.InheritedAttributes = append(result.InheritedAttributes, attribute)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) ListCategories(ctx context.Context, filter model.CategoryFilter, limit, offset int) ([]model.Category, error) {
+	return s.storage.ListCategories(ctx, filter, limit, offset)
+}
+
+// UpdateCategory renames or moves a category and replaces its attributes. A category cannot be
+// moved under itself or one of its descendants.
+func (s *Service) UpdateCategory(ctx context.Context, id string, req model.UpdateCategoryRequest) (model.CategoryWithAttributes, error) {
+	category, err := s.storage.GetCategoryByID(ctx, id)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	if req.ParentID != nil && *req.ParentID == category.ID {
+		return model.CategoryWithAttributes{}, errors.ErrBadRequest
+	}
+
+	inherited, err := s.parentCategoryAttributes(ctx, req.ParentID)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	attributes, err := newCategoryAttributes(category.ID, req.Attributes, inherited)
+	if err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	category.ParentID = req.ParentID
+	category.Name = strings.TrimSpace(req.Name)
+	category.Description = strings.TrimSpace(req.Description)
+	category.UpdatedAt = time.Now()
+
+	if err := s.storage.UpdateCategory(ctx, category, attributes); err != nil {
+		return model.CategoryWithAttributes{}, err
+	}
+
+	return s.GetCategory(ctx, id)
+}
+
+// DeleteCategory deletes a category that has no subcategories and no items
+func (s *Service) DeleteCategory(ctx context.Context, id string) error {
+	return s.storage.DeleteCategory(ctx, id)
+}
+
+// parentCategoryAttributes checks that a parent category exists and returns the attributes it
+// passes on to its children
+func (s *Service) parentCategoryAttributes(ctx context.Context, parentID *uuid.UUID) ([]model.CategoryAttribute, error) {
+	if parentID == nil {
+		return nil, nil
+	}
+
+	if _, err := s.storage.GetCategoryByID(ctx, parentID.String()); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrBadRequest
+		}
+		return nil, err
+	}
+
+	return s.storage.ListCategoryAttributes(ctx, parentID.String())
+}
+
+// newCategoryAttributes builds a category's attributes from a request. Names are unique across
+// the category and its ancestors, and the options of an enum attribute are unique.
+func newCategoryAttributes(categoryID uuid.UUID, reqs []model.CategoryAttributeRequest, inherited []model.CategoryAttribute) ([]model.CategoryAttribute, error) {
+	seen := make(map[string]bool, len(reqs)+len(inherited))
+	for _, attribute := range inherited {
+		seen[strings.ToLower(attribute.Name)] = true
+	}
+
+	attributes := make([]model.CategoryAttribute, 0, len(reqs))
+	for _, req := range reqs {
+		name := strings.TrimSpace(req.Name)
+		if seen[strings.ToLower(name)] {
+			return nil, errors.ErrBadRequest
+		}
+		seen[strings.ToLower(name)] = true
+
+		options := make([]string, 0, len(req.Options))
+		seenOptions := make(map[string]bool, len(req.Options))
+		for _, option := range req.Options {
+			option = strings.TrimSpace(option)
+			if seenOptions[option] {
+				return nil, errors.ErrBadRequest
+			}
+			seenOptions[option] = true
+			options = append(options, option)
+		}
+
+		attributes = append(attributes, model.CategoryAttribute{
+			ID:         uuid.New(),
+			CategoryID: categoryID,
+			Name:       name,
+			Type:       req.Type,
+			Required:   req.Required,
+			Options:    options,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+
+	return attributes, nil
+}
+
+// itemAttributes checks an item's attribute values against the attributes of its category and
+// the category's ancestors. Every value must be for one of those attributes and of its type,
+// and every required attribute must have a value. Null values are dropped.
+func (s *Service) itemAttributes(ctx context.Context, categoryID *uuid.UUID, values map[string]interface{}) (map[string]interface{}, error) {
+	if categoryID == nil {
+		if len(values) > 0 {
+			return nil, errors.ErrBadRequest
+		}
+		return nil, nil
+	}
+
+	if _, err := s.storage.GetCategoryByID(ctx, categoryID.String()); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrBadRequest
+		}
+		return nil, err
+	}
+
+	attributes, err := s.storage.ListCategoryAttributes(ctx, categoryID.String())
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]model.CategoryAttribute, len(attributes))
+	for _, attribute := range attributes {
+		byName[attribute.Name] = attribute
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+
+		attribute, ok := byName[name]
+		if !ok {
+			return nil, errors.ErrBadRequest
+		}
+
+		value, ok := attributeValue(attribute, value)
+		if !ok {
+			return nil, errors.ErrBadRequest
+		}
+		result[name] = value
+	}
+
+	for _, attribute := range attributes {
+		if _, ok := result[attribute.Name]; attribute.Required && !ok {
+			return nil, errors.ErrBadRequest
+		}
+	}
+
+	return result, nil
+}
+
+// attributeValue checks that a decoded JSON value fits an attribute's type, trimming text
+func attributeValue(attribute model.CategoryAttribute, value interface{}) (interface{}, bool) {
+	switch attribute.Type {
+	case model.AttributeTypeNumber:
+		number, ok := value.(float64)
+		return number, ok
+	case model.AttributeTypeBoolean:
+		flag, ok := value.(bool)
+		return flag, ok
+	case model.AttributeTypeEnum:
+		option, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		for _, allowed := range attribute.Options {
+			if option == allowed {
+				return option, true
+			}
+		}
+		return nil, false
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		text = strings.TrimSpace(text)
+		return text, text != "" && len(text) <= 1000
+	}
+}
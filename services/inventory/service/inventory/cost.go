@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetValuation values the stock on hand just before the given time
+func (s *Service) GetValuation(ctx context.Context, before time.Time) (model.ValuationReport, error) {
+	valuations, err := s.storage.ListItemValuations(ctx, before)
+	if err != nil {
+		return model.ValuationReport{}, err
+	}
+
+	report := model.ValuationReport{Items: valuations}
+	for _, valuation := range valuations {
+		report.TotalValue += valuation.Value
+	}
+
+	return report, nil
+}
+
+// GetOrderCostOfGoodsSold reports the cost of the goods shipped on a sales order
+func (s *Service) GetOrderCostOfGoodsSold(ctx context.Context, orderID string) (model.OrderCostOfGoodsSold, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return model.OrderCostOfGoodsSold{}, errors.ErrBadRequest
+	}
+
+	items, err := s.storage.ListOrderCostOfGoodsSold(ctx, orderID)
+	if err != nil {
+		return model.OrderCostOfGoodsSold{}, err
+	}
+	if len(items) == 0 {
+		return model.OrderCostOfGoodsSold{}, errors.ErrNotFound
+	}
+
+	report := model.OrderCostOfGoodsSold{
+		OrderID: orderUUID,
+		Items:   items,
+	}
+	for _, item := range items {
+		report.TotalCost += item.Cost
+	}
+
+	return report, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateStockCount opens a count session over a warehouse, or the default warehouse when none is
+// given, snapshotting the quantities the counters are expected to find
+func (s *Service) CreateStockCount(ctx context.Context, req model.CreateStockCountRequest) (model.StockCountWithLines, error) {
+	warehouseID := s.defaultWarehouseID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+
+	if _, err := s.storage.GetWarehouseByID(ctx, warehouseID.String()); err != nil {
+		if err == errors.ErrNotFound {
+			return model.StockCountWithLines{}, errors.ErrBadRequest
+		}
+		return model.StockCountWithLines{}, err
+	}
+
+	for _, itemID := range req.ItemIDs {
+		if _, err := s.storage.GetItemByID(ctx, itemID.String()); err != nil {
+			if err == errors.ErrNotFound {
+				return model.StockCountWithLines{}, errors.ErrBadRequest
+			}
+			return model.StockCountWithLines{}, err
+		}
+	}
+
+	count := model.StockCount{
+		ID:          uuid.New(),
+		WarehouseID: warehouseID,
+		Status:      model.StockCountStatusOpen,
+		Notes:       strings.TrimSpace(req.Notes),
+		SnapshotAt:  time.Now(),
+		CreatedBy:   middleware.GetUserIDFromContext(ctx),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.storage.CreateStockCount(ctx, count, req.ItemIDs); err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	return s.GetStockCount(ctx, count.ID.String())
+}
+
+func (s *Service) GetStockCount(ctx context.Context, id string) (model.StockCountWithLines, error) {
+	count, err := s.storage.GetStockCountByID(ctx, id)
+	if err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	lines, err := s.storage.ListStockCountLines(ctx, id)
+	if err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	return model.StockCountWithLines{
+		StockCount: count,
+		Lines:      lines,
+	}, nil
+}
+
+func (s *Service) ListStockCounts(ctx context.Context, filter model.StockCountFilter, limit, offset int) ([]model.StockCount, error) {
+	return s.storage.ListStockCounts(ctx, filter, limit, offset)
+}
+
+// RecordStockCounts records the quantities found by the requesting counter. Counts without a
+// location are for the warehouse's default location. Counting a line again while the session is
+// open replaces the earlier count.
+func (s *Service) RecordStockCounts(ctx context.Context, id string, req model.RecordStockCountsRequest) (model.StockCountWithLines, error) {
+	count, err := s.storage.GetStockCountByID(ctx, id)
+	if err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	defaultLocation, err := s.storage.GetDefaultLocation(ctx, count.WarehouseID.String())
+	if err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	entries := make([]model.StockCountEntry, 0, len(req.Counts))
+	for _, entry := range req.Counts {
+		locationID := defaultLocation.ID
+		if entry.LocationID != nil {
+			location, err := s.storage.GetLocationByID(ctx, entry.LocationID.String())
+			if err != nil {
+				if err == errors.ErrNotFound {
+					return model.StockCountWithLines{}, errors.ErrBadRequest
+				}
+				return model.StockCountWithLines{}, err
+			}
+			if location.WarehouseID != count.WarehouseID {
+				return model.StockCountWithLines{}, errors.ErrBadRequest
+			}
+			locationID = location.ID
+		}
+
+		entries = append(entries, model.StockCountEntry{
+			ItemID:     entry.ItemID,
+			LocationID: locationID,
+			Quantity:   entry.Quantity,
+		})
+	}
+
+	if err := s.storage.RecordStockCounts(ctx, id, entries, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	return s.GetStockCount(ctx, id)
+}
+
+// PostStockCount adjusts stock to the counted quantities and closes the session
+func (s *Service) PostStockCount(ctx context.Context, id string) (model.StockCountWithLines, error) {
+	if err := s.storage.PostStockCount(ctx, id, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	count, err := s.GetStockCount(ctx, id)
+	if err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	checked := make(map[uuid.UUID]bool, len(count.Lines))
+	for _, line := range count.Lines {
+		if !checked[line.ItemID] {
+			checked[line.ItemID] = true
+			s.checkStockLevel(ctx, line.ItemID.String())
+		}
+	}
+
+	return count, nil
+}
+
+func (s *Service) CancelStockCount(ctx context.Context, id string) (model.StockCountWithLines, error) {
+	if err := s.storage.CancelStockCount(ctx, id); err != nil {
+		return model.StockCountWithLines{}, err
+	}
+
+	return s.GetStockCount(ctx, id)
+}
@@ -0,0 +1,503 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/inventory/model"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImportRows caps the number of items in one CSV import
+	maxImportRows = 10000
+
+	// exportBatchSize is the number of items read per query while exporting
+	exportBatchSize = 500
+
+	// utf8BOM marks a CSV file as UTF-8 for spreadsheet applications
+	utf8BOM = "\uFEFF"
+)
+
+// itemCSVColumns are the columns of an item export. An import accepts them in any order, needs
+// only sku, and also accepts unit_cost to value the opening stock.
+var itemCSVColumns = []string{
+	"sku", "name", "description", "unit_price", "reorder_point", "reorder_quantity", "min_stock",
+	"costing_method", "base_unit", "serialized", "category_id", "quantity",
+}
+
+// itemImportRow is a valid row of an import; existing is set when the SKU already exists, and
+// item is the item the row writes
+type itemImportRow struct {
+	row      int
+	existing *model.Item
+	req      model.UpdateItemRequest
+	item     model.Item
+	quantity int
+	unitCost *float64
+}
+
+// ImportItems creates or updates items from CSV, matching them by SKU. For an existing item only
+// the non-empty cells are applied; new items take the defaults for empty cells and have the
+// opening stock in the quantity column booked into the default warehouse. Every row goes through
+// the same checks as the item endpoints before anything is written, in a dry run as well. The
+// rows are then written in one transaction, so an import with any invalid row or failed write,
+// or a dry run, writes nothing.
+func (s *Service) ImportItems(ctx context.Context, r io.Reader, dryRun bool) (model.ItemImportResult, error) {
+	reader := newItemCSVReader(r)
+	decimalComma := reader.Comma == ';'
+
+	result := model.ItemImportResult{
+		DryRun: dryRun,
+		Errors: []model.ItemImportError{},
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return model.ItemImportResult{}, errors.ErrBadRequest
+	}
+	columns, headerErrors := importColumns(header)
+	if len(headerErrors) > 0 {
+		result.Errors = headerErrors
+		return result, nil
+	}
+
+	rows := make([]itemImportRow, 0)
+	seen := make(map[string]int)
+	for rowNumber := 2; ; rowNumber++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.ItemImportResult{}, errors.ErrBadRequest
+		}
+		if blankRecord(record) {
+			continue
+		}
+
+		result.Rows++
+		if result.Rows > maxImportRows {
+			return model.ItemImportResult{}, errors.ErrBadRequest
+		}
+
+		if len(record) > len(columns) {
+			result.Errors = append(result.Errors, model.ItemImportError{
+				Row:     rowNumber,
+				Message: "row has more cells than the header",
+			})
+			continue
+		}
+
+		cells := make(map[string]string, len(columns))
+		for i, value := range record {
+			cells[columns[i]] = strings.TrimSpace(value)
+		}
+
+		row, rowErrors, err := s.parseImportRow(ctx, rowNumber, cells, decimalComma)
+		if err != nil {
+			return model.ItemImportResult{}, err
+		}
+
+		if first, ok := seen[row.req.SKU]; ok && row.req.SKU != "" {
+			rowErrors = append(rowErrors, model.ItemImportError{
+				Row:     rowNumber,
+				SKU:     row.req.SKU,
+				Field:   "sku",
+				Message: fmt.Sprintf("duplicates row %d", first),
+			})
+		} else {
+			seen[row.req.SKU] = rowNumber
+		}
+
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if dryRun {
+		countImportRows(&result, rows)
+		return result, nil
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	items := make([]model.ItemImport, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, model.ItemImport{
+			Item:         row.item,
+			New:          row.existing == nil,
+			OpeningStock: row.quantity,
+			UnitCost:     row.unitCost,
+		})
+	}
+
+	source := model.StockMovementSource{
+		Reason: model.StockMovementReasonAdjustment,
+		UserID: middleware.GetUserIDFromContext(ctx),
+	}
+
+	failed, err := s.storage.ImportItems(ctx, items, s.defaultWarehouseID.String(), source)
+	if err != nil {
+		if failed < 0 {
+			return model.ItemImportResult{}, err
+		}
+		result.Errors = append(result.Errors, importApplyError(rows[failed], "", err))
+		return result, nil
+	}
+
+	countImportRows(&result, rows)
+	for _, row := range rows {
+		if row.quantity > 0 {
+			s.checkStockLevel(ctx, row.item.ID.String())
+		}
+	}
+
+	return result, nil
+}
+
+// countImportRows counts the items an import creates and updates
+func countImportRows(result *model.ItemImportResult, rows []itemImportRow) {
+	for _, row := range rows {
+		if row.existing != nil {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+	}
+}
+
+// ExportItems writes every item with its stock on hand to w as CSV, in SKU order. Nothing is
+// written if the first items cannot be read.
+func (s *Service) ExportItems(ctx context.Context, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	afterSKU := ""
+	for first := true; ; first = false {
+		items, err := s.storage.ListItemsForExport(ctx, afterSKU, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if first {
+			if _, err := io.WriteString(w, utf8BOM); err != nil {
+				return err
+			}
+			if err := writer.Write(itemCSVColumns); err != nil {
+				return err
+			}
+		}
+
+		for _, item := range items {
+			if err := writer.Write(itemExportRecord(item)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+
+		if len(items) < exportBatchSize {
+			return nil
+		}
+		afterSKU = items[len(items)-1].SKU
+	}
+}
+
+// newItemCSVReader reads CSV as saved by spreadsheet applications. A leading UTF-8 byte order
+// mark is skipped, and a header separated by semicolons, as written in locales with a decimal
+// comma, switches the separator to semicolons.
+func newItemCSVReader(r io.Reader) *csv.Reader {
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+		buffered.Discard(len(utf8BOM))
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+
+	// Peek returns what it could read along with any error, which the reader reports later
+	peeked, _ := buffered.Peek(buffered.Size())
+	header := peeked
+	if end := bytes.IndexByte(peeked, '\n'); end >= 0 {
+		header = peeked[:end]
+	}
+	if bytes.IndexByte(header, ';') >= 0 && bytes.IndexByte(header, ',') < 0 {
+		reader.Comma = ';'
+	}
+
+	return reader
+}
+
+// importColumns reads the header of an import. Column names are case-insensitive; each may
+// appear once, and sku is required.
+func importColumns(header []string) ([]string, []model.ItemImportError) {
+	allowed := make(map[string]bool, len(itemCSVColumns)+1)
+	for _, column := range itemCSVColumns {
+		allowed[column] = true
+	}
+	allowed["unit_cost"] = true
+
+	var headerErrors []model.ItemImportError
+	columns := make([]string, 0, len(header))
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		column := strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case !allowed[column]:
+			headerErrors = append(headerErrors, model.ItemImportError{Row: 1, Field: column, Message: "unknown column"})
+		case seen[column]:
+			headerErrors = append(headerErrors, model.ItemImportError{Row: 1, Field: column, Message: "duplicate column"})
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	if !seen["sku"] {
+		headerErrors = append(headerErrors, model.ItemImportError{Row: 1, Field: "sku", Message: "missing column"})
+	}
+
+	return columns, headerErrors
+}
+
+// parseImportRow turns the cells of an import row into an item request, starting from the
+// existing item with the row's SKU when there is one. The request is checked with the same rules
+// as the item endpoints, and a valid row carries the item it writes.
+func (s *Service) parseImportRow(ctx context.Context, rowNumber int, cells map[string]string, decimalComma bool) (itemImportRow, []model.ItemImportError, error) {
+	sku := strings.ToUpper(cells["sku"])
+	row := itemImportRow{
+		row: rowNumber,
+		req: model.UpdateItemRequest{SKU: sku},
+	}
+
+	// An archived item keeps its SKU, so a row for it can neither update it nor create another
+	archived := false
+	if sku != "" {
+		item, err := s.storage.GetItemBySKU(ctx, sku)
+		if err == nil {
+			row.existing = &item
+			row.req = updateItemRequestFromItem(item)
+			archived = item.ArchivedAt != nil
+		} else if err != errors.ErrNotFound {
+			return itemImportRow{}, nil, err
+		}
+	}
+
+	var rowErrors []model.ItemImportError
+	fail := func(column, message string) {
+		rowErrors = append(rowErrors, model.ItemImportError{Row: rowNumber, SKU: sku, Field: column, Message: message})
+	}
+	if archived {
+		fail("sku", "belongs to an archived item")
+		return row, rowErrors, nil
+	}
+	setInt := func(column string, target *int) {
+		if value := cells[column]; value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				fail(column, "must be a whole number")
+				return
+			}
+			*target = n
+		}
+	}
+	setFloat := func(column string, target *float64) bool {
+		value := cells[column]
+		if value == "" {
+			return false
+		}
+		if decimalComma {
+			value = strings.Replace(value, ",", ".", 1)
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fail(column, "must be a number")
+			return false
+		}
+		*target = f
+		return true
+	}
+
+	if value := cells["name"]; value != "" {
+		row.req.Name = value
+	}
+	if value := cells["description"]; value != "" {
+		row.req.Description = value
+	}
+	setFloat("unit_price", &row.req.UnitPrice)
+	setInt("reorder_point", &row.req.ReorderPoint)
+	setInt("reorder_quantity", &row.req.ReorderQuantity)
+	setInt("min_stock", &row.req.MinStock)
+	if value := cells["costing_method"]; value != "" {
+		row.req.CostingMethod = model.CostingMethod(strings.ToLower(value))
+	}
+	if value := cells["base_unit"]; value != "" {
+		row.req.BaseUnit = value
+	}
+	if value := cells["serialized"]; value != "" {
+		serialized, err := strconv.ParseBool(value)
+		if err != nil {
+			fail("serialized", "must be true or false")
+		} else {
+			row.req.Serialized = serialized
+		}
+	}
+	if value := cells["category_id"]; value != "" {
+		categoryID, err := uuid.Parse(value)
+		if err != nil {
+			fail("category_id", "must be a valid UUID")
+		} else {
+			row.req.CategoryID = &categoryID
+		}
+	}
+
+	// Opening stock only applies to new items
+	if row.existing == nil {
+		setInt("quantity", &row.quantity)
+		var unitCost float64
+		if setFloat("unit_cost", &unitCost) {
+			row.unitCost = &unitCost
+		}
+
+		switch {
+		case row.quantity < 0:
+			fail("quantity", "must be no less than 0")
+		case row.quantity > 0 && row.req.Serialized:
+			fail("quantity", "serialized items need serial numbers; adjust their stock instead")
+		}
+		if row.unitCost != nil && *row.unitCost < 0 {
+			fail("unit_cost", "must be no less than 0")
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return row, rowErrors, nil
+	}
+
+	var err error
+	if row.existing != nil {
+		err = row.req.Validate()
+	} else {
+		req := model.CreateItemRequest(row.req)
+		err = req.Validate()
+	}
+	if err != nil {
+		rowErrors = append(rowErrors, importValidationErrors(rowNumber, sku, err)...)
+		return row, rowErrors, nil
+	}
+
+	// The units, category attributes and stock are checked as the item endpoints check them
+	if row.existing != nil {
+		row.item, err = s.updatedItem(ctx, *row.existing, row.req)
+	} else {
+		row.item, err = s.newItem(ctx, model.CreateItemRequest(row.req))
+	}
+	if err == errors.ErrInternalServerError {
+		return itemImportRow{}, nil, err
+	}
+	if err != nil {
+		rowErrors = append(rowErrors, importApplyError(row, "", err))
+	}
+
+	return row, rowErrors, nil
+}
+
+// updateItemRequestFromItem builds an update request that leaves an item unchanged
+func updateItemRequestFromItem(item model.Item) model.UpdateItemRequest {
+	return model.UpdateItemRequest{
+		Name:              item.Name,
+		Description:       item.Description,
+		SKU:               item.SKU,
+		UnitPrice:         item.UnitPrice,
+		ReorderPoint:      item.ReorderPoint,
+		ReorderQuantity:   item.ReorderQuantity,
+		PreferredVendorID: item.PreferredVendorID,
+		MinStock:          item.MinStock,
+		Serialized:        item.Serialized,
+		CostingMethod:     item.CostingMethod,
+		BaseUnit:          item.BaseUnit,
+		Units:             item.Units,
+		CategoryID:        item.CategoryID,
+		Attributes:        item.Attributes,
+	}
+}
+
+// importValidationErrors reports the validation errors of a row, one per field in field order
+func importValidationErrors(rowNumber int, sku string, err error) []model.ItemImportError {
+	fieldErrors, ok := err.(validation.Errors)
+	if !ok {
+		return []model.ItemImportError{{Row: rowNumber, SKU: sku, Message: err.Error()}}
+	}
+
+	fields := make([]string, 0, len(fieldErrors))
+	for field := range fieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	rowErrors := make([]model.ItemImportError, 0, len(fields))
+	for _, field := range fields {
+		rowErrors = append(rowErrors, model.ItemImportError{
+			Row:     rowNumber,
+			SKU:     sku,
+			Field:   field,
+			Message: fieldErrors[field].Error(),
+		})
+	}
+	return rowErrors
+}
+
+func importApplyError(row itemImportRow, field string, err error) model.ItemImportError {
+	return model.ItemImportError{
+		Row:     row.row,
+		SKU:     row.req.SKU,
+		Field:   field,
+		Message: err.Error(),
+	}
+}
+
+// blankRecord reports whether every cell of a record is empty, as in the trailing rows
+// spreadsheets often leave
+func blankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func itemExportRecord(item model.ItemExportRow) []string {
+	categoryID := ""
+	if item.CategoryID != nil {
+		categoryID = item.CategoryID.String()
+	}
+
+	return []string{
+		item.SKU,
+		item.Name,
+		item.Description,
+		strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+		strconv.Itoa(item.ReorderPoint),
+		strconv.Itoa(item.ReorderQuantity),
+		strconv.Itoa(item.MinStock),
+		item.CostingMethod.String(),
+		item.BaseUnit,
+		strconv.FormatBool(item.Serialized),
+		categoryID,
+		strconv.Itoa(item.Quantity),
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"microservice-challenge/services/inventory/model"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewItemCSVReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantComma rune
+		wantRows  [][]string
+	}{
+		{
+			name:      "comma separated",
+			input:     "sku,unit_price\nA-1,2.50\n",
+			wantComma: ',',
+			wantRows:  [][]string{{"sku", "unit_price"}, {"A-1", "2.50"}},
+		},
+		{
+			name:      "byte order mark is skipped",
+			input:     utf8BOM + "sku,name\nA-1,Widget\n",
+			wantComma: ',',
+			wantRows:  [][]string{{"sku", "name"}, {"A-1", "Widget"}},
+		},
+		{
+			name:      "semicolon header switches to semicolons",
+			input:     utf8BOM + "sku;unit_price\r\nA-1;2,50\r\n",
+			wantComma: ';',
+			wantRows:  [][]string{{"sku", "unit_price"}, {"A-1", "2,50"}},
+		},
+		{
+			name:      "semicolons in a comma-separated header stay data",
+			input:     "sku,description\nA-1,red; large\n",
+			wantComma: ',',
+			wantRows:  [][]string{{"sku", "description"}, {"A-1", "red; large"}},
+		},
+		{
+			name:      "header without a line end",
+			input:     "sku;name",
+			wantComma: ';',
+			wantRows:  [][]string{{"sku", "name"}},
+		},
+		{
+			name:      "rows may have fewer cells than the header",
+			input:     "sku,name,unit_price\nA-1\n",
+			wantComma: ',',
+			wantRows:  [][]string{{"sku", "name", "unit_price"}, {"A-1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := newItemCSVReader(strings.NewReader(tt.input))
+			if reader.Comma != tt.wantComma {
+				t.Errorf("separator = %q, want %q", reader.Comma, tt.wantComma)
+			}
+
+			rows, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !slices.EqualFunc(rows, tt.wantRows, slices.Equal[[]string]) {
+				t.Errorf("rows = %q, want %q", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestImportColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      []string
+		wantColumns []string
+		wantErrors  []model.ItemImportError
+	}{
+		{
+			name:        "known columns in any order",
+			header:      []string{"name", "sku", "unit_cost"},
+			wantColumns: []string{"name", "sku", "unit_cost"},
+		},
+		{
+			name:        "names are trimmed and lower-cased",
+			header:      []string{" SKU ", "Unit_Price"},
+			wantColumns: []string{"sku", "unit_price"},
+		},
+		{
+			name:        "unknown column",
+			header:      []string{"sku", "colour"},
+			wantColumns: []string{"sku", "colour"},
+			wantErrors:  []model.ItemImportError{{Row: 1, Field: "colour", Message: "unknown column"}},
+		},
+		{
+			name:        "duplicate column",
+			header:      []string{"sku", "name", "Name"},
+			wantColumns: []string{"sku", "name", "name"},
+			wantErrors:  []model.ItemImportError{{Row: 1, Field: "name", Message: "duplicate column"}},
+		},
+		{
+			name:        "missing sku",
+			header:      []string{"name", "unit_price"},
+			wantColumns: []string{"name", "unit_price"},
+			wantErrors:  []model.ItemImportError{{Row: 1, Field: "sku", Message: "missing column"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, errs := importColumns(tt.header)
+			if !slices.Equal(columns, tt.wantColumns) {
+				t.Errorf("columns = %q, want %q", columns, tt.wantColumns)
+			}
+			if !slices.Equal(errs, tt.wantErrors) {
+				t.Errorf("errors = %+v, want %+v", errs, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestBlankRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []string
+		want   bool
+	}{
+		{name: "no cells", record: nil, want: true},
+		{name: "empty cells", record: []string{"", "", ""}, want: true},
+		{name: "whitespace only", record: []string{" ", "\t"}, want: true},
+		{name: "one value", record: []string{"", "A-1", ""}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blankRecord(tt.record); got != tt.want {
+				t.Errorf("blankRecord(%q) = %v, want %v", tt.record, got, tt.want)
+			}
+		})
+	}
+}
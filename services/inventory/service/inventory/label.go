@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+)
+
+// Label geometry, in modules (the width of the narrowest bar). EAN-13 needs a quiet zone of 11
+// modules on the left and 7 on the right of its 95 modules of bars.
+const (
+	labelQuietLeft  = 11
+	labelQuietRight = 7
+	labelWidth      = labelQuietLeft + 95 + labelQuietRight
+	labelBarHeight  = 60
+	labelGuardExtra = 5
+	labelDigitSize  = 9
+
+	// labelModuleMM is the nominal EAN-13 module width the SVG label is sized for
+	labelModuleMM = 0.33
+
+	// labelPNGScale is the number of pixels per module in a PNG label
+	labelPNGScale = 4
+
+	// labelMaxNameLength truncates the item name printed on an SVG label
+	labelMaxNameLength = 40
+)
+
+// EAN-13 encodes each digit in 7 modules. Left-hand digits use the L or G patterns, chosen by the
+// first digit, and right-hand digits the R patterns. R is the complement of L and G is R
+// reversed.
+var (
+	ean13LPatterns = [10]string{
+		"0001101", "0011001", "0010011", "0111101", "0100011",
+		"0110001", "0101111", "0111011", "0110111", "0001011",
+	}
+	ean13Parity = [10]string{
+		"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+		"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+	}
+)
+
+// labelDigitGlyphs is a 5x7 bitmap font for the human-readable digits of a PNG label
+var labelDigitGlyphs = [10][7]string{
+	{"01110", "10001", "10011", "10101", "11001", "10001", "01110"},
+	{"00100", "01100", "00100", "00100", "00100", "00100", "01110"},
+	{"01110", "10001", "00001", "00010", "00100", "01000", "11111"},
+	{"11111", "00010", "00100", "00010", "00001", "10001", "01110"},
+	{"00010", "00110", "01010", "10010", "11111", "00010", "00010"},
+	{"11111", "10000", "11110", "00001", "00001", "10001", "01110"},
+	{"00110", "01000", "10000", "11110", "10001", "10001", "01110"},
+	{"11111", "00001", "00010", "00100", "01000", "01000", "01000"},
+	{"01110", "10001", "10001", "01110", "10001", "10001", "01110"},
+	{"01110", "10001", "10001", "01111", "00001", "00010", "01100"},
+}
+
+// ean13Modules encodes a 13-digit code as its 95 modules, true for a bar. guard marks the modules
+// of the start, centre and end guards, which are drawn longer.
+func ean13Modules(code string) (modules []bool, guard []bool) {
+	modules = make([]bool, 0, 95)
+	guard = make([]bool, 0, 95)
+	add := func(pattern string, isGuard bool) {
+		for _, c := range pattern {
+			modules = append(modules, c == '1')
+			guard = append(guard, isGuard)
+		}
+	}
+
+	parity := ean13Parity[code[0]-'0']
+	add("101", true)
+	for i := 1; i <= 6; i++ {
+		pattern := ean13LPatterns[code[i]-'0']
+		if parity[i-1] == 'G' {
+			pattern = reversePattern(complementPattern(pattern))
+		}
+		add(pattern, false)
+	}
+	add("01010", true)
+	for i := 7; i <= 12; i++ {
+		add(complementPattern(ean13LPatterns[code[i]-'0']), false)
+	}
+	add("101", true)
+
+	return modules, guard
+}
+
+func complementPattern(pattern string) string {
+	var b strings.Builder
+	for _, c := range pattern {
+		if c == '1' {
+			b.WriteByte('0')
+		} else {
+			b.WriteByte('1')
+		}
+	}
+	return b.String()
+}
+
+func reversePattern(pattern string) string {
+	reversed := []byte(pattern)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	return string(reversed)
+}
+
+// labelDigitPositions returns the horizontal centre, in modules, of each human-readable digit:
+// the first digit sits in the left quiet zone and the others under their bars
+func labelDigitPositions() [13]float64 {
+	var positions [13]float64
+	positions[0] = labelQuietLeft - 4
+	for i := 0; i < 6; i++ {
+		positions[1+i] = labelQuietLeft + 3 + 7*float64(i) + 3.5
+		positions[7+i] = labelQuietLeft + 3 + 42 + 5 + 7*float64(i) + 3.5
+	}
+	return positions
+}
+
+// renderLabelSVG renders a label with the item's name and SKU above the barcode, sized for the
+// nominal module width. The pack quantity follows the SKU for pack barcodes.
+func renderLabelSVG(item model.Item, barcode model.ItemBarcode) []byte {
+	const top = 18
+	height := top + labelBarHeight + labelGuardExtra + labelDigitSize
+
+	name := item.Name
+	if runes := []rune(name); len(runes) > labelMaxNameLength {
+		name = string(runes[:labelMaxNameLength-1]) + "…"
+	}
+	caption := item.SKU
+	if barcode.PackQuantity > 1 {
+		caption += fmt.Sprintf(" × %d", barcode.PackQuantity)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.2fmm" height="%.2fmm" viewBox="0 0 %d %d">`,
+		labelWidth*labelModuleMM, float64(height)*labelModuleMM, labelWidth, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/>`, labelWidth, height)
+
+	b.WriteString(`<text x="3" y="7" font-family="sans-serif" font-size="6">`)
+	xml.EscapeText(&b, []byte(name))
+	b.WriteString(`</text>`)
+	b.WriteString(`<text x="3" y="15" font-family="sans-serif" font-size="5">`)
+	xml.EscapeText(&b, []byte(caption))
+	b.WriteString(`</text>`)
+
+	// Consecutive bar modules are drawn as one rectangle
+	modules, guard := ean13Modules(barcode.Code)
+	for start := 0; start < len(modules); {
+		if !modules[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(modules) && modules[end] && guard[end] == guard[start] {
+			end++
+		}
+		barHeight := labelBarHeight
+		if guard[start] {
+			barHeight += labelGuardExtra
+		}
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, labelQuietLeft+start, top, end-start, barHeight)
+		start = end
+	}
+
+	baseline := top + labelBarHeight + labelDigitSize - 1
+	for i, x := range labelDigitPositions() {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" font-family="monospace" font-size="%d" text-anchor="middle">%c</text>`,
+			x, baseline, labelDigitSize, barcode.Code[i])
+	}
+
+	b.WriteString(`</svg>`)
+	return b.Bytes()
+}
+
+// renderLabelPNG renders the barcode and its digits as a PNG, at labelPNGScale pixels per module
+func renderLabelPNG(barcode model.ItemBarcode) ([]byte, error) {
+	const top = 4
+	height := top + labelBarHeight + labelGuardExtra + labelDigitSize + 2
+
+	img := image.NewGray(image.Rect(0, 0, labelWidth*labelPNGScale, height*labelPNGScale))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	fill := func(x, y, width, height int) {
+		for py := y; py < y+height; py++ {
+			for px := x; px < x+width; px++ {
+				img.SetGray(px, py, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	modules, guard := ean13Modules(barcode.Code)
+	for i, bar := range modules {
+		if !bar {
+			continue
+		}
+		barHeight := labelBarHeight
+		if guard[i] {
+			barHeight += labelGuardExtra
+		}
+		fill((labelQuietLeft+i)*labelPNGScale, top*labelPNGScale, labelPNGScale, barHeight*labelPNGScale)
+	}
+
+	// Each glyph pixel is labelPNGScale pixels square, so a digit is 5 by 7 modules
+	glyphTop := (top + labelBarHeight + 2) * labelPNGScale
+	for i, x := range labelDigitPositions() {
+		glyph := labelDigitGlyphs[barcode.Code[i]-'0']
+		left := int((x - 2.5) * labelPNGScale)
+		for row, bits := range glyph {
+			for col, bit := range bits {
+				if bit == '1' {
+					fill(left+col*labelPNGScale, glyphTop+row*labelPNGScale, labelPNGScale, labelPNGScale)
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+	return b.Bytes(), nil
+}
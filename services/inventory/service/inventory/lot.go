@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/services/inventory/model"
+	"time"
+)
+
+func (s *Service) ListStockLots(ctx context.Context, itemID string) ([]model.StockLot, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListStockLotsByItemID(ctx, itemID)
+}
+
+// ListExpiringLots reports lots with stock that expire within the given number of days, including
+// lots that have already expired
+func (s *Service) ListExpiringLots(ctx context.Context, days int) ([]model.ExpiringLot, error) {
+	now := time.Now()
+	today := truncateToDate(&now)
+
+	lots, err := s.storage.ListExpiringLots(ctx, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lots {
+		lots[i].DaysUntilExpiry = int(lots[i].ExpiryDate.Sub(*today).Hours() / 24)
+	}
+
+	return lots, nil
+}
+
+// eventLotsByItem reads the lots carried by a purchase.order.received event, keyed by item ID
+func eventLotsByItem(event map[string]interface{}) map[string][]model.LotQuantity {
+	lotsByItem := make(map[string][]model.LotQuantity)
+
+	lots, ok := event["lots"].([]interface{})
+	if !ok {
+		return lotsByItem
+	}
+
+	for _, lotData := range lots {
+		lotMap, ok := lotData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, ok := lotMap["item_id"].(string)
+		if !ok {
+			continue
+		}
+
+		lotNumber, ok := lotMap["lot_number"].(string)
+		if !ok || lotNumber == "" {
+			continue
+		}
+
+		quantity, ok := lotMap["quantity"].(float64)
+		if !ok || quantity <= 0 {
+			continue
+		}
+
+		lot := model.LotQuantity{
+			LotRef:   model.LotRef{LotNumber: lotNumber},
+			Quantity: int(quantity),
+		}
+		if expiry, ok := lotMap["expiry_date"].(string); ok {
+			if expiryDate, err := time.Parse(time.DateOnly, expiry); err == nil {
+				lot.ExpiryDate = &expiryDate
+			}
+		}
+
+		lotsByItem[itemID] = append(lotsByItem[itemID], lot)
+	}
+
+	return lotsByItem
+}
+
+// truncateToDate drops the time of day, since expiry dates are stored as dates
+func truncateToDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &date
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *Service) ListItemPrices(ctx context.Context, itemID string, limit, offset int) ([]model.ItemPrice, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListItemPrices(ctx, itemID, limit, offset)
+}
+
+// GetItemPriceAt returns the price of an item in effect at a time. Orders are priced with the
+// price in effect when they were placed.
+func (s *Service) GetItemPriceAt(ctx context.Context, itemID string, at time.Time) (model.ItemPrice, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return model.ItemPrice{}, err
+	}
+
+	return s.storage.GetItemPriceAt(ctx, itemID, at.UTC())
+}
+
+// SchedulePriceChange sets the price an item changes to at a future time. Past prices are
+// history and cannot be added.
+func (s *Service) SchedulePriceChange(ctx context.Context, itemID string, req model.SchedulePriceChangeRequest) (model.ItemPrice, error) {
+	item, err := s.storage.GetItemByID(ctx, itemID)
+	if err != nil {
+		return model.ItemPrice{}, err
+	}
+	if item.ArchivedAt != nil {
+		return model.ItemPrice{}, errors.ErrBadRequest
+	}
+
+	now := time.Now().UTC()
+	if !req.EffectiveFrom.After(now) {
+		return model.ItemPrice{}, errors.ErrBadRequest
+	}
+
+	price := model.ItemPrice{
+		ID:            uuid.New(),
+		ItemID:        item.ID,
+		UnitPrice:     req.UnitPrice,
+		EffectiveFrom: req.EffectiveFrom.UTC(),
+		CreatedAt:     now,
+	}
+
+	if err := s.storage.CreateItemPrice(ctx, price); err != nil {
+		return model.ItemPrice{}, err
+	}
+
+	return price, nil
+}
+
+// CancelPriceChange removes a scheduled price change. A price that has taken effect is part of
+// the item's history and stays.
+func (s *Service) CancelPriceChange(ctx context.Context, itemID, priceID string) error {
+	price, err := s.storage.GetItemPriceByID(ctx, priceID)
+	if err != nil {
+		return err
+	}
+	if price.ItemID.String() != itemID {
+		return errors.ErrNotFound
+	}
+	if !price.EffectiveFrom.After(time.Now().UTC()) {
+		return errors.ErrConflict
+	}
+
+	return s.storage.DeleteItemPrice(ctx, price.ID)
+}
+
+// StartPriceScheduler applies scheduled price changes to the items' unit prices as they fall due
+func (s *Service) StartPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.applyDuePrices(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.applyDuePrices(ctx)
+		}
+	}
+}
+
+func (s *Service) applyDuePrices(ctx context.Context) {
+	prices, err := s.storage.ApplyDueItemPrices(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.Error(ctx, "failed to apply scheduled prices", zap.Error(err))
+		return
+	}
+
+	for _, price := range prices {
+		s.logger.Info(ctx, "applied scheduled price",
+			zap.String("item_id", price.ItemID.String()),
+			zap.Float64("unit_price", price.UnitPrice),
+		)
+	}
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxProductVariants caps the number of combinations the axes of a product may produce
+const maxProductVariants = 500
+
+// CreateProduct creates a product and a variant item for every combination of the values of its
+// axes. Variant items are ordinary items: they have their own SKU, price and stock and are what
+// sales and purchase order lines reference.
+func (s *Service) CreateProduct(ctx context.Context, req model.CreateProductRequest) (model.ProductWithVariants, error) {
+	axes, err := normalizeProductAxes(req.Axes)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	product := model.Product{
+		ID:          uuid.New(),
+		SKU:         strings.ToUpper(strings.TrimSpace(req.SKU)),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		UnitPrice:   req.UnitPrice,
+		Axes:        axes,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	variants, err := newVariantItems(product, req.Variants, nil)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	if err := s.storage.CreateProduct(ctx, product, variants); err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	s.createVariantStock(ctx, variants)
+
+	return s.GetProduct(ctx, product.ID.String())
+}
+
+// GetProduct returns a product with its variant matrix and the stock of each variant
+func (s *Service) GetProduct(ctx context.Context, id string) (model.ProductWithVariants, error) {
+	product, err := s.storage.GetProductByID(ctx, id)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	variants, err := s.storage.ListProductVariants(ctx, id)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	return model.ProductWithVariants{
+		Product:  product,
+		Variants: variants,
+	}, nil
+}
+
+func (s *Service) ListProducts(ctx context.Context, limit, offset int) ([]model.Product, error) {
+	return s.storage.ListProducts(ctx, limit, offset)
+}
+
+// UpdateProduct updates a product and creates a variant item for every combination of the values
+// of its axes that has none yet. Axes keep their names and order and may only gain values, so
+// existing variants are never orphaned.
+func (s *Service) UpdateProduct(ctx context.Context, id string, req model.UpdateProductRequest) (model.ProductWithVariants, error) {
+	product, err := s.storage.GetProductByID(ctx, id)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	axes, err := normalizeProductAxes(req.Axes)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	if len(axes) != len(product.Axes) {
+		return model.ProductWithVariants{}, errors.ErrBadRequest
+	}
+	for i, axis := range axes {
+		if axis.Name != product.Axes[i].Name {
+			return model.ProductWithVariants{}, errors.ErrBadRequest
+		}
+		for _, value := range product.Axes[i].Values {
+			if !containsValue(axis.Values, value) {
+				return model.ProductWithVariants{}, errors.ErrBadRequest
+			}
+		}
+	}
+
+	existing, err := s.storage.ListProductVariants(ctx, id)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	product.Name = strings.TrimSpace(req.Name)
+	product.Description = strings.TrimSpace(req.Description)
+	product.UnitPrice = req.UnitPrice
+	product.Axes = axes
+	product.UpdatedAt = time.Now()
+
+	variants, err := newVariantItems(product, req.Variants, existing)
+	if err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	if err := s.storage.UpdateProduct(ctx, product, variants); err != nil {
+		return model.ProductWithVariants{}, err
+	}
+
+	s.createVariantStock(ctx, variants)
+
+	return s.GetProduct(ctx, id)
+}
+
+// createVariantStock gives new variant items an empty stock record at the default location, as
+// CreateItem does for other items
+func (s *Service) createVariantStock(ctx context.Context, variants []model.Item) {
+	if len(variants) == 0 {
+		return
+	}
+
+	location, err := s.storage.GetDefaultLocation(ctx, s.defaultWarehouseID.String())
+	if err != nil {
+		s.logger.Error(ctx, "failed to find default location for initial stock", zap.Error(err))
+		return
+	}
+
+	for _, variant := range variants {
+		stock := model.Stock{
+			ID:         uuid.New(),
+			ItemID:     variant.ID,
+			LocationID: location.ID,
+			Quantity:   0,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+
+		if err := s.storage.CreateStock(ctx, stock); err != nil {
+			s.logger.Error(ctx, "failed to create initial stock", zap.Error(err), zap.String("item_id", variant.ID.String()))
+		}
+	}
+}
+
+// normalizeProductAxes trims axis names and values and rejects repeated axis names or values
+func normalizeProductAxes(axes []model.ProductAxis) ([]model.ProductAxis, error) {
+	normalized := make([]model.ProductAxis, 0, len(axes))
+	names := make(map[string]bool, len(axes))
+	combinations := 1
+
+	for _, axis := range axes {
+		name := strings.ToLower(strings.TrimSpace(axis.Name))
+		if name == "" || names[name] {
+			return nil, errors.ErrBadRequest
+		}
+		names[name] = true
+
+		values := make([]string, 0, len(axis.Values))
+		for _, value := range axis.Values {
+			value = strings.TrimSpace(value)
+			if value == "" || containsValue(values, value) {
+				return nil, errors.ErrBadRequest
+			}
+			values = append(values, value)
+		}
+
+		combinations *= len(values)
+		if combinations > maxProductVariants {
+			return nil, errors.ErrBadRequest
+		}
+
+		normalized = append(normalized, model.ProductAxis{Name: name, Values: values})
+	}
+
+	return normalized, nil
+}
+
+// newVariantItems builds the variant items for the combinations of the product's axes that are
+// not among the existing variants, applying the SKU and price overrides given. An override that
+// matches no combination is rejected.
+func newVariantItems(product model.Product, overrides []model.ProductVariantRequest, existing []model.ProductVariant) ([]model.Item, error) {
+	combinations := variantCombinations(product.Axes)
+
+	known := make(map[string]bool, len(combinations))
+	for _, combination := range combinations {
+		known[variantKey(product.Axes, combination)] = true
+	}
+
+	byKey := make(map[string]model.ProductVariantRequest, len(overrides))
+	for _, override := range overrides {
+		options := make(map[string]string, len(override.Options))
+		for name, value := range override.Options {
+			options[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+		if len(options) != len(product.Axes) {
+			return nil, errors.ErrBadRequest
+		}
+		key := variantKey(product.Axes, options)
+		if !known[key] {
+			return nil, errors.ErrBadRequest
+		}
+		if _, ok := byKey[key]; ok {
+			return nil, errors.ErrBadRequest
+		}
+		byKey[key] = override
+	}
+
+	created := make(map[string]bool, len(existing))
+	for _, variant := range existing {
+		created[variantKey(product.Axes, variant.Options)] = true
+	}
+
+	variants := make([]model.Item, 0, len(combinations))
+	for _, combination := range combinations {
+		key := variantKey(product.Axes, combination)
+		if created[key] {
+			continue
+		}
+
+		values := make([]string, 0, len(product.Axes))
+		for _, axis := range product.Axes {
+			values = append(values, combination[axis.Name])
+		}
+
+		sku := product.SKU
+		for _, value := range values {
+			sku += "-" + strings.ToUpper(strings.ReplaceAll(value, " ", "-"))
+		}
+		unitPrice := product.UnitPrice
+
+		if override, ok := byKey[key]; ok {
+			if strings.TrimSpace(override.SKU) != "" {
+				sku = strings.ToUpper(strings.TrimSpace(override.SKU))
+			}
+			if override.UnitPrice != nil {
+				unitPrice = *override.UnitPrice
+			}
+		}
+
+		productID := product.ID
+		variants = append(variants, model.Item{
+			ID:             uuid.New(),
+			SKU:            sku,
+			Name:           product.Name + " (" + strings.Join(values, ", ") + ")",
+			Description:    product.Description,
+			UnitPrice:      unitPrice,
+			CostingMethod:  model.CostingMethodFIFO,
+			BaseUnit:       model.DefaultBaseUnit,
+			ProductID:      &productID,
+			VariantOptions: combination,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		})
+	}
+
+	return variants, nil
+}
+
+// variantCombinations returns every combination of the values of the axes, the first axis
+// varying slowest
+func variantCombinations(axes []model.ProductAxis) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, axis := range axes {
+		next := make([]map[string]string, 0, len(combinations)*len(axis.Values))
+		for _, combination := range combinations {
+			for _, value := range axis.Values {
+				options := make(map[string]string, len(combination)+1)
+				for name, v := range combination {
+					options[name] = v
+				}
+				options[axis.Name] = value
+				next = append(next, options)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// variantKey identifies a combination of options by its values in axis order
+func variantKey(axes []model.ProductAxis, options map[string]string) string {
+	values := make([]string, 0, len(axes))
+	for _, axis := range axes {
+		values = append(values, options[axis.Name])
+	}
+	return strings.Join(values, "\x00")
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+func (s *Service) ListReorderSuggestions(ctx context.Context) ([]model.ReorderSuggestion, error) {
+	return s.storage.ListReorderSuggestions(ctx)
+}
+
+// StartReorderMonitor periodically looks for items at or below their reorder point
+// and publishes an inventory.reorder.needed event for them. It blocks until ctx is done.
+func (s *Service) StartReorderMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.checkReorderPoints(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkReorderPoints(ctx)
+		}
+	}
+}
+
+func (s *Service) checkReorderPoints(ctx context.Context) {
+	// Items whose stock recovered become eligible for a new request next time they drop
+	if err := s.storage.ResetRecoveredReorderRequests(ctx); err != nil {
+		s.logger.Error(ctx, "failed to reset recovered reorder requests", zap.Error(err))
+		return
+	}
+
+	suggestions, err := s.storage.ListReorderSuggestions(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list items below reorder point", zap.Error(err))
+		return
+	}
+
+	pending := make([]model.ReorderSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if suggestion.RequestedAt == nil {
+			pending = append(pending, suggestion)
+		}
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	eventItems := make([]map[string]interface{}, 0, len(pending))
+	for _, suggestion := range pending {
+		eventItem := map[string]interface{}{
+			"item_id":          suggestion.ItemID.String(),
+			"sku":              suggestion.SKU,
+			"name":             suggestion.Name,
+			"quantity":         suggestion.Quantity,
+			"reorder_point":    suggestion.ReorderPoint,
+			"reorder_quantity": suggestion.ReorderQuantity,
+		}
+		if suggestion.PreferredVendorID != nil {
+			eventItem["preferred_vendor_id"] = suggestion.PreferredVendorID.String()
+		}
+		eventItems = append(eventItems, eventItem)
+	}
+
+	event := map[string]interface{}{
+		"event_type": "inventory.reorder.needed",
+		"items":      eventItems,
+		"timestamp":  time.Now().Format(time.RFC3339),
+	}
+
+	if err := s.natsClient.Publish("inventory.reorder.needed", event); err != nil {
+		s.logger.Error(ctx, "failed to publish inventory.reorder.needed event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info(ctx, "published inventory.reorder.needed event", zap.Int("items", len(pending)))
+
+	requestedAt := time.Now()
+	for _, suggestion := range pending {
+		if err := s.storage.MarkReorderRequested(ctx, suggestion.ItemID, requestedAt); err != nil {
+			s.logger.Error(ctx, "failed to mark reorder requested",
+				zap.String("item_id", suggestion.ItemID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// handlePurchaseReorderFailed clears the reorder request of items purchasing could not create a
+// draft order for, such as items without a catalog cost from their preferred vendor. They are
+// reported again by the next reorder check while still at or below their reorder point.
+func (s *Service) handlePurchaseReorderFailed(ctx context.Context, msg *nats.Msg) {
+	var event map[string]interface{}
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error(ctx, "failed to unmarshal purchase.reorder.failed event", zap.Error(err))
+		return
+	}
+
+	items, ok := event["items"].([]interface{})
+	if !ok {
+		s.logger.Error(ctx, "invalid items format in purchase.reorder.failed event")
+		return
+	}
+
+	for _, itemData := range items {
+		itemMap, ok := itemData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, err := uuid.Parse(fmt.Sprint(itemMap["item_id"]))
+		if err != nil {
+			continue
+		}
+
+		s.logger.Warn(ctx, "reorder request failed",
+			zap.String("item_id", itemID.String()),
+			zap.String("reason", fmt.Sprint(itemMap["reason"])),
+		)
+
+		if err := s.storage.ClearReorderRequested(ctx, itemID); err != nil {
+			s.logger.Error(ctx, "failed to clear reorder request",
+				zap.String("item_id", itemID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// parseReservationSource checks the source document a reservation is held for
+func parseReservationSource(sourceType, sourceID string) (model.ReservationSourceType, uuid.UUID, error) {
+	source := model.ReservationSourceType(sourceType)
+	if source != model.ReservationSourceSalesOrder {
+		return "", uuid.Nil, errors.ErrBadRequest
+	}
+
+	id, err := uuid.Parse(sourceID)
+	if err != nil {
+		return "", uuid.Nil, errors.ErrBadRequest
+	}
+
+	return source, id, nil
+}
+
+// ReserveStock replaces the reservations held for a source document with the requested items.
+// Kits are reserved as their components, since those are what is issued when the document is
+// fulfilled. Reserving more of an item than is available in the warehouse is rejected with
+// ErrConflict.
+func (s *Service) ReserveStock(ctx context.Context, sourceType, sourceID string, req model.ReserveStockRequest) ([]model.StockReservation, error) {
+	source, documentID, err := parseReservationSource(sourceType, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouseID := s.defaultWarehouseID
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.reservationTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, errors.ErrBadRequest
+		}
+		expiresAt = req.ExpiresAt.UTC()
+	}
+
+	itemIDs := make([]string, 0, len(req.Items))
+	quantities := make(map[string]int, len(req.Items))
+	for _, itemReq := range req.Items {
+		item, err := s.storage.GetItemByID(ctx, itemReq.ItemID.String())
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrBadRequest
+			}
+			return nil, err
+		}
+		if item.ArchivedAt != nil {
+			return nil, errors.ErrBadRequest
+		}
+
+		itemID := item.ID.String()
+		if _, seen := quantities[itemID]; !seen {
+			itemIDs = append(itemIDs, itemID)
+		}
+		quantities[itemID] += itemReq.Quantity
+	}
+	itemIDs, quantities = s.explodeKits(ctx, itemIDs, quantities)
+
+	reservations := make([]model.StockReservation, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		reservations = append(reservations, model.StockReservation{
+			ID:          uuid.New(),
+			ItemID:      uuid.MustParse(itemID),
+			WarehouseID: warehouseID,
+			Quantity:    quantities[itemID],
+			SourceType:  source,
+			SourceID:    documentID,
+			ExpiresAt:   &expiresAt,
+			CreatedAt:   now,
+		})
+	}
+
+	if err := s.storage.ReplaceStockReservations(ctx, source, documentID, reservations); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "reserved stock",
+		zap.String("source_type", source.String()),
+		zap.String("source_id", documentID.String()),
+		zap.Int("items", len(reservations)),
+	)
+
+	return reservations, nil
+}
+
+func (s *Service) ListSourceReservations(ctx context.Context, sourceType, sourceID string) ([]model.StockReservation, error) {
+	source, documentID, err := parseReservationSource(sourceType, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListStockReservationsBySource(ctx, source, documentID, time.Now().UTC())
+}
+
+func (s *Service) ListItemReservations(ctx context.Context, itemID string, limit, offset int) ([]model.StockReservation, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListStockReservationsByItemID(ctx, itemID, time.Now().UTC(), limit, offset)
+}
+
+// ReleaseReservations removes the reservations held for a source document. Releasing a document
+// without reservations is not an error.
+func (s *Service) ReleaseReservations(ctx context.Context, sourceType, sourceID string) error {
+	source, documentID, err := parseReservationSource(sourceType, sourceID)
+	if err != nil {
+		return err
+	}
+
+	return s.releaseReservations(ctx, source, documentID)
+}
+
+func (s *Service) releaseReservations(ctx context.Context, source model.ReservationSourceType, documentID uuid.UUID) error {
+	released, err := s.storage.ReleaseStockReservations(ctx, source, documentID)
+	if err != nil {
+		return err
+	}
+
+	if released > 0 {
+		s.logger.Info(ctx, "released stock reservations",
+			zap.String("source_type", source.String()),
+			zap.String("source_id", documentID.String()),
+			zap.Int64("reservations", released),
+		)
+	}
+
+	return nil
+}
+
+// StartReservationExpiry purges reservations as they expire. Expired reservations already stop
+// counting as reserved; purging keeps them out of the table.
+func (s *Service) StartReservationExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.expireReservations(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expireReservations(ctx)
+		}
+	}
+}
+
+func (s *Service) expireReservations(ctx context.Context) {
+	expired, err := s.storage.DeleteExpiredStockReservations(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.Error(ctx, "failed to expire stock reservations", zap.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		s.logger.Info(ctx, "expired stock reservations", zap.Int64("reservations", expired))
+	}
+}
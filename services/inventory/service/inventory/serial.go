@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+)
+
+func (s *Service) ListStockSerials(ctx context.Context, filter model.StockSerialFilter, limit, offset int) ([]model.StockSerial, error) {
+	if _, err := s.storage.GetItemByID(ctx, filter.ItemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListStockSerials(ctx, filter, limit, offset)
+}
+
+// LookupSerial finds the units carrying a serial number, across all items
+func (s *Service) LookupSerial(ctx context.Context, serialNumber string) ([]model.SerialLookup, error) {
+	serials, err := s.storage.LookupSerials(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(serials) == 0 {
+		return nil, errors.ErrNotFound
+	}
+
+	return serials, nil
+}
+
+// eventSerialsByItem reads the serial numbers carried by an order event, keyed by item ID
+func eventSerialsByItem(event map[string]interface{}) map[string][]string {
+	serialsByItem := make(map[string][]string)
+
+	serials, ok := event["serials"].([]interface{})
+	if !ok {
+		return serialsByItem
+	}
+
+	for _, serialData := range serials {
+		serialMap, ok := serialData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, ok := serialMap["item_id"].(string)
+		if !ok {
+			continue
+		}
+
+		serialNumbers, ok := serialMap["serial_numbers"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, serialNumber := range serialNumbers {
+			if number, ok := serialNumber.(string); ok && strings.TrimSpace(number) != "" {
+				serialsByItem[itemID] = append(serialsByItem[itemID], number)
+			}
+		}
+	}
+
+	return serialsByItem
+}
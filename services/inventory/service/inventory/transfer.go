@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CreateStockTransfer drafts a transfer of stock between two locations. Nothing moves until the
+// transfer is shipped.
+func (s *Service) CreateStockTransfer(ctx context.Context, req model.CreateStockTransferRequest) (model.StockTransferWithLines, error) {
+	if req.SourceLocationID == req.DestinationLocationID {
+		return model.StockTransferWithLines{}, errors.ErrBadRequest
+	}
+
+	for _, locationID := range []uuid.UUID{req.SourceLocationID, req.DestinationLocationID} {
+		if _, err := s.storage.GetLocationByID(ctx, locationID.String()); err != nil {
+			if err == errors.ErrNotFound {
+				return model.StockTransferWithLines{}, errors.ErrBadRequest
+			}
+			return model.StockTransferWithLines{}, err
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Lines))
+	lines := make([]model.StockTransferLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		if seen[line.ItemID] {
+			return model.StockTransferWithLines{}, errors.ErrBadRequest
+		}
+		seen[line.ItemID] = true
+
+		if _, err := s.storage.GetItemByID(ctx, line.ItemID.String()); err != nil {
+			if err == errors.ErrNotFound {
+				return model.StockTransferWithLines{}, errors.ErrBadRequest
+			}
+			return model.StockTransferWithLines{}, err
+		}
+
+		lines = append(lines, model.StockTransferLine{
+			ID:            uuid.New(),
+			ItemID:        line.ItemID,
+			Quantity:      line.Quantity,
+			SerialNumbers: line.SerialNumbers,
+		})
+	}
+
+	transfer := model.StockTransfer{
+		ID:                    uuid.New(),
+		SourceLocationID:      req.SourceLocationID,
+		DestinationLocationID: req.DestinationLocationID,
+		Status:                model.StockTransferStatusDraft,
+		Notes:                 strings.TrimSpace(req.Notes),
+		CreatedBy:             middleware.GetUserIDFromContext(ctx),
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	if err := s.storage.CreateStockTransfer(ctx, transfer, lines); err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	return s.publishStockTransferEvent(ctx, transfer.ID.String(), "inventory.transfer.created")
+}
+
+func (s *Service) GetStockTransfer(ctx context.Context, id string) (model.StockTransferWithLines, error) {
+	transfer, err := s.storage.GetStockTransferByID(ctx, id)
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	lines, err := s.storage.ListStockTransferLines(ctx, id)
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	return model.StockTransferWithLines{
+		StockTransfer: transfer,
+		Lines:         lines,
+	}, nil
+}
+
+func (s *Service) ListStockTransfers(ctx context.Context, filter model.StockTransferFilter, limit, offset int) ([]model.StockTransfer, error) {
+	return s.storage.ListStockTransfers(ctx, filter, limit, offset)
+}
+
+// ShipStockTransfer takes the transfer's stock out of the source location and puts it in transit
+func (s *Service) ShipStockTransfer(ctx context.Context, id string) (model.StockTransferWithLines, error) {
+	if err := s.storage.ShipStockTransfer(ctx, id, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	transfer, err := s.publishStockTransferEvent(ctx, id, "inventory.transfer.shipped")
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	s.checkTransferStockLevels(ctx, transfer)
+
+	return transfer, nil
+}
+
+// ReceiveStockTransfer books the stock in transit into the destination location
+func (s *Service) ReceiveStockTransfer(ctx context.Context, id string) (model.StockTransferWithLines, error) {
+	if err := s.storage.ReceiveStockTransfer(ctx, id, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	transfer, err := s.publishStockTransferEvent(ctx, id, "inventory.transfer.received")
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	s.checkTransferStockLevels(ctx, transfer)
+
+	return transfer, nil
+}
+
+// CancelStockTransfer cancels a transfer that has not been received, returning any stock in
+// transit to the source location
+func (s *Service) CancelStockTransfer(ctx context.Context, id string) (model.StockTransferWithLines, error) {
+	if err := s.storage.CancelStockTransfer(ctx, id, middleware.GetUserIDFromContext(ctx)); err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	transfer, err := s.publishStockTransferEvent(ctx, id, "inventory.transfer.cancelled")
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	s.checkTransferStockLevels(ctx, transfer)
+
+	return transfer, nil
+}
+
+// checkTransferStockLevels checks the stock level of every item on a transfer once its stock has
+// moved; on-hand stock leaves with the shipment and comes back on receipt or cancellation
+func (s *Service) checkTransferStockLevels(ctx context.Context, transfer model.StockTransferWithLines) {
+	checked := make(map[uuid.UUID]bool, len(transfer.Lines))
+	for _, line := range transfer.Lines {
+		if !checked[line.ItemID] {
+			checked[line.ItemID] = true
+			s.checkStockLevel(ctx, line.ItemID.String())
+		}
+	}
+}
+
+// publishStockTransferEvent reloads a transfer after a change and publishes the change on the
+// subject given. A failure to publish is logged; the change itself has already been saved.
+func (s *Service) publishStockTransferEvent(ctx context.Context, id, subject string) (model.StockTransferWithLines, error) {
+	transfer, err := s.GetStockTransfer(ctx, id)
+	if err != nil {
+		return model.StockTransferWithLines{}, err
+	}
+
+	eventItems := make([]map[string]interface{}, 0, len(transfer.Lines))
+	for _, line := range transfer.Lines {
+		eventItem := map[string]interface{}{
+			"item_id":  line.ItemID.String(),
+			"sku":      line.SKU,
+			"quantity": line.Quantity,
+		}
+		if len(line.SerialNumbers) > 0 {
+			eventItem["serial_numbers"] = line.SerialNumbers
+		}
+		eventItems = append(eventItems, eventItem)
+	}
+
+	event := map[string]interface{}{
+		"event_type":               subject,
+		"transfer_id":              transfer.ID.String(),
+		"status":                   transfer.Status.String(),
+		"source_location_id":       transfer.SourceLocationID.String(),
+		"source_warehouse_id":      transfer.SourceWarehouseID.String(),
+		"destination_location_id":  transfer.DestinationLocationID.String(),
+		"destination_warehouse_id": transfer.DestinationWarehouseID.String(),
+		"items":                    eventItems,
+		"timestamp":                time.Now().Format(time.RFC3339),
+	}
+
+	if err := s.natsClient.Publish(subject, event); err != nil {
+		s.logger.Error(ctx, "failed to publish stock transfer event",
+			zap.String("subject", subject),
+			zap.String("transfer_id", id),
+			zap.Error(err),
+		)
+	}
+
+	return transfer, nil
+}
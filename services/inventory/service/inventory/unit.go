@@ -0,0 +1,33 @@
+package service
+
+import (
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"strings"
+)
+
+// normalizeItemUnits trims an item's base unit and units of measure, defaulting the base unit.
+// A unit may be named once and may not be the base unit.
+func normalizeItemUnits(baseUnit string, units []model.ItemUnit) (string, []model.ItemUnit, error) {
+	baseUnit = strings.TrimSpace(baseUnit)
+	if baseUnit == "" {
+		baseUnit = model.DefaultBaseUnit
+	}
+
+	seen := map[string]bool{strings.ToLower(baseUnit): true}
+	normalized := make([]model.ItemUnit, 0, len(units))
+	for _, unit := range units {
+		name := strings.TrimSpace(unit.Unit)
+		if seen[strings.ToLower(name)] {
+			return "", nil, errors.ErrBadRequest
+		}
+		seen[strings.ToLower(name)] = true
+
+		normalized = append(normalized, model.ItemUnit{
+			Unit:             name,
+			ConversionFactor: unit.ConversionFactor,
+		})
+	}
+
+	return baseUnit, normalized, nil
+}
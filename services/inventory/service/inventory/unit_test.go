@@ -0,0 +1,76 @@
+package service
+
+import (
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeItemUnits(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseUnit     string
+		units        []model.ItemUnit
+		wantBaseUnit string
+		wantUnits    []model.ItemUnit
+		wantErr      error
+	}{
+		{
+			name:         "base unit defaults to each",
+			baseUnit:     "",
+			wantBaseUnit: model.DefaultBaseUnit,
+			wantUnits:    []model.ItemUnit{},
+		},
+		{
+			name:         "names are trimmed",
+			baseUnit:     " kg ",
+			units:        []model.ItemUnit{{Unit: " sack ", ConversionFactor: 25}},
+			wantBaseUnit: "kg",
+			wantUnits:    []model.ItemUnit{{Unit: "sack", ConversionFactor: 25}},
+		},
+		{
+			name:         "several units",
+			baseUnit:     "each",
+			units:        []model.ItemUnit{{Unit: "case", ConversionFactor: 12}, {Unit: "pallet", ConversionFactor: 480}},
+			wantBaseUnit: "each",
+			wantUnits:    []model.ItemUnit{{Unit: "case", ConversionFactor: 12}, {Unit: "pallet", ConversionFactor: 480}},
+		},
+		{
+			name:     "unit named twice is rejected",
+			baseUnit: "each",
+			units:    []model.ItemUnit{{Unit: "case", ConversionFactor: 12}, {Unit: "Case", ConversionFactor: 24}},
+			wantErr:  errors.ErrBadRequest,
+		},
+		{
+			name:     "unit naming the base unit is rejected",
+			baseUnit: "each",
+			units:    []model.ItemUnit{{Unit: " EACH", ConversionFactor: 2}},
+			wantErr:  errors.ErrBadRequest,
+		},
+		{
+			name:    "unit naming the default base unit is rejected",
+			units:   []model.ItemUnit{{Unit: "each", ConversionFactor: 2}},
+			wantErr: errors.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseUnit, units, err := normalizeItemUnits(tt.baseUnit, tt.units)
+			if err != tt.wantErr {
+				t.Fatalf("normalizeItemUnits() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if baseUnit != tt.wantBaseUnit {
+				t.Errorf("base unit = %q, want %q", baseUnit, tt.wantBaseUnit)
+			}
+			if !slices.Equal(units, tt.wantUnits) {
+				t.Errorf("units = %v, want %v", units, tt.wantUnits)
+			}
+		})
+	}
+}
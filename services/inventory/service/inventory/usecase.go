@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"microservice-challenge/package/errors"
 	"microservice-challenge/package/log"
+	"microservice-challenge/package/middleware"
 	natsclient "microservice-challenge/package/nats"
+	"microservice-challenge/services/inventory/client"
 	"microservice-challenge/services/inventory/model"
 	"microservice-challenge/services/inventory/storage"
 	"strings"
@@ -16,21 +18,68 @@ import (
 	"go.uber.org/zap"
 )
 
+// maxSearchQueryLength caps the length of an item search query
+const maxSearchQueryLength = 200
+
 type Service struct {
-	storage    storage.Storage
-	natsClient *natsclient.Client
-	logger     log.Logger
+	storage            storage.Storage
+	natsClient         *natsclient.Client
+	salesClient        *client.SalesClient
+	purchaseClient     *client.PurchaseClient
+	authClient         *client.AuthClient
+	defaultWarehouseID uuid.UUID
+	reservationTTL     time.Duration
+	logger             log.Logger
 }
 
-func NewService(storage storage.Storage, natsClient *natsclient.Client, logger log.Logger) *Service {
+func NewService(storage storage.Storage, natsClient *natsclient.Client, salesClient *client.SalesClient, purchaseClient *client.PurchaseClient, authClient *client.AuthClient, defaultWarehouseID uuid.UUID, reservationTTL time.Duration, logger log.Logger) *Service {
 	return &Service{
-		storage:    storage,
-		natsClient: natsClient,
-		logger:     logger,
+		storage:            storage,
+		natsClient:         natsClient,
+		salesClient:        salesClient,
+		purchaseClient:     purchaseClient,
+		authClient:         authClient,
+		defaultWarehouseID: defaultWarehouseID,
+		reservationTTL:     reservationTTL,
+		logger:             logger,
 	}
 }
 
 func (s *Service) CreateItem(ctx context.Context, req model.CreateItemRequest) (model.Item, error) {
+	item, err := s.newItem(ctx, req)
+	if err != nil {
+		return model.Item{}, err
+	}
+
+	if err := s.storage.CreateItem(ctx, item); err != nil {
+		return model.Item{}, err
+	}
+
+	location, err := s.storage.GetDefaultLocation(ctx, s.defaultWarehouseID.String())
+	if err != nil {
+		s.logger.Error(ctx, "failed to find default location for initial stock", zap.Error(err))
+		return item, nil
+	}
+
+	stock := model.Stock{
+		ID:         uuid.New(),
+		ItemID:     item.ID,
+		LocationID: location.ID,
+		Quantity:   0,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := s.storage.CreateStock(ctx, stock); err != nil {
+		s.logger.Error(ctx, "failed to create initial stock", zap.Error(err))
+	}
+
+	return item, nil
+}
+
+// newItem checks a create request against the existing items, units and category attributes and
+// builds the item to write
+func (s *Service) newItem(ctx context.Context, req model.CreateItemRequest) (model.Item, error) {
 	sku := strings.ToUpper(strings.TrimSpace(req.SKU))
 
 	_, err := s.storage.GetItemBySKU(ctx, sku)
@@ -41,32 +90,42 @@ func (s *Service) CreateItem(ctx context.Context, req model.CreateItemRequest) (
 		return model.Item{}, err
 	}
 
+	if req.CostingMethod == "" {
+		req.CostingMethod = model.CostingMethodFIFO
+	}
+
+	baseUnit, units, err := normalizeItemUnits(req.BaseUnit, req.Units)
+	if err != nil {
+		return model.Item{}, err
+	}
+
+	attributes, err := s.itemAttributes(ctx, req.CategoryID, req.Attributes)
+	if err != nil {
+		return model.Item{}, err
+	}
+
 	item := model.Item{
 		ID:          uuid.New(),
 		Name:        strings.TrimSpace(req.Name),
 		Description: strings.TrimSpace(req.Description),
 		SKU:         sku,
 		UnitPrice:   req.UnitPrice,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
 
-	if err := s.storage.CreateItem(ctx, item); err != nil {
-		return model.Item{}, err
-	}
+		ReorderPoint:      req.ReorderPoint,
+		ReorderQuantity:   req.ReorderQuantity,
+		MinStock:          req.MinStock,
+		PreferredVendorID: req.PreferredVendorID,
+		Serialized:        req.Serialized,
+		CostingMethod:     req.CostingMethod,
+		BaseUnit:          baseUnit,
+		Units:             units,
+		CategoryID:        req.CategoryID,
+		Attributes:        attributes,
 
-	stock := model.Stock{
-		ID:        uuid.New(),
-		ItemID:    item.ID,
-		Quantity:  0,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	if err := s.storage.CreateStock(ctx, stock); err != nil {
-		s.logger.Error(ctx, "failed to create initial stock", zap.Error(err))
-	}
-
 	return item, nil
 }
 
@@ -74,8 +133,22 @@ func (s *Service) GetItemByID(ctx context.Context, id string) (model.Item, error
 	return s.storage.GetItemByID(ctx, id)
 }
 
-func (s *Service) ListItems(ctx context.Context, limit, offset int) ([]model.Item, error) {
-	return s.storage.ListItems(ctx, limit, offset)
+func (s *Service) ListItems(ctx context.Context, filter model.ItemFilter, limit, offset int) ([]model.Item, error) {
+	return s.storage.ListItems(ctx, filter, limit, offset)
+}
+
+// SearchItems finds items by full-text search over their SKU, name and description, with fuzzy
+// matching on the SKU
+func (s *Service) SearchItems(ctx context.Context, filter model.ItemSearchFilter, limit, offset int) ([]model.ItemSearchResult, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" || len(filter.Query) > maxSearchQueryLength {
+		return nil, errors.ErrBadRequest
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, errors.ErrBadRequest
+	}
+
+	return s.storage.SearchItems(ctx, filter, limit, offset)
 }
 
 func (s *Service) UpdateItem(ctx context.Context, id string, req model.UpdateItemRequest) (model.Item, error) {
@@ -84,6 +157,21 @@ func (s *Service) UpdateItem(ctx context.Context, id string, req model.UpdateIte
 		return model.Item{}, err
 	}
 
+	item, err = s.updatedItem(ctx, item, req)
+	if err != nil {
+		return model.Item{}, err
+	}
+
+	if err := s.storage.UpdateItem(ctx, item); err != nil {
+		return model.Item{}, err
+	}
+
+	return item, nil
+}
+
+// updatedItem checks an update request against the existing items, units, category attributes
+// and the item's stock, and applies it to the item
+func (s *Service) updatedItem(ctx context.Context, item model.Item, req model.UpdateItemRequest) (model.Item, error) {
 	sku := strings.ToUpper(strings.TrimSpace(req.SKU))
 	if sku != item.SKU {
 		_, err := s.storage.GetItemBySKU(ctx, sku)
@@ -95,45 +183,225 @@ func (s *Service) UpdateItem(ctx context.Context, id string, req model.UpdateIte
 		}
 	}
 
+	if req.CostingMethod == "" {
+		req.CostingMethod = model.CostingMethodFIFO
+	}
+
+	baseUnit, units, err := normalizeItemUnits(req.BaseUnit, req.Units)
+	if err != nil {
+		return model.Item{}, err
+	}
+
+	attributes, err := s.itemAttributes(ctx, req.CategoryID, req.Attributes)
+	if err != nil {
+		return model.Item{}, err
+	}
+
+	// Units already in stock have no serial numbers to track them by, were costed under the old
+	// method and were counted in the old base unit
+	if req.Serialized != item.Serialized || req.CostingMethod != item.CostingMethod || !strings.EqualFold(baseUnit, item.BaseUnit) {
+		stock, err := s.storage.GetStockByItemID(ctx, item.ID.String())
+		if err != nil {
+			return model.Item{}, err
+		}
+		if stock.Quantity > 0 || stock.InTransit > 0 {
+			return model.Item{}, errors.ErrBadRequest
+		}
+	}
+
 	item.Name = strings.TrimSpace(req.Name)
 	item.Description = strings.TrimSpace(req.Description)
 	item.SKU = sku
 	item.UnitPrice = req.UnitPrice
+	item.ReorderPoint = req.ReorderPoint
+	item.ReorderQuantity = req.ReorderQuantity
+	item.MinStock = req.MinStock
+	item.PreferredVendorID = req.PreferredVendorID
+	item.Serialized = req.Serialized
+	item.CostingMethod = req.CostingMethod
+	item.BaseUnit = baseUnit
+	item.Units = units
+	item.CategoryID = req.CategoryID
+	item.Attributes = attributes
 	item.UpdatedAt = time.Now()
 
-	if err := s.storage.UpdateItem(ctx, item); err != nil {
-		return model.Item{}, err
-	}
-
 	return item, nil
 }
 
-func (s *Service) DeleteItem(ctx context.Context, id string) error {
-	return s.storage.DeleteItem(ctx, id)
-}
+func (s *Service) GetStockByItemID(ctx context.Context, itemID string) (model.ItemStock, error) {
+	if _, err := s.storage.GetItemByID(ctx, itemID); err != nil {
+		return model.ItemStock{}, err
+	}
 
-func (s *Service) GetStockByItemID(ctx context.Context, itemID string) (model.Stock, error) {
 	return s.storage.GetStockByItemID(ctx, itemID)
 }
 
-func (s *Service) AdjustStock(ctx context.Context, itemID string, quantity int) (model.Stock, error) {
+// AdjustStock changes the stock held at a location, or at the default warehouse's default
+// location when no location is given. The change is recorded against the requesting user.
+func (s *Service) AdjustStock(ctx context.Context, itemID string, req model.AdjustStockRequest) (model.ItemStock, error) {
 	_, err := s.storage.GetItemByID(ctx, itemID)
 	if err != nil {
-		return model.Stock{}, err
+		return model.ItemStock{}, err
+	}
+
+	var location model.Location
+	if req.LocationID != nil {
+		location, err = s.storage.GetLocationByID(ctx, req.LocationID.String())
+	} else {
+		location, err = s.storage.GetDefaultLocation(ctx, s.defaultWarehouseID.String())
+	}
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return model.ItemStock{}, errors.ErrBadRequest
+		}
+		return model.ItemStock{}, err
+	}
+
+	source := model.StockMovementSource{
+		Reason:           req.Reason,
+		SourceDocumentID: req.SourceDocumentID,
+		UserID:           middleware.GetUserIDFromContext(ctx),
+	}
+	if source.Reason == "" {
+		source.Reason = model.StockMovementReasonAdjustment
+	}
+
+	var lot *model.LotRef
+	if req.LotNumber != "" {
+		lot = &model.LotRef{
+			LotNumber:  req.LotNumber,
+			ExpiryDate: truncateToDate(req.ExpiryDate),
+		}
 	}
 
-	if err := s.storage.AdjustStock(ctx, itemID, quantity); err != nil {
-		return model.Stock{}, err
+	if err := s.storage.AdjustStock(ctx, itemID, location.ID.String(), req.Quantity, lot, req.SerialNumbers, source); err != nil {
+		return model.ItemStock{}, err
 	}
+	s.checkStockLevel(ctx, itemID)
 
 	stock, err := s.storage.GetStockByItemID(ctx, itemID)
 	if err != nil {
-		return model.Stock{}, err
+		return model.ItemStock{}, err
 	}
 
 	return stock, nil
 }
 
+func (s *Service) ListStockMovements(ctx context.Context, filter model.StockMovementFilter, limit, offset int) ([]model.StockMovement, error) {
+	if _, err := s.storage.GetItemByID(ctx, filter.ItemID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListStockMovements(ctx, filter, limit, offset)
+}
+
+// eventMovementSource builds the ledger source for stock changed by an order event. The source
+// document is taken from the event field named by documentKey.
+func eventMovementSource(event map[string]interface{}, reason model.StockMovementReason, documentKey string) model.StockMovementSource {
+	source := model.StockMovementSource{Reason: reason}
+
+	if documentID, ok := event[documentKey].(string); ok {
+		if id, err := uuid.Parse(documentID); err == nil {
+			source.SourceDocumentID = &id
+		}
+	}
+	if userID, ok := event["user_id"].(string); ok {
+		source.UserID = userID
+	}
+
+	return source
+}
+
+// eventWarehouseID returns the warehouse named by an order event, falling back to the
+// configured default warehouse
+func (s *Service) eventWarehouseID(event map[string]interface{}) string {
+	if warehouseID, ok := event["warehouse_id"].(string); ok && warehouseID != "" {
+		return warehouseID
+	}
+	return s.defaultWarehouseID.String()
+}
+
+// eventItemQuantities totals the quantity of each item on the lines of an order event. Item IDs
+// are returned in the order they first appear.
+func eventItemQuantities(items []interface{}) ([]string, map[string]int) {
+	itemIDs := make([]string, 0, len(items))
+	quantities := make(map[string]int, len(items))
+	for _, itemData := range items {
+		itemMap, ok := itemData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, ok := itemMap["item_id"].(string)
+		if !ok {
+			continue
+		}
+
+		quantity, ok := eventItemBaseQuantity(itemMap)
+		if !ok {
+			continue
+		}
+
+		if _, seen := quantities[itemID]; !seen {
+			itemIDs = append(itemIDs, itemID)
+		}
+		quantities[itemID] += int(quantity)
+	}
+	return itemIDs, quantities
+}
+
+// eventItemBaseQuantity reads the quantity of an order event line in the item's base unit. Lines
+// published before units of measure only carry the quantity.
+func eventItemBaseQuantity(itemMap map[string]interface{}) (float64, bool) {
+	if quantity, ok := itemMap["base_quantity"].(float64); ok {
+		return quantity, true
+	}
+	quantity, ok := itemMap["quantity"].(float64)
+	return quantity, ok
+}
+
+// eventItemCosts collects the quantity and unit cost of the lines of an order event, keyed by
+// item ID
+func eventItemCosts(items []interface{}) map[string][]model.UnitCostQuantity {
+	costs := make(map[string][]model.UnitCostQuantity, len(items))
+	for _, itemData := range items {
+		itemMap, ok := itemData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, ok := itemMap["item_id"].(string)
+		if !ok {
+			continue
+		}
+
+		quantity, ok := eventItemBaseQuantity(itemMap)
+		if !ok {
+			continue
+		}
+
+		// Prefer the landed unit cost, which includes the allocated freight, duty and
+		// other charges, and fall back to the bare unit price
+		unitCost, ok := itemMap["landed_unit_cost"].(float64)
+		if !ok {
+			unitCost, ok = itemMap["unit_price"].(float64)
+			if !ok {
+				continue
+			}
+		}
+		// Both costs are per unit entered on the line
+		if factor, ok := itemMap["conversion_factor"].(float64); ok && factor > 0 {
+			unitCost /= factor
+		}
+
+		costs[itemID] = append(costs[itemID], model.UnitCostQuantity{
+			Quantity: int(quantity),
+			UnitCost: unitCost,
+		})
+	}
+	return costs
+}
+
 func (s *Service) StartEventSubscriptions(ctx context.Context) error {
 	salesSub, err := s.natsClient.Subscribe("sales.order.confirmed", func(msg *nats.Msg) {
 		s.handleSalesOrderConfirmed(ctx, msg)
@@ -151,6 +419,22 @@ func (s *Service) StartEventSubscriptions(ctx context.Context) error {
 	}
 	s.logger.Info(ctx, "subscribed to purchase.order.received", zap.String("subscription", purchaseSub.Subject))
 
+	returnSub, err := s.natsClient.Subscribe("purchase.order.returned", func(msg *nats.Msg) {
+		s.handlePurchaseOrderReturned(ctx, msg)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info(ctx, "subscribed to purchase.order.returned", zap.String("subscription", returnSub.Subject))
+
+	reorderFailedSub, err := s.natsClient.Subscribe("purchase.reorder.failed", func(msg *nats.Msg) {
+		s.handlePurchaseReorderFailed(ctx, msg)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info(ctx, "subscribed to purchase.reorder.failed", zap.String("subscription", reorderFailedSub.Subject))
+
 	return nil
 }
 
@@ -167,33 +451,36 @@ func (s *Service) handleSalesOrderConfirmed(ctx context.Context, msg *nats.Msg)
 		return
 	}
 
-	for _, itemData := range items {
-		itemMap, ok := itemData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		itemID, ok := itemMap["item_id"].(string)
-		if !ok {
-			continue
-		}
+	warehouseID := s.eventWarehouseID(event)
+	source := eventMovementSource(event, model.StockMovementReasonSale, "order_id")
+	source.EventType = msg.Subject
+	serialsByItem := eventSerialsByItem(event)
+
+	// An item may appear on several lines; ship it once so its serial numbers can span the lines.
+	// Kits are shipped as their components.
+	itemIDs, quantities := eventItemQuantities(items)
+	itemIDs, quantities = s.explodeKits(ctx, itemIDs, quantities)
+
+	lines := make([]model.StockEventLine, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		lines = append(lines, model.StockEventLine{
+			ItemID:        itemID,
+			Quantity:      -quantities[itemID],
+			SerialNumbers: serialsByItem[itemID],
+		})
+	}
 
-		quantity, ok := itemMap["quantity"].(float64)
-		if !ok {
-			continue
-		}
+	// The reservation is released whether or not the stock could be issued: a confirmed order is
+	// no longer a draft holding stock, and a failed issue is reported through
+	// inventory.stock.adjustment_failed rather than by keeping the stock held
+	s.applyStockEvent(ctx, event, "order_id", warehouseID, lines, source)
 
-		if err := s.storage.AdjustStock(ctx, itemID, -int(quantity)); err != nil {
-			s.logger.Error(ctx, "failed to decrease stock for sales order",
-				zap.String("item_id", itemID),
-				zap.Int("quantity", int(quantity)),
+	if source.SourceDocumentID != nil {
+		if err := s.releaseReservations(ctx, model.ReservationSourceSalesOrder, *source.SourceDocumentID); err != nil {
+			s.logger.Error(ctx, "failed to release stock reservations for sales order",
+				zap.String("order_id", source.SourceDocumentID.String()),
 				zap.Error(err),
 			)
-		} else {
-			s.logger.Info(ctx, "decreased stock for sales order",
-				zap.String("item_id", itemID),
-				zap.Int("quantity", int(quantity)),
-			)
 		}
 	}
 }
@@ -211,33 +498,123 @@ func (s *Service) handlePurchaseOrderReceived(ctx context.Context, msg *nats.Msg
 		return
 	}
 
-	for _, itemData := range items {
-		itemMap, ok := itemData.(map[string]interface{})
-		if !ok {
-			continue
-		}
+	warehouseID := s.eventWarehouseID(event)
+	source := eventMovementSource(event, model.StockMovementReasonPurchase, "order_id")
+	source.EventType = msg.Subject
+	lotsByItem := eventLotsByItem(event)
+	serialsByItem := eventSerialsByItem(event)
+	costsByItem := eventItemCosts(items)
+
+	// An item may appear on several lines; receive it once so its lots can span the lines. Each
+	// line still opens its own cost layer at the line's unit price.
+	itemIDs, quantities := eventItemQuantities(items)
+
+	lines := make([]model.StockEventLine, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		lines = append(lines, model.StockEventLine{
+			ItemID:        itemID,
+			Quantity:      quantities[itemID],
+			Lots:          lotsByItem[itemID],
+			SerialNumbers: serialsByItem[itemID],
+			Costs:         costsByItem[itemID],
+		})
+	}
 
-		itemID, ok := itemMap["item_id"].(string)
-		if !ok {
-			continue
-		}
+	s.applyStockEvent(ctx, event, "order_id", warehouseID, lines, source)
+}
 
-		quantity, ok := itemMap["quantity"].(float64)
-		if !ok {
-			continue
-		}
+func (s *Service) handlePurchaseOrderReturned(ctx context.Context, msg *nats.Msg) {
+	var event map[string]interface{}
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error(ctx, "failed to unmarshal purchase.order.returned event", zap.Error(err))
+		return
+	}
 
-		if err := s.storage.AdjustStock(ctx, itemID, int(quantity)); err != nil {
-			s.logger.Error(ctx, "failed to increase stock for purchase order",
-				zap.String("item_id", itemID),
-				zap.Int("quantity", int(quantity)),
-				zap.Error(err),
-			)
-		} else {
-			s.logger.Info(ctx, "increased stock for purchase order",
-				zap.String("item_id", itemID),
-				zap.Int("quantity", int(quantity)),
-			)
+	items, ok := event["items"].([]interface{})
+	if !ok {
+		s.logger.Error(ctx, "invalid items format in purchase.order.returned event")
+		return
+	}
+
+	warehouseID := s.eventWarehouseID(event)
+	source := eventMovementSource(event, model.StockMovementReasonReturn, "return_id")
+	source.EventType = msg.Subject
+	serialsByItem := eventSerialsByItem(event)
+
+	itemIDs, quantities := eventItemQuantities(items)
+
+	lines := make([]model.StockEventLine, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		lines = append(lines, model.StockEventLine{
+			ItemID:        itemID,
+			Quantity:      -quantities[itemID],
+			SerialNumbers: serialsByItem[itemID],
+		})
+	}
+
+	s.applyStockEvent(ctx, event, "return_id", warehouseID, lines, source)
+}
+
+// applyStockEvent applies the stock lines of an order event to a warehouse as a whole. If any line
+// fails nothing is applied, and inventory.stock.adjustment_failed is published naming the failing
+// lines. Lines already applied for the event, as when NATS redelivers a message or an order's
+// event is published again, are skipped.
+func (s *Service) applyStockEvent(ctx context.Context, event map[string]interface{}, documentKey, warehouseID string, lines []model.StockEventLine, source model.StockMovementSource) {
+	applied, failures, err := s.storage.ApplyStockEventLines(ctx, warehouseID, lines, source)
+	if err != nil {
+		s.logger.Error(ctx, "failed to apply stock event",
+			zap.String("event_type", source.EventType),
+			zap.String("warehouse_id", warehouseID),
+			zap.Int("lines", len(lines)),
+			zap.Int("failed_lines", len(failures)),
+			zap.Error(err),
+		)
+		if len(failures) > 0 {
+			s.publishStockAdjustmentFailed(ctx, event, documentKey, warehouseID, source, failures)
 		}
+		return
+	}
+
+	if skipped := len(lines) - len(applied); skipped > 0 {
+		s.logger.Info(ctx, "skipped stock changes already applied for event",
+			zap.String("event_type", source.EventType),
+			zap.Int("lines", skipped),
+		)
+	}
+
+	for _, line := range applied {
+		s.logger.Info(ctx, "applied stock change for event",
+			zap.String("event_type", source.EventType),
+			zap.String("item_id", line.ItemID),
+			zap.String("warehouse_id", warehouseID),
+			zap.Int("quantity", line.Quantity),
+			zap.Int("lots", len(line.Lots)),
+			zap.Int("serials", len(line.SerialNumbers)),
+		)
+		s.checkStockLevel(ctx, line.ItemID)
+	}
+}
+
+// publishStockAdjustmentFailed reports an event whose stock could not be applied, naming the
+// failing lines and the document the event was about
+func (s *Service) publishStockAdjustmentFailed(ctx context.Context, event map[string]interface{}, documentKey, warehouseID string, source model.StockMovementSource, failures []model.StockLineFailure) {
+	failedEvent := map[string]interface{}{
+		"event_type":   "inventory.stock.adjustment_failed",
+		"source_event": source.EventType,
+		"warehouse_id": warehouseID,
+		"lines":        failures,
+		"timestamp":    time.Now().Format(time.RFC3339),
+	}
+	if documentID, ok := event[documentKey].(string); ok {
+		failedEvent[documentKey] = documentID
+	}
+
+	if err := s.natsClient.Publish("inventory.stock.adjustment_failed", failedEvent); err != nil {
+		s.logger.Error(ctx, "failed to publish inventory.stock.adjustment_failed event", zap.Error(err))
+	} else {
+		s.logger.Info(ctx, "published inventory.stock.adjustment_failed event",
+			zap.String("source_event", source.EventType),
+			zap.Int("failed_lines", len(failures)),
+		)
 	}
 }
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"microservice-challenge/services/inventory/model"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEventMovementSource(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name         string
+		event        map[string]interface{}
+		wantDocument *uuid.UUID
+		wantUserID   string
+	}{
+		{
+			name:         "document and user",
+			event:        map[string]interface{}{"order_id": orderID.String(), "user_id": "user-1"},
+			wantDocument: &orderID,
+			wantUserID:   "user-1",
+		},
+		{
+			name:       "no document",
+			event:      map[string]interface{}{"user_id": "user-1"},
+			wantUserID: "user-1",
+		},
+		{
+			name:  "document that is not a UUID",
+			event: map[string]interface{}{"order_id": "not-a-uuid"},
+		},
+		{
+			name:  "document of the wrong type",
+			event: map[string]interface{}{"order_id": 42.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := eventMovementSource(tt.event, model.StockMovementReasonSale, "order_id")
+			if source.Reason != model.StockMovementReasonSale {
+				t.Errorf("reason = %q, want %q", source.Reason, model.StockMovementReasonSale)
+			}
+			if (source.SourceDocumentID == nil) != (tt.wantDocument == nil) ||
+				(source.SourceDocumentID != nil && *source.SourceDocumentID != *tt.wantDocument) {
+				t.Errorf("source document = %v, want %v", source.SourceDocumentID, tt.wantDocument)
+			}
+			if source.UserID != tt.wantUserID {
+				t.Errorf("user = %q, want %q", source.UserID, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestEventItemQuantities(t *testing.T) {
+	tests := []struct {
+		name           string
+		items          []interface{}
+		wantItemIDs    []string
+		wantQuantities map[string]int
+	}{
+		{
+			name: "lines of an item are totalled in order of appearance",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "b", "quantity": 2.0},
+				map[string]interface{}{"item_id": "a", "quantity": 1.0},
+				map[string]interface{}{"item_id": "b", "quantity": 3.0},
+			},
+			wantItemIDs:    []string{"b", "a"},
+			wantQuantities: map[string]int{"a": 1, "b": 5},
+		},
+		{
+			name: "base quantity wins over the quantity entered",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0, "base_quantity": 24.0},
+			},
+			wantItemIDs:    []string{"a"},
+			wantQuantities: map[string]int{"a": 24},
+		},
+		{
+			name: "malformed lines are skipped",
+			items: []interface{}{
+				"not a line",
+				map[string]interface{}{"quantity": 2.0},
+				map[string]interface{}{"item_id": "a"},
+				map[string]interface{}{"item_id": "a", "quantity": "2"},
+			},
+			wantItemIDs:    []string{},
+			wantQuantities: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemIDs, quantities := eventItemQuantities(tt.items)
+			if !slices.Equal(itemIDs, tt.wantItemIDs) {
+				t.Errorf("item IDs = %v, want %v", itemIDs, tt.wantItemIDs)
+			}
+			if len(quantities) != len(tt.wantQuantities) {
+				t.Fatalf("quantities = %v, want %v", quantities, tt.wantQuantities)
+			}
+			for itemID, want := range tt.wantQuantities {
+				if quantities[itemID] != want {
+					t.Errorf("quantity of %s = %d, want %d", itemID, quantities[itemID], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventItemCosts(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []interface{}
+		want  map[string][]model.UnitCostQuantity
+	}{
+		{
+			name: "landed unit cost wins over the unit price",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0, "unit_price": 10.0, "landed_unit_cost": 11.5},
+			},
+			want: map[string][]model.UnitCostQuantity{"a": {{Quantity: 2, UnitCost: 11.5}}},
+		},
+		{
+			name: "unit price without a landed cost",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0, "unit_price": 10.0},
+			},
+			want: map[string][]model.UnitCostQuantity{"a": {{Quantity: 2, UnitCost: 10}}},
+		},
+		{
+			name: "cost per unit entered is spread over the base units",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0, "base_quantity": 24.0, "conversion_factor": 12.0, "unit_price": 30.0},
+			},
+			want: map[string][]model.UnitCostQuantity{"a": {{Quantity: 24, UnitCost: 2.5}}},
+		},
+		{
+			name: "each line of an item keeps its cost",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0, "unit_price": 10.0},
+				map[string]interface{}{"item_id": "a", "quantity": 3.0, "unit_price": 12.0},
+			},
+			want: map[string][]model.UnitCostQuantity{"a": {{Quantity: 2, UnitCost: 10}, {Quantity: 3, UnitCost: 12}}},
+		},
+		{
+			name: "line without a cost is skipped",
+			items: []interface{}{
+				map[string]interface{}{"item_id": "a", "quantity": 2.0},
+			},
+			want: map[string][]model.UnitCostQuantity{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costs := eventItemCosts(tt.items)
+			if len(costs) != len(tt.want) {
+				t.Fatalf("costs = %v, want %v", costs, tt.want)
+			}
+			for itemID, want := range tt.want {
+				if !slices.Equal(costs[itemID], want) {
+					t.Errorf("costs of %s = %v, want %v", itemID, costs[itemID], want)
+				}
+			}
+		})
+	}
+}
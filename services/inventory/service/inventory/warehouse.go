@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateWarehouse creates a warehouse with a DEFAULT location that receives stock booked
+// against the warehouse as a whole
+func (s *Service) CreateWarehouse(ctx context.Context, req model.CreateWarehouseRequest) (model.WarehouseWithLocations, error) {
+	warehouse := model.Warehouse{
+		ID:        uuid.New(),
+		Code:      req.Code,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	defaultLocation := model.Location{
+		ID:          uuid.New(),
+		WarehouseID: warehouse.ID,
+		Code:        "DEFAULT",
+		Name:        "Default Location",
+		IsDefault:   true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.storage.CreateWarehouse(ctx, warehouse, defaultLocation); err != nil {
+		return model.WarehouseWithLocations{}, err
+	}
+
+	return s.GetWarehouseByID(ctx, warehouse.ID.String())
+}
+
+func (s *Service) GetWarehouseByID(ctx context.Context, id string) (model.WarehouseWithLocations, error) {
+	warehouse, err := s.storage.GetWarehouseByID(ctx, id)
+	if err != nil {
+		return model.WarehouseWithLocations{}, err
+	}
+
+	locations, err := s.storage.ListLocationsByWarehouseID(ctx, id)
+	if err != nil {
+		return model.WarehouseWithLocations{}, err
+	}
+
+	return model.WarehouseWithLocations{
+		Warehouse: warehouse,
+		Locations: locations,
+	}, nil
+}
+
+func (s *Service) ListWarehouses(ctx context.Context, limit, offset int) ([]model.Warehouse, error) {
+	return s.storage.ListWarehouses(ctx, limit, offset)
+}
+
+func (s *Service) UpdateWarehouse(ctx context.Context, id string, req model.UpdateWarehouseRequest) (model.WarehouseWithLocations, error) {
+	warehouse, err := s.storage.GetWarehouseByID(ctx, id)
+	if err != nil {
+		return model.WarehouseWithLocations{}, err
+	}
+
+	warehouse.Code = req.Code
+	warehouse.Name = req.Name
+	warehouse.UpdatedAt = time.Now()
+
+	if err := s.storage.UpdateWarehouse(ctx, warehouse); err != nil {
+		return model.WarehouseWithLocations{}, err
+	}
+
+	return s.GetWarehouseByID(ctx, id)
+}
+
+func (s *Service) CreateLocation(ctx context.Context, warehouseID string, req model.CreateLocationRequest) (model.Location, error) {
+	warehouse, err := s.storage.GetWarehouseByID(ctx, warehouseID)
+	if err != nil {
+		return model.Location{}, err
+	}
+
+	location := model.Location{
+		ID:          uuid.New(),
+		WarehouseID: warehouse.ID,
+		Code:        req.Code,
+		Name:        req.Name,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.storage.CreateLocation(ctx, location); err != nil {
+		return model.Location{}, err
+	}
+
+	return s.storage.GetLocationByID(ctx, location.ID.String())
+}
+
+func (s *Service) ListLocations(ctx context.Context, warehouseID string) ([]model.Location, error) {
+	if _, err := s.storage.GetWarehouseByID(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListLocationsByWarehouseID(ctx, warehouseID)
+}
+
+func (s *Service) UpdateLocation(ctx context.Context, id string, req model.UpdateLocationRequest) (model.Location, error) {
+	location, err := s.storage.GetLocationByID(ctx, id)
+	if err != nil {
+		return model.Location{}, err
+	}
+
+	location.Code = req.Code
+	location.Name = req.Name
+	location.UpdatedAt = time.Now()
+
+	if err := s.storage.UpdateLocation(ctx, location); err != nil {
+		return model.Location{}, err
+	}
+
+	return s.storage.GetLocationByID(ctx, id)
+}
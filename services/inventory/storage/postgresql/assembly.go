@@ -0,0 +1,347 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBAssemblyOrderToModel converts sqlc generated db.AssemblyOrder to model.AssemblyOrder
+func convertDBAssemblyOrderToModel(dbOrder db.AssemblyOrder) model.AssemblyOrder {
+	order := model.AssemblyOrder{
+		ID:          dbOrder.ID,
+		ItemID:      dbOrder.ItemID,
+		WarehouseID: dbOrder.WarehouseID,
+		Quantity:    int(dbOrder.Quantity),
+		Status:      model.AssemblyOrderStatus(dbOrder.Status),
+		CompletedAt: convertNullTimeToPtr(dbOrder.CompletedAt),
+		CreatedAt:   dbOrder.CreatedAt,
+		UpdatedAt:   dbOrder.UpdatedAt,
+	}
+
+	if dbOrder.Notes.Valid {
+		order.Notes = dbOrder.Notes.String
+	}
+	if dbOrder.CreatedBy.Valid {
+		order.CreatedBy = dbOrder.CreatedBy.String
+	}
+
+	return order
+}
+
+// CreateAssemblyOrder saves a draft assembly order with the components it will consume
+func (s *Storage) CreateAssemblyOrder(ctx context.Context, order model.AssemblyOrder, lines []model.AssemblyOrderLine) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.CreateAssemblyOrderParams{
+		ID:          order.ID,
+		ItemID:      order.ItemID,
+		WarehouseID: order.WarehouseID,
+		Quantity:    int32(order.Quantity),
+		Status:      string(order.Status),
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+	}
+	if order.Notes != "" {
+		params.Notes = sql.NullString{String: order.Notes, Valid: true}
+	}
+	if order.CreatedBy != "" {
+		params.CreatedBy = sql.NullString{String: order.CreatedBy, Valid: true}
+	}
+	if err := qtx.CreateAssemblyOrder(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return errors.ErrBadRequest
+		}
+		return errors.ErrInternalServerError
+	}
+
+	for _, line := range lines {
+		lineParams := db.CreateAssemblyOrderLineParams{
+			ID:              line.ID,
+			AssemblyOrderID: order.ID,
+			ItemID:          line.ItemID,
+			Quantity:        int32(line.Quantity),
+		}
+		if err := qtx.CreateAssemblyOrderLine(ctx, lineParams); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok {
+				switch pqErr.Code {
+				case "23503", "23505":
+					return errors.ErrBadRequest
+				}
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetAssemblyOrderByID(ctx context.Context, id string) (model.AssemblyOrder, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return model.AssemblyOrder{}, errors.ErrBadRequest
+	}
+
+	row, err := s.queries.GetAssemblyOrderByID(ctx, orderID)
+	if err == sql.ErrNoRows {
+		return model.AssemblyOrder{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.AssemblyOrder{}, errors.ErrInternalServerError
+	}
+
+	order := convertDBAssemblyOrderToModel(db.AssemblyOrder{
+		ID:          row.ID,
+		ItemID:      row.ItemID,
+		WarehouseID: row.WarehouseID,
+		Quantity:    row.Quantity,
+		Status:      row.Status,
+		Notes:       row.Notes,
+		CreatedBy:   row.CreatedBy,
+		CompletedAt: row.CompletedAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	})
+	order.SKU = row.Sku
+	order.Name = row.Name
+
+	return order, nil
+}
+
+func (s *Storage) ListAssemblyOrders(ctx context.Context, filter model.AssemblyOrderFilter, limit, offset int) ([]model.AssemblyOrder, error) {
+	params := db.ListAssemblyOrdersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.Status != "" {
+		params.Status = sql.NullString{String: string(filter.Status), Valid: true}
+	}
+	if filter.ItemID != "" {
+		itemID, err := uuid.Parse(filter.ItemID)
+		if err != nil {
+			return nil, errors.ErrBadRequest
+		}
+		params.ItemID = uuid.NullUUID{UUID: itemID, Valid: true}
+	}
+
+	rows, err := s.queries.ListAssemblyOrders(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	orders := make([]model.AssemblyOrder, 0, len(rows))
+	for _, row := range rows {
+		order := convertDBAssemblyOrderToModel(db.AssemblyOrder{
+			ID:          row.ID,
+			ItemID:      row.ItemID,
+			WarehouseID: row.WarehouseID,
+			Quantity:    row.Quantity,
+			Status:      row.Status,
+			Notes:       row.Notes,
+			CreatedBy:   row.CreatedBy,
+			CompletedAt: row.CompletedAt,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		order.SKU = row.Sku
+		order.Name = row.Name
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+func (s *Storage) ListAssemblyOrderLines(ctx context.Context, orderID string) ([]model.AssemblyOrderLine, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListAssemblyOrderLines(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lines := make([]model.AssemblyOrderLine, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, model.AssemblyOrderLine{
+			ID:       row.ID,
+			ItemID:   row.ItemID,
+			SKU:      row.Sku,
+			Name:     row.Name,
+			Quantity: int(row.Quantity),
+		})
+	}
+
+	return lines, nil
+}
+
+// CompleteAssemblyOrder builds the items of a draft assembly order in one transaction. The
+// components are issued from the warehouse, lots first expiry first, and the finished items are
+// received into its default location at the value of the components consumed. Both sides are
+// written as assembly movements referencing the order. Serialized items cannot be assembled, and
+// components reserved in the warehouse cannot be consumed.
+func (s *Storage) CompleteAssemblyOrder(ctx context.Context, orderID string, userID string) error {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	order, err := lockAssemblyOrder(ctx, qtx, orderUUID, model.AssemblyOrderStatusDraft)
+	if err != nil {
+		return err
+	}
+
+	lines, err := qtx.ListAssemblyOrderLines(ctx, orderUUID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	quantities := make(map[uuid.UUID]int, len(lines))
+	for _, line := range lines {
+		quantities[line.ItemID] += int(line.Quantity)
+	}
+	if err := checkUnreservedStock(ctx, qtx, order.WarehouseID, quantities); err != nil {
+		return err
+	}
+
+	source := model.StockMovementSource{
+		Reason:           model.StockMovementReasonAssembly,
+		SourceDocumentID: &orderUUID,
+		UserID:           userID,
+	}
+
+	value := 0.0
+	for _, line := range lines {
+		if _, err := checkSerialNumbers(ctx, qtx, line.ItemID, int(line.Quantity), nil); err != nil {
+			return err
+		}
+
+		lineValue, err := issueCost(ctx, qtx, line.ItemID, int(line.Quantity), source)
+		if err != nil {
+			return err
+		}
+		value += lineValue
+
+		if err := issueWarehouseStock(ctx, qtx, line.ItemID, order.WarehouseID, int(line.Quantity), source); err != nil {
+			return err
+		}
+	}
+
+	quantity := int(order.Quantity)
+	if _, err := checkSerialNumbers(ctx, qtx, order.ItemID, quantity, nil); err != nil {
+		return err
+	}
+
+	costs := []model.UnitCostQuantity{{Quantity: quantity, UnitCost: value / float64(quantity)}}
+	if err := receiveCost(ctx, qtx, order.ItemID, quantity, costs, source); err != nil {
+		return err
+	}
+
+	location, err := qtx.GetDefaultLocationByWarehouseID(ctx, order.WarehouseID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := receiveLocationStock(ctx, qtx, order.ItemID, location.ID, quantity, nil, source); err != nil {
+		return err
+	}
+
+	statusParams := db.UpdateAssemblyOrderStatusParams{
+		ID:          orderUUID,
+		Status:      string(model.AssemblyOrderStatusCompleted),
+		CompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt:   time.Now(),
+	}
+	if err := qtx.UpdateAssemblyOrderStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// CancelAssemblyOrder cancels a draft assembly order; no stock has moved yet
+func (s *Storage) CancelAssemblyOrder(ctx context.Context, orderID string) error {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := lockAssemblyOrder(ctx, qtx, orderUUID, model.AssemblyOrderStatusDraft); err != nil {
+		return err
+	}
+
+	statusParams := db.UpdateAssemblyOrderStatusParams{
+		ID:        orderUUID,
+		Status:    string(model.AssemblyOrderStatusCancelled),
+		UpdatedAt: time.Now(),
+	}
+	if err := qtx.UpdateAssemblyOrderStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// lockAssemblyOrder locks an assembly order for the rest of the transaction, making sure it is in
+// one of the given statuses
+func lockAssemblyOrder(ctx context.Context, qtx *db.Queries, orderID uuid.UUID, statuses ...model.AssemblyOrderStatus) (db.AssemblyOrder, error) {
+	order, err := qtx.GetAssemblyOrderForUpdate(ctx, orderID)
+	if err == sql.ErrNoRows {
+		return db.AssemblyOrder{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return db.AssemblyOrder{}, errors.ErrInternalServerError
+	}
+
+	for _, status := range statuses {
+		if order.Status == string(status) {
+			return order, nil
+		}
+	}
+
+	return db.AssemblyOrder{}, errors.ErrBadRequest
+}
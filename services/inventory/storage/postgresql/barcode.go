@@ -0,0 +1,99 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func convertDBItemBarcodeToModel(dbBarcode db.ItemBarcode) model.ItemBarcode {
+	return model.ItemBarcode{
+		ID:           dbBarcode.ID,
+		ItemID:       dbBarcode.ItemID,
+		Code:         dbBarcode.Code,
+		Type:         model.BarcodeType(dbBarcode.Type),
+		PackQuantity: int(dbBarcode.PackQuantity),
+		CreatedAt:    dbBarcode.CreatedAt,
+	}
+}
+
+func (s *Storage) CreateItemBarcode(ctx context.Context, barcode model.ItemBarcode) error {
+	params := db.CreateItemBarcodeParams{
+		ID:           barcode.ID,
+		ItemID:       barcode.ItemID,
+		Code:         barcode.Code,
+		Type:         barcode.Type.String(),
+		PackQuantity: int32(barcode.PackQuantity),
+		CreatedAt:    barcode.CreatedAt,
+	}
+
+	if err := s.queries.CreateItemBarcode(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				return errors.ErrConflict
+			case "23503":
+				return errors.ErrNotFound
+			case "23514":
+				return errors.ErrBadRequest
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetItemBarcodeByCode(ctx context.Context, code string) (model.ItemBarcode, error) {
+	dbBarcode, err := s.queries.GetItemBarcodeByCode(ctx, code)
+	if err == sql.ErrNoRows {
+		return model.ItemBarcode{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.ItemBarcode{}, errors.ErrInternalServerError
+	}
+
+	return convertDBItemBarcodeToModel(dbBarcode), nil
+}
+
+func (s *Storage) ListItemBarcodes(ctx context.Context, itemID string) ([]model.ItemBarcode, error) {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbBarcodes, err := s.queries.ListItemBarcodesByItemID(ctx, itemUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	barcodes := make([]model.ItemBarcode, 0, len(dbBarcodes))
+	for _, dbBarcode := range dbBarcodes {
+		barcodes = append(barcodes, convertDBItemBarcodeToModel(dbBarcode))
+	}
+
+	return barcodes, nil
+}
+
+func (s *Storage) DeleteItemBarcode(ctx context.Context, id uuid.UUID) error {
+	if err := s.queries.DeleteItemBarcode(ctx, id); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// NextInternalBarcodeNumber returns the next number for an internally generated barcode
+func (s *Storage) NextInternalBarcodeNumber(ctx context.Context) (int64, error) {
+	number, err := s.queries.NextInternalBarcodeNumber(ctx)
+	if err != nil {
+		return 0, errors.ErrInternalServerError
+	}
+
+	return number, nil
+}
@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (s *Storage) GetBillOfMaterials(ctx context.Context, itemID string) (model.BillOfMaterials, error) {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return model.BillOfMaterials{}, errors.ErrBadRequest
+	}
+
+	dbBOM, err := s.queries.GetBillOfMaterials(ctx, itemUUID)
+	if err == sql.ErrNoRows {
+		return model.BillOfMaterials{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.BillOfMaterials{}, errors.ErrInternalServerError
+	}
+
+	rows, err := s.queries.ListBOMComponents(ctx, itemUUID)
+	if err != nil {
+		return model.BillOfMaterials{}, errors.ErrInternalServerError
+	}
+
+	bom := model.BillOfMaterials{
+		ItemID:     dbBOM.ItemID,
+		Kit:        dbBOM.Kit,
+		Components: make([]model.BOMComponent, 0, len(rows)),
+		CreatedAt:  dbBOM.CreatedAt,
+		UpdatedAt:  dbBOM.UpdatedAt,
+	}
+	for _, row := range rows {
+		bom.Components = append(bom.Components, model.BOMComponent{
+			ItemID:   row.ComponentItemID,
+			SKU:      row.Sku,
+			Name:     row.Name,
+			Quantity: int(row.Quantity),
+		})
+	}
+
+	return bom, nil
+}
+
+// SaveBillOfMaterials creates or replaces the bill of materials of an item. A component may not
+// be the item itself or use the item, directly or through its own components. A kit is only
+// sold, so it cannot be a component and its components cannot be kits.
+func (s *Storage) SaveBillOfMaterials(ctx context.Context, bom model.BillOfMaterials) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	parentIDs, err := qtx.ListBOMParentItemIDs(ctx, bom.ItemID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if bom.Kit && len(parentIDs) > 0 {
+		return errors.ErrBadRequest
+	}
+
+	excluded := make(map[uuid.UUID]bool, len(parentIDs)+1)
+	excluded[bom.ItemID] = true
+	for _, parentID := range parentIDs {
+		excluded[parentID] = true
+	}
+
+	for _, component := range bom.Components {
+		if excluded[component.ItemID] {
+			return errors.ErrBadRequest
+		}
+
+		componentBOM, err := qtx.GetBillOfMaterials(ctx, component.ItemID)
+		if err != nil && err != sql.ErrNoRows {
+			return errors.ErrInternalServerError
+		}
+		if err == nil && componentBOM.Kit {
+			return errors.ErrBadRequest
+		}
+	}
+
+	bomParams := db.UpsertBillOfMaterialsParams{
+		ItemID:    bom.ItemID,
+		Kit:       bom.Kit,
+		CreatedAt: bom.CreatedAt,
+		UpdatedAt: bom.UpdatedAt,
+	}
+	if err := qtx.UpsertBillOfMaterials(ctx, bomParams); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return errors.ErrNotFound
+		}
+		return errors.ErrInternalServerError
+	}
+
+	if err := qtx.DeleteBOMComponentsByItemID(ctx, bom.ItemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	for _, component := range bom.Components {
+		componentParams := db.CreateBOMComponentParams{
+			ID:              uuid.New(),
+			ItemID:          bom.ItemID,
+			ComponentItemID: component.ItemID,
+			Quantity:        int32(component.Quantity),
+		}
+		if err := qtx.CreateBOMComponent(ctx, componentParams); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok {
+				switch pqErr.Code {
+				case "23503", "23505", "23514":
+					return errors.ErrBadRequest
+				}
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteBillOfMaterials(ctx context.Context, itemID string) error {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	_, err = s.queries.GetBillOfMaterials(ctx, itemUUID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := s.queries.DeleteBillOfMaterials(ctx, itemUUID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
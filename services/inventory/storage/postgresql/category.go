@@ -0,0 +1,387 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBCategoryToModel converts sqlc generated db.Category to model.Category
+func convertDBCategoryToModel(dbCategory db.Category) model.Category {
+	category := model.Category{
+		ID:        dbCategory.ID,
+		ParentID:  convertNullUUIDToPtr(dbCategory.ParentID),
+		Name:      dbCategory.Name,
+		CreatedAt: dbCategory.CreatedAt,
+		UpdatedAt: dbCategory.UpdatedAt,
+	}
+
+	if dbCategory.Description.Valid {
+		category.Description = dbCategory.Description.String
+	}
+
+	return category
+}
+
+// convertDBCategoryAttributeToModel converts sqlc generated db.CategoryAttribute to
+// model.CategoryAttribute
+func convertDBCategoryAttributeToModel(dbAttribute db.CategoryAttribute) model.CategoryAttribute {
+	return model.CategoryAttribute{
+		ID:         dbAttribute.ID,
+		CategoryID: dbAttribute.CategoryID,
+		Name:       dbAttribute.Name,
+		Type:       model.AttributeType(dbAttribute.Type),
+		Required:   dbAttribute.Required,
+		Options:    dbAttribute.Options,
+		CreatedAt:  dbAttribute.CreatedAt,
+		UpdatedAt:  dbAttribute.UpdatedAt,
+	}
+}
+
+// convertDescriptionToNullString converts an optional description to sql.NullString
+func convertDescriptionToNullString(description string) sql.NullString {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: description, Valid: true}
+}
+
+// CreateCategory stores a category together with its attributes
+func (s *Storage) CreateCategory(ctx context.Context, category model.Category, attributes []model.CategoryAttribute) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.CreateCategoryParams{
+		ID:          category.ID,
+		ParentID:    convertOptionalIDToNullUUID(category.ParentID),
+		Name:        strings.TrimSpace(category.Name),
+		Description: convertDescriptionToNullString(category.Description),
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+	if err := qtx.CreateCategory(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				return errors.ErrConflict
+			case "23503":
+				return errors.ErrBadRequest
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	for _, attribute := range attributes {
+		if err := createCategoryAttribute(ctx, qtx, attribute); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCategoryByID(ctx context.Context, id string) (model.Category, error) {
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return model.Category{}, errors.ErrBadRequest
+	}
+
+	dbCategory, err := s.queries.GetCategoryByID(ctx, categoryID)
+	if err == sql.ErrNoRows {
+		return model.Category{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.Category{}, errors.ErrInternalServerError
+	}
+
+	return convertDBCategoryToModel(dbCategory), nil
+}
+
+func (s *Storage) ListCategories(ctx context.Context, filter model.CategoryFilter, limit, offset int) ([]model.Category, error) {
+	params := db.ListCategoriesParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.ParentID != "" {
+		parentID, err := uuid.Parse(filter.ParentID)
+		if err != nil {
+			return nil, errors.ErrBadRequest
+		}
+		params.ParentID = uuid.NullUUID{UUID: parentID, Valid: true}
+	}
+
+	dbCategories, err := s.queries.ListCategories(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	categories := make([]model.Category, 0, len(dbCategories))
+	for _, dbCategory := range dbCategories {
+		categories = append(categories, convertDBCategoryToModel(dbCategory))
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory saves a category and replaces its attributes, matched by name. Attributes left
+// out are deleted with their values; an attribute whose type changes loses its values. A
+// category cannot be moved under itself or one of its descendants.
+func (s *Storage) UpdateCategory(ctx context.Context, category model.Category, attributes []model.CategoryAttribute) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if category.ParentID != nil {
+		descendantIDs, err := qtx.ListCategoryDescendantIDs(ctx, category.ID)
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+		for _, descendantID := range descendantIDs {
+			if descendantID == *category.ParentID {
+				return errors.ErrBadRequest
+			}
+		}
+	}
+
+	params := db.UpdateCategoryParams{
+		ID:          category.ID,
+		ParentID:    convertOptionalIDToNullUUID(category.ParentID),
+		Name:        strings.TrimSpace(category.Name),
+		Description: convertDescriptionToNullString(category.Description),
+		UpdatedAt:   category.UpdatedAt,
+	}
+	if err := qtx.UpdateCategory(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				return errors.ErrConflict
+			case "23503":
+				return errors.ErrBadRequest
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	existing, err := qtx.ListCategoryAttributesByCategoryID(ctx, category.ID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	existingByName := make(map[string]db.CategoryAttribute, len(existing))
+	for _, attribute := range existing {
+		existingByName[attribute.Name] = attribute
+	}
+
+	for _, attribute := range attributes {
+		current, ok := existingByName[attribute.Name]
+		if !ok {
+			if err := createCategoryAttribute(ctx, qtx, attribute); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(existingByName, attribute.Name)
+
+		if current.Type != attribute.Type.String() {
+			if err := qtx.DeleteItemAttributeValuesByAttributeID(ctx, current.ID); err != nil {
+				return errors.ErrInternalServerError
+			}
+		}
+
+		err := qtx.UpdateCategoryAttribute(ctx, db.UpdateCategoryAttributeParams{
+			ID:        current.ID,
+			Type:      attribute.Type.String(),
+			Required:  attribute.Required,
+			Options:   attributeOptions(attribute.Options),
+			UpdatedAt: attribute.UpdatedAt,
+		})
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	for _, attribute := range existingByName {
+		if err := qtx.DeleteCategoryAttribute(ctx, attribute.ID); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// DeleteCategory deletes a category with no subcategories and no items
+func (s *Storage) DeleteCategory(ctx context.Context, id string) error {
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	_, err = s.queries.GetCategoryByID(ctx, categoryID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := s.queries.DeleteCategory(ctx, categoryID); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ListCategoryAttributes lists the attributes of a category and its ancestors, root first
+func (s *Storage) ListCategoryAttributes(ctx context.Context, categoryID string) ([]model.CategoryAttribute, error) {
+	categoryUUID, err := uuid.Parse(categoryID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListInheritedCategoryAttributes(ctx, categoryUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	attributes := make([]model.CategoryAttribute, 0, len(rows))
+	for _, row := range rows {
+		attributes = append(attributes, convertDBCategoryAttributeToModel(row))
+	}
+
+	return attributes, nil
+}
+
+func createCategoryAttribute(ctx context.Context, qtx *db.Queries, attribute model.CategoryAttribute) error {
+	err := qtx.CreateCategoryAttribute(ctx, db.CreateCategoryAttributeParams{
+		ID:         attribute.ID,
+		CategoryID: attribute.CategoryID,
+		Name:       attribute.Name,
+		Type:       attribute.Type.String(),
+		Required:   attribute.Required,
+		Options:    attributeOptions(attribute.Options),
+		CreatedAt:  attribute.CreatedAt,
+		UpdatedAt:  attribute.UpdatedAt,
+	})
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// attributeOptions stores an attribute without options as an empty array rather than NULL
+func attributeOptions(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return options
+}
+
+// loadItemAttributes fills in the attribute values of the items given
+func loadItemAttributes(ctx context.Context, qtx *db.Queries, items []model.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	rows, err := qtx.ListItemAttributeValuesByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	attributes := make(map[uuid.UUID]map[string]interface{}, len(items))
+	for _, row := range rows {
+		var value interface{}
+		if err := json.Unmarshal(row.Value, &value); err != nil {
+			return errors.ErrInternalServerError
+		}
+		if attributes[row.ItemID] == nil {
+			attributes[row.ItemID] = make(map[string]interface{})
+		}
+		attributes[row.ItemID][row.Name] = value
+	}
+
+	for i := range items {
+		items[i].Attributes = attributes[items[i].ID]
+	}
+
+	return nil
+}
+
+// replaceItemAttributes replaces the attribute values of an item. Every value must be for an
+// attribute of the item's category or one of its ancestors.
+func replaceItemAttributes(ctx context.Context, qtx *db.Queries, item model.Item) error {
+	if err := qtx.DeleteItemAttributeValuesByItemID(ctx, item.ID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if len(item.Attributes) == 0 {
+		return nil
+	}
+	if item.CategoryID == nil {
+		return errors.ErrBadRequest
+	}
+
+	rows, err := qtx.ListInheritedCategoryAttributes(ctx, *item.CategoryID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	attributeIDs := make(map[string]uuid.UUID, len(rows))
+	for _, row := range rows {
+		attributeIDs[row.Name] = row.ID
+	}
+
+	for name, value := range item.Attributes {
+		attributeID, ok := attributeIDs[name]
+		if !ok {
+			return errors.ErrBadRequest
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return errors.ErrBadRequest
+		}
+
+		err = qtx.CreateItemAttributeValue(ctx, db.CreateItemAttributeValueParams{
+			ItemID:      item.ID,
+			AttributeID: attributeID,
+			Value:       encoded,
+		})
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListItemValuations values the stock of every item from the cost ledger entries made before the
+// given time
+func (s *Storage) ListItemValuations(ctx context.Context, before time.Time) ([]model.ItemValuation, error) {
+	rows, err := s.queries.ListItemValuations(ctx, before)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	valuations := make([]model.ItemValuation, 0, len(rows))
+	for _, row := range rows {
+		valuation := model.ItemValuation{
+			ItemID:        row.ID,
+			SKU:           row.Sku,
+			Name:          row.Name,
+			CostingMethod: model.CostingMethod(row.CostingMethod),
+			Quantity:      int(row.Quantity),
+		}
+		if value, err := strconv.ParseFloat(row.Value, 64); err == nil {
+			valuation.Value = value
+		}
+		if valuation.Quantity > 0 {
+			valuation.UnitCost = valuation.Value / float64(valuation.Quantity)
+		}
+		valuations = append(valuations, valuation)
+	}
+
+	return valuations, nil
+}
+
+// ListOrderCostOfGoodsSold lists the cost of the goods shipped on a sales order, by item
+func (s *Storage) ListOrderCostOfGoodsSold(ctx context.Context, orderID string) ([]model.ItemCostOfGoodsSold, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListOrderCostOfGoodsSold(ctx, uuid.NullUUID{UUID: orderUUID, Valid: true})
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	items := make([]model.ItemCostOfGoodsSold, 0, len(rows))
+	for _, row := range rows {
+		item := model.ItemCostOfGoodsSold{
+			ItemID:   row.ID,
+			SKU:      row.Sku,
+			Name:     row.Name,
+			Quantity: int(row.Quantity),
+		}
+		if cost, err := strconv.ParseFloat(row.Cost, 64); err == nil {
+			item.Cost = cost
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// itemCost is the quantity and value of an item's stock according to the cost ledger
+type itemCost struct {
+	quantity int
+	value    float64
+}
+
+// averageUnitCost is the moving average cost of the stock on hand, or zero when there is none
+func (c itemCost) averageUnitCost() float64 {
+	if c.quantity <= 0 {
+		return 0
+	}
+	return c.value / float64(c.quantity)
+}
+
+// lockItemCost locks an item's costing for the rest of the transaction, so concurrent stock
+// changes value the item one at a time, and returns its costing method and current cost
+func lockItemCost(ctx context.Context, qtx *db.Queries, itemID uuid.UUID) (model.CostingMethod, itemCost, error) {
+	costingMethod, err := qtx.GetItemCostingMethodForUpdate(ctx, itemID)
+	if err == sql.ErrNoRows {
+		return "", itemCost{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return "", itemCost{}, errors.ErrInternalServerError
+	}
+
+	sum, err := qtx.SumItemCost(ctx, itemID)
+	if err != nil {
+		return "", itemCost{}, errors.ErrInternalServerError
+	}
+
+	cost := itemCost{quantity: int(sum.Quantity)}
+	if value, err := strconv.ParseFloat(sum.Value, 64); err == nil {
+		cost.value = value
+	}
+
+	return model.CostingMethod(costingMethod), cost, nil
+}
+
+// receiveCost opens a cost layer for each quantity received at a known cost and records its value
+// in the cost ledger. Any quantity the costs do not cover, such as a manual increase, is valued at
+// the item's moving average cost.
+func receiveCost(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, quantity int, costs []model.UnitCostQuantity, source model.StockMovementSource) error {
+	_, cost, err := lockItemCost(ctx, qtx, itemID)
+	if err != nil {
+		return err
+	}
+
+	uncosted := quantity
+	for _, c := range costs {
+		if c.Quantity <= 0 {
+			continue
+		}
+		if err := openCostLayer(ctx, qtx, itemID, c.Quantity, c.UnitCost, source); err != nil {
+			return err
+		}
+		uncosted -= c.Quantity
+	}
+	if uncosted < 0 {
+		return errors.ErrBadRequest
+	}
+	if uncosted > 0 {
+		return openCostLayer(ctx, qtx, itemID, uncosted, cost.averageUnitCost(), source)
+	}
+
+	return nil
+}
+
+// issueCost values an issue under the item's costing method and records it in the cost ledger.
+// The cost layers are drawn down oldest first whatever the method; fifo items are valued at the
+// layers' costs and average items at the moving average cost. It returns the value issued.
+func issueCost(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, quantity int, source model.StockMovementSource) (float64, error) {
+	costingMethod, cost, err := lockItemCost(ctx, qtx, itemID)
+	if err != nil {
+		return 0, err
+	}
+
+	layers, err := qtx.ListOpenCostLayersForUpdate(ctx, itemID)
+	if err != nil {
+		return 0, errors.ErrInternalServerError
+	}
+
+	takes, layersValue, remaining := drawCostLayers(layers, quantity)
+	for i, take := range takes {
+		if take == 0 {
+			continue
+		}
+		consumeParams := db.ConsumeCostLayerParams{
+			ID:                layers[i].ID,
+			RemainingQuantity: int32(take),
+		}
+		if err := qtx.ConsumeCostLayer(ctx, consumeParams); err != nil {
+			return 0, errors.ErrInternalServerError
+		}
+	}
+
+	value := issueValue(costingMethod, cost, quantity, layersValue, remaining)
+
+	if err := createCostEntry(ctx, qtx, itemID, -quantity, -value, source); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// drawCostLayers draws a quantity from open cost layers, oldest first. It returns the quantity
+// taken from each layer, the value of what was taken at the layers' costs and the quantity the
+// layers could not cover.
+func drawCostLayers(layers []db.CostLayer, quantity int) ([]int, float64, int) {
+	takes := make([]int, len(layers))
+	remaining := quantity
+	value := 0.0
+	for i, layer := range layers {
+		if remaining == 0 {
+			break
+		}
+		take := min(remaining, int(layer.RemainingQuantity))
+		takes[i] = take
+		if unitCost, err := strconv.ParseFloat(layer.UnitCost, 64); err == nil {
+			value += float64(take) * unitCost
+		}
+		remaining -= take
+	}
+
+	return takes, value, remaining
+}
+
+// issueValue values an issue of quantity under the item's costing method, given the value drawn
+// from the cost layers and the quantity they did not cover
+func issueValue(costingMethod model.CostingMethod, cost itemCost, quantity int, layersValue float64, remaining int) float64 {
+	switch {
+	case quantity >= cost.quantity:
+		// Issuing everything on hand takes the whole value, leaving no rounding behind
+		return cost.value
+	case costingMethod == model.CostingMethodAverage:
+		return float64(quantity) * cost.averageUnitCost()
+	default:
+		// Stock not covered by a layer is valued at the average cost
+		return layersValue + float64(remaining)*cost.averageUnitCost()
+	}
+}
+
+// openCostLayer opens a cost layer for a quantity received and records its value in the cost ledger
+func openCostLayer(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, quantity int, unitCost float64, source model.StockMovementSource) error {
+	layerParams := db.CreateCostLayerParams{
+		ID:               uuid.New(),
+		ItemID:           itemID,
+		Quantity:         int32(quantity),
+		UnitCost:         strconv.FormatFloat(unitCost, 'f', 4, 64),
+		SourceDocumentID: convertOptionalIDToNullUUID(source.SourceDocumentID),
+		CreatedAt:        time.Now(),
+	}
+	if err := qtx.CreateCostLayer(ctx, layerParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return createCostEntry(ctx, qtx, itemID, quantity, float64(quantity)*unitCost, source)
+}
+
+func createCostEntry(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, quantityDelta int, valueDelta float64, source model.StockMovementSource) error {
+	entryParams := db.CreateCostEntryParams{
+		ID:               uuid.New(),
+		ItemID:           itemID,
+		QuantityDelta:    int32(quantityDelta),
+		ValueDelta:       strconv.FormatFloat(valueDelta, 'f', 4, 64),
+		Reason:           string(source.Reason),
+		SourceDocumentID: convertOptionalIDToNullUUID(source.SourceDocumentID),
+		CreatedAt:        time.Now(),
+	}
+	if err := qtx.CreateCostEntry(ctx, entryParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package postgresql
+
+import (
+	"math"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"slices"
+	"testing"
+)
+
+func TestAverageUnitCost(t *testing.T) {
+	tests := []struct {
+		name string
+		cost itemCost
+		want float64
+	}{
+		{name: "value spread over the quantity", cost: itemCost{quantity: 4, value: 10}, want: 2.5},
+		{name: "no stock", cost: itemCost{quantity: 0, value: 0}, want: 0},
+		{name: "value left without stock", cost: itemCost{quantity: 0, value: 0.01}, want: 0},
+		{name: "negative stock", cost: itemCost{quantity: -2, value: -5}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cost.averageUnitCost(); got != tt.want {
+				t.Errorf("averageUnitCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrawCostLayers(t *testing.T) {
+	layers := []db.CostLayer{
+		{RemainingQuantity: 5, UnitCost: "2.0000"},
+		{RemainingQuantity: 3, UnitCost: "3.5000"},
+		{RemainingQuantity: 10, UnitCost: "4.0000"},
+	}
+
+	tests := []struct {
+		name          string
+		layers        []db.CostLayer
+		quantity      int
+		wantTakes     []int
+		wantValue     float64
+		wantRemaining int
+	}{
+		{
+			name:          "within the oldest layer",
+			layers:        layers,
+			quantity:      4,
+			wantTakes:     []int{4, 0, 0},
+			wantValue:     8,
+			wantRemaining: 0,
+		},
+		{
+			name:          "oldest layer exactly",
+			layers:        layers,
+			quantity:      5,
+			wantTakes:     []int{5, 0, 0},
+			wantValue:     10,
+			wantRemaining: 0,
+		},
+		{
+			name:          "across layers oldest first",
+			layers:        layers,
+			quantity:      9,
+			wantTakes:     []int{5, 3, 1},
+			wantValue:     10 + 10.5 + 4,
+			wantRemaining: 0,
+		},
+		{
+			name:          "more than the layers hold",
+			layers:        layers,
+			quantity:      20,
+			wantTakes:     []int{5, 3, 10},
+			wantValue:     10 + 10.5 + 40,
+			wantRemaining: 2,
+		},
+		{
+			name:          "no open layers",
+			layers:        nil,
+			quantity:      3,
+			wantTakes:     []int{},
+			wantValue:     0,
+			wantRemaining: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			takes, value, remaining := drawCostLayers(tt.layers, tt.quantity)
+			if !slices.Equal(takes, tt.wantTakes) {
+				t.Errorf("takes = %v, want %v", takes, tt.wantTakes)
+			}
+			if math.Abs(value-tt.wantValue) > 1e-9 {
+				t.Errorf("value = %v, want %v", value, tt.wantValue)
+			}
+			if remaining != tt.wantRemaining {
+				t.Errorf("remaining = %d, want %d", remaining, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestIssueValue(t *testing.T) {
+	// 10 units worth 25: 4 at 2 from an older layer, 6 at 2.8333... from a newer one
+	cost := itemCost{quantity: 10, value: 25}
+
+	tests := []struct {
+		name          string
+		costingMethod model.CostingMethod
+		cost          itemCost
+		quantity      int
+		layersValue   float64
+		remaining     int
+		want          float64
+	}{
+		{
+			name:          "fifo takes the layers' value",
+			costingMethod: model.CostingMethodFIFO,
+			cost:          cost,
+			quantity:      4,
+			layersValue:   8,
+			want:          8,
+		},
+		{
+			name:          "fifo values uncovered stock at the average cost",
+			costingMethod: model.CostingMethodFIFO,
+			cost:          cost,
+			quantity:      6,
+			layersValue:   8,
+			remaining:     2,
+			want:          8 + 2*2.5,
+		},
+		{
+			name:          "average ignores the layers' value",
+			costingMethod: model.CostingMethodAverage,
+			cost:          cost,
+			quantity:      4,
+			layersValue:   8,
+			want:          10,
+		},
+		{
+			name:          "issuing everything on hand takes the whole value",
+			costingMethod: model.CostingMethodAverage,
+			cost:          itemCost{quantity: 3, value: 10},
+			quantity:      3,
+			layersValue:   9.99,
+			want:          10,
+		},
+		{
+			name:          "issuing more than on hand takes the whole value",
+			costingMethod: model.CostingMethodFIFO,
+			cost:          itemCost{quantity: 3, value: 10},
+			quantity:      5,
+			layersValue:   10,
+			remaining:     2,
+			want:          10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := issueValue(tt.costingMethod, tt.cost, tt.quantity, tt.layersValue, tt.remaining)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("issueValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// convertNullInt32ToPtr converts a nullable database integer to an optional int
+func convertNullInt32ToPtr(n sql.NullInt32) *int {
+	if !n.Valid {
+		return nil
+	}
+	value := int(n.Int32)
+	return &value
+}
+
+// convertDBStockCountToModel converts sqlc generated db.StockCount to model.StockCount
+func convertDBStockCountToModel(dbCount db.StockCount) model.StockCount {
+	count := model.StockCount{
+		ID:          dbCount.ID,
+		WarehouseID: dbCount.WarehouseID,
+		Status:      model.StockCountStatus(dbCount.Status),
+		SnapshotAt:  dbCount.SnapshotAt,
+		PostedAt:    convertNullTimeToPtr(dbCount.PostedAt),
+		CreatedAt:   dbCount.CreatedAt,
+		UpdatedAt:   dbCount.UpdatedAt,
+	}
+
+	if dbCount.Notes.Valid {
+		count.Notes = dbCount.Notes.String
+	}
+	if dbCount.CreatedBy.Valid {
+		count.CreatedBy = dbCount.CreatedBy.String
+	}
+	if dbCount.PostedBy.Valid {
+		count.PostedBy = dbCount.PostedBy.String
+	}
+
+	return count
+}
+
+// convertDBStockCountLineToModel converts a count line, working out its variance once counted
+func convertDBStockCountLineToModel(row db.ListStockCountLinesRow) model.StockCountLine {
+	line := model.StockCountLine{
+		ID:                 row.ID,
+		ItemID:             row.ItemID,
+		SKU:                row.Sku,
+		Name:               row.Name,
+		LocationID:         row.LocationID,
+		LocationCode:       row.LocationCode,
+		ExpectedQuantity:   int(row.ExpectedQuantity),
+		ExpectedAtCount:    int(row.ExpectedAtCount),
+		CountedQuantity:    convertNullInt32ToPtr(row.CountedQuantity),
+		CountedAt:          convertNullTimeToPtr(row.CountedAt),
+		AdjustmentQuantity: convertNullInt32ToPtr(row.AdjustmentQuantity),
+	}
+
+	if row.CountedBy.Valid {
+		line.CountedBy = row.CountedBy.String
+	}
+	if line.CountedQuantity != nil {
+		variance := *line.CountedQuantity - line.ExpectedAtCount
+		line.Variance = &variance
+	}
+
+	return line
+}
+
+// CreateStockCount opens a count session and snapshots the expected quantities: the stock of the
+// counted items at every location of the warehouse, and zero at the default location for counted
+// items the warehouse does not stock. Serialized items are left out.
+func (s *Storage) CreateStockCount(ctx context.Context, count model.StockCount, itemIDs []uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	location, err := qtx.GetDefaultLocationByWarehouseID(ctx, count.WarehouseID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	params := db.CreateStockCountParams{
+		ID:          count.ID,
+		WarehouseID: count.WarehouseID,
+		Status:      string(count.Status),
+		SnapshotAt:  count.SnapshotAt,
+		CreatedAt:   count.CreatedAt,
+		UpdatedAt:   count.UpdatedAt,
+	}
+	if count.Notes != "" {
+		params.Notes = sql.NullString{String: count.Notes, Valid: true}
+	}
+	if count.CreatedBy != "" {
+		params.CreatedBy = sql.NullString{String: count.CreatedBy, Valid: true}
+	}
+	if err := qtx.CreateStockCount(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	snapshotParams := db.SnapshotStockCountLinesParams{
+		CountID:     count.ID,
+		WarehouseID: count.WarehouseID,
+		ItemIds:     itemIDs,
+	}
+	if err := qtx.SnapshotStockCountLines(ctx, snapshotParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	unstockedParams := db.SnapshotUnstockedCountLinesParams{
+		CountID:    count.ID,
+		LocationID: location.ID,
+		ItemIds:    itemIDs,
+	}
+	if err := qtx.SnapshotUnstockedCountLines(ctx, unstockedParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	// The snapshot holds the stock rows until commit, so movements booked from here on come after it
+	snapshotAtParams := db.SetStockCountSnapshotAtParams{
+		ID:         count.ID,
+		SnapshotAt: time.Now(),
+	}
+	if err := qtx.SetStockCountSnapshotAt(ctx, snapshotAtParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetStockCountByID(ctx context.Context, id string) (model.StockCount, error) {
+	countID, err := uuid.Parse(id)
+	if err != nil {
+		return model.StockCount{}, errors.ErrBadRequest
+	}
+
+	row, err := s.queries.GetStockCountByID(ctx, countID)
+	if err == sql.ErrNoRows {
+		return model.StockCount{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.StockCount{}, errors.ErrInternalServerError
+	}
+
+	count := convertDBStockCountToModel(db.StockCount{
+		ID:          row.ID,
+		WarehouseID: row.WarehouseID,
+		Status:      row.Status,
+		Notes:       row.Notes,
+		SnapshotAt:  row.SnapshotAt,
+		CreatedBy:   row.CreatedBy,
+		PostedBy:    row.PostedBy,
+		PostedAt:    row.PostedAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	})
+	count.WarehouseCode = row.WarehouseCode
+
+	return count, nil
+}
+
+func (s *Storage) ListStockCounts(ctx context.Context, filter model.StockCountFilter, limit, offset int) ([]model.StockCount, error) {
+	params := db.ListStockCountsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.Status != "" {
+		params.Status = sql.NullString{String: string(filter.Status), Valid: true}
+	}
+
+	rows, err := s.queries.ListStockCounts(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	counts := make([]model.StockCount, 0, len(rows))
+	for _, row := range rows {
+		count := convertDBStockCountToModel(db.StockCount{
+			ID:          row.ID,
+			WarehouseID: row.WarehouseID,
+			Status:      row.Status,
+			Notes:       row.Notes,
+			SnapshotAt:  row.SnapshotAt,
+			CreatedBy:   row.CreatedBy,
+			PostedBy:    row.PostedBy,
+			PostedAt:    row.PostedAt,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		count.WarehouseCode = row.WarehouseCode
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+func (s *Storage) ListStockCountLines(ctx context.Context, countID string) ([]model.StockCountLine, error) {
+	countUUID, err := uuid.Parse(countID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListStockCountLines(ctx, countUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lines := make([]model.StockCountLine, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, convertDBStockCountLineToModel(row))
+	}
+
+	return lines, nil
+}
+
+// RecordStockCounts records counted quantities against an open session. A recount of the same
+// item and location, as when correcting a miscount, replaces the earlier count and its
+// counted_at, so the variance is worked out against the time of the recount. Only items included
+// in the session can be counted, at any location of its warehouse.
+func (s *Storage) RecordStockCounts(ctx context.Context, countID string, entries []model.StockCountEntry, countedBy string) error {
+	countUUID, err := uuid.Parse(countID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := lockOpenStockCount(ctx, qtx, countUUID); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		includesParams := db.StockCountIncludesItemParams{
+			CountID: countUUID,
+			ItemID:  entry.ItemID,
+		}
+		included, err := qtx.StockCountIncludesItem(ctx, includesParams)
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+		if !included {
+			return errors.ErrBadRequest
+		}
+
+		lineParams := db.RecordStockCountLineParams{
+			ID:              uuid.New(),
+			CountID:         countUUID,
+			ItemID:          entry.ItemID,
+			LocationID:      entry.LocationID,
+			CountedQuantity: sql.NullInt32{Int32: int32(entry.Quantity), Valid: true},
+			CountedAt:       sql.NullTime{Time: time.Now(), Valid: true},
+		}
+		if countedBy != "" {
+			lineParams.CountedBy = sql.NullString{String: countedBy, Valid: true}
+		}
+		if err := qtx.RecordStockCountLine(ctx, lineParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// PostStockCount books the variance of every counted line as a count adjustment and closes the
+// session, all in one transaction. Lines left uncounted are not adjusted. A count that would
+// leave an item with less stock than is reserved in the warehouse is refused with ErrConflict.
+func (s *Storage) PostStockCount(ctx context.Context, countID string, postedBy string) error {
+	countUUID, err := uuid.Parse(countID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	count, err := lockOpenStockCount(ctx, qtx, countUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := qtx.ListStockCountLines(ctx, countUUID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	// An item counted short may not lose stock sales orders hold reserved; the variance across
+	// its locations is what leaves the warehouse
+	issues := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		if row.CountedQuantity.Valid {
+			issues[row.ItemID] += int(row.ExpectedAtCount) - int(row.CountedQuantity.Int32)
+		}
+	}
+	if err := checkUnreservedStock(ctx, qtx, count.WarehouseID, issues); err != nil {
+		return err
+	}
+
+	source := model.StockMovementSource{
+		Reason:           model.StockMovementReasonCount,
+		SourceDocumentID: &countUUID,
+		UserID:           postedBy,
+	}
+
+	for _, row := range rows {
+		if !row.CountedQuantity.Valid {
+			continue
+		}
+
+		delta := int(row.CountedQuantity.Int32) - int(row.ExpectedAtCount)
+		switch {
+		case delta > 0:
+			if err := receiveCost(ctx, qtx, row.ItemID, delta, nil, source); err != nil {
+				return err
+			}
+			err = receiveLocationStock(ctx, qtx, row.ItemID, row.LocationID, delta, nil, source)
+		case delta < 0:
+			if _, err := issueCost(ctx, qtx, row.ItemID, -delta, source); err != nil {
+				return err
+			}
+			err = issueLocationStock(ctx, qtx, row.ItemID, row.LocationID, -delta, source)
+		}
+		if err != nil {
+			return err
+		}
+
+		adjustmentParams := db.SetStockCountLineAdjustmentParams{
+			ID:                 row.ID,
+			AdjustmentQuantity: sql.NullInt32{Int32: int32(delta), Valid: true},
+		}
+		if err := qtx.SetStockCountLineAdjustment(ctx, adjustmentParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	statusParams := db.UpdateStockCountStatusParams{
+		ID:        countUUID,
+		Status:    string(model.StockCountStatusPosted),
+		PostedAt:  sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt: time.Now(),
+	}
+	if postedBy != "" {
+		statusParams.PostedBy = sql.NullString{String: postedBy, Valid: true}
+	}
+	if err := qtx.UpdateStockCountStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// CancelStockCount closes an open session without adjusting stock
+func (s *Storage) CancelStockCount(ctx context.Context, countID string) error {
+	countUUID, err := uuid.Parse(countID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := lockOpenStockCount(ctx, qtx, countUUID); err != nil {
+		return err
+	}
+
+	statusParams := db.UpdateStockCountStatusParams{
+		ID:        countUUID,
+		Status:    string(model.StockCountStatusCancelled),
+		UpdatedAt: time.Now(),
+	}
+	if err := qtx.UpdateStockCountStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// lockOpenStockCount locks a session for the rest of the transaction; sessions that are no longer
+// open cannot be changed
+func lockOpenStockCount(ctx context.Context, qtx *db.Queries, countID uuid.UUID) (db.StockCount, error) {
+	count, err := qtx.GetStockCountForUpdate(ctx, countID)
+	if err == sql.ErrNoRows {
+		return db.StockCount{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return db.StockCount{}, errors.ErrInternalServerError
+	}
+
+	if count.Status != string(model.StockCountStatusOpen) {
+		return db.StockCount{}, errors.ErrBadRequest
+	}
+
+	return count, nil
+}
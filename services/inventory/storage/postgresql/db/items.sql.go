@@ -8,24 +8,77 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const archiveItem = `-- name: ArchiveItem :execrows
+UPDATE items
+SET archived_at = $2,
+    updated_at = $2
+WHERE id = $1 AND archived_at IS NULL
+`
+
+type ArchiveItemParams struct {
+	ID         uuid.UUID    `json:"id"`
+	ArchivedAt sql.NullTime `json:"archived_at"`
+}
+
+func (q *Queries) ArchiveItem(ctx context.Context, arg ArchiveItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, archiveItem, arg.ID, arg.ArchivedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const clearItemStockAlert = `-- name: ClearItemStockAlert :exec
+UPDATE items
+SET stock_alert = NULL
+WHERE id = $1 AND stock_alert IS NOT NULL
+`
+
+func (q *Queries) ClearItemStockAlert(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearItemStockAlert, id)
+	return err
+}
+
+const clearReorderRequested = `-- name: ClearReorderRequested :exec
+UPDATE items
+SET reorder_requested_at = NULL
+WHERE id = $1
+`
+
+func (q *Queries) ClearReorderRequested(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearReorderRequested, id)
+	return err
+}
+
 const createItem = `-- name: CreateItem :exec
-INSERT INTO items (id, name, description, sku, unit_price, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO items (id, name, description, sku, unit_price, reorder_point, reorder_quantity, preferred_vendor_id, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 `
 
 type CreateItemParams struct {
-	ID          uuid.UUID      `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Sku         string         `json:"sku"`
-	UnitPrice   string         `json:"unit_price"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID                uuid.UUID       `json:"id"`
+	Name              string          `json:"name"`
+	Description       sql.NullString  `json:"description"`
+	Sku               string          `json:"sku"`
+	UnitPrice         string          `json:"unit_price"`
+	ReorderPoint      int32           `json:"reorder_point"`
+	ReorderQuantity   int32           `json:"reorder_quantity"`
+	PreferredVendorID uuid.NullUUID   `json:"preferred_vendor_id"`
+	Serialized        bool            `json:"serialized"`
+	CostingMethod     string          `json:"costing_method"`
+	BaseUnit          string          `json:"base_unit"`
+	CategoryID        uuid.NullUUID   `json:"category_id"`
+	ProductID         uuid.NullUUID   `json:"product_id"`
+	VariantOptions    json.RawMessage `json:"variant_options"`
+	MinStock          int32           `json:"min_stock"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
 func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) error {
@@ -35,24 +88,24 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) error {
 		arg.Description,
 		arg.Sku,
 		arg.UnitPrice,
+		arg.ReorderPoint,
+		arg.ReorderQuantity,
+		arg.PreferredVendorID,
+		arg.Serialized,
+		arg.CostingMethod,
+		arg.BaseUnit,
+		arg.CategoryID,
+		arg.ProductID,
+		arg.VariantOptions,
+		arg.MinStock,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
 	return err
 }
 
-const deleteItem = `-- name: DeleteItem :exec
-DELETE FROM items
-WHERE id = $1
-`
-
-func (q *Queries) DeleteItem(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteItem, id)
-	return err
-}
-
 const getItemByID = `-- name: GetItemByID :one
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
 WHERE id = $1
 `
@@ -68,12 +121,25 @@ func (q *Queries) GetItemByID(ctx context.Context, id uuid.UUID) (Item, error) {
 		&i.UnitPrice,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.PreferredVendorID,
+		&i.ReorderRequestedAt,
+		&i.Serialized,
+		&i.CostingMethod,
+		&i.BaseUnit,
+		&i.CategoryID,
+		&i.ProductID,
+		&i.VariantOptions,
+		&i.MinStock,
+		&i.StockAlert,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
 const getItemBySKU = `-- name: GetItemBySKU :one
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
 WHERE sku = $1
 `
@@ -89,24 +155,47 @@ func (q *Queries) GetItemBySKU(ctx context.Context, sku string) (Item, error) {
 		&i.UnitPrice,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.PreferredVendorID,
+		&i.ReorderRequestedAt,
+		&i.Serialized,
+		&i.CostingMethod,
+		&i.BaseUnit,
+		&i.CategoryID,
+		&i.ProductID,
+		&i.VariantOptions,
+		&i.MinStock,
+		&i.StockAlert,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
 const listItems = `-- name: ListItems :many
-SELECT id, name, description, sku, unit_price, created_at, updated_at
+SELECT id, name, description, sku, unit_price, created_at, updated_at, reorder_point, reorder_quantity, preferred_vendor_id, reorder_requested_at, serialized, costing_method, base_unit, category_id, product_id, variant_options, min_stock, stock_alert, archived_at
 FROM items
+WHERE archived_at IS NULL
+  AND ($1::uuid IS NULL OR category_id IN (
+    WITH RECURSIVE tree AS (
+        SELECT categories.id FROM categories WHERE categories.id = $1::uuid
+        UNION ALL
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+    )
+    SELECT id FROM tree
+  ))
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+LIMIT $2 OFFSET $3
 `
 
 type ListItemsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	CategoryID uuid.NullUUID `json:"category_id"`
+	Limit      int32         `json:"limit"`
+	Offset     int32         `json:"offset"`
 }
 
 func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Item, error) {
-	rows, err := q.db.QueryContext(ctx, listItems, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listItems, arg.CategoryID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +211,309 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Item, e
 			&i.UnitPrice,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.PreferredVendorID,
+			&i.ReorderRequestedAt,
+			&i.Serialized,
+			&i.CostingMethod,
+			&i.BaseUnit,
+			&i.CategoryID,
+			&i.ProductID,
+			&i.VariantOptions,
+			&i.MinStock,
+			&i.StockAlert,
+			&i.ArchivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsForExport = `-- name: ListItemsForExport :many
+SELECT i.id, i.sku, i.name, i.description, i.unit_price, i.reorder_point, i.reorder_quantity, i.min_stock,
+       i.costing_method, i.base_unit, i.serialized, i.category_id,
+       COALESCE((SELECT SUM(s.quantity) FROM stock s WHERE s.item_id = i.id), 0)::integer AS quantity
+FROM items i
+WHERE i.sku > $1
+  AND i.archived_at IS NULL
+ORDER BY i.sku ASC
+LIMIT $2
+`
+
+type ListItemsForExportParams struct {
+	Sku   string `json:"sku"`
+	Limit int32  `json:"limit"`
+}
+
+type ListItemsForExportRow struct {
+	ID              uuid.UUID      `json:"id"`
+	Sku             string         `json:"sku"`
+	Name            string         `json:"name"`
+	Description     sql.NullString `json:"description"`
+	UnitPrice       string         `json:"unit_price"`
+	ReorderPoint    int32          `json:"reorder_point"`
+	ReorderQuantity int32          `json:"reorder_quantity"`
+	MinStock        int32          `json:"min_stock"`
+	CostingMethod   string         `json:"costing_method"`
+	BaseUnit        string         `json:"base_unit"`
+	Serialized      bool           `json:"serialized"`
+	CategoryID      uuid.NullUUID  `json:"category_id"`
+	Quantity        int32          `json:"quantity"`
+}
+
+func (q *Queries) ListItemsForExport(ctx context.Context, arg ListItemsForExportParams) ([]ListItemsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsForExport, arg.Sku, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListItemsForExportRow{}
+	for rows.Next() {
+		var i ListItemsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.UnitPrice,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.MinStock,
+			&i.CostingMethod,
+			&i.BaseUnit,
+			&i.Serialized,
+			&i.CategoryID,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsBelowReorderPoint = `-- name: ListItemsBelowReorderPoint :many
+SELECT i.id, i.name, i.sku, i.reorder_point, i.reorder_quantity, i.preferred_vendor_id, i.reorder_requested_at,
+       COALESCE(SUM(s.quantity), 0)::integer AS quantity
+FROM items i
+LEFT JOIN stock s ON s.item_id = i.id
+WHERE i.reorder_point > 0
+  AND i.archived_at IS NULL
+GROUP BY i.id
+HAVING COALESCE(SUM(s.quantity), 0) <= i.reorder_point
+ORDER BY i.sku ASC
+`
+
+type ListItemsBelowReorderPointRow struct {
+	ID                 uuid.UUID     `json:"id"`
+	Name               string        `json:"name"`
+	Sku                string        `json:"sku"`
+	ReorderPoint       int32         `json:"reorder_point"`
+	ReorderQuantity    int32         `json:"reorder_quantity"`
+	PreferredVendorID  uuid.NullUUID `json:"preferred_vendor_id"`
+	ReorderRequestedAt sql.NullTime  `json:"reorder_requested_at"`
+	Quantity           int32         `json:"quantity"`
+}
+
+func (q *Queries) ListItemsBelowReorderPoint(ctx context.Context) ([]ListItemsBelowReorderPointRow, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsBelowReorderPoint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListItemsBelowReorderPointRow{}
+	for rows.Next() {
+		var i ListItemsBelowReorderPointRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Sku,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.PreferredVendorID,
+			&i.ReorderRequestedAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockItem = `-- name: LockItem :one
+SELECT id FROM items WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) LockItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockItem, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markReorderRequested = `-- name: MarkReorderRequested :exec
+UPDATE items
+SET reorder_requested_at = $2
+WHERE id = $1
+`
+
+type MarkReorderRequestedParams struct {
+	ID                 uuid.UUID    `json:"id"`
+	ReorderRequestedAt sql.NullTime `json:"reorder_requested_at"`
+}
+
+func (q *Queries) MarkReorderRequested(ctx context.Context, arg MarkReorderRequestedParams) error {
+	_, err := q.db.ExecContext(ctx, markReorderRequested, arg.ID, arg.ReorderRequestedAt)
+	return err
+}
+
+const raiseItemStockAlert = `-- name: RaiseItemStockAlert :one
+UPDATE items
+SET stock_alert = $2
+WHERE id = $1
+  AND (stock_alert IS NULL OR (stock_alert = 'low' AND $2 = 'depleted'))
+RETURNING id
+`
+
+type RaiseItemStockAlertParams struct {
+	ID         uuid.UUID      `json:"id"`
+	StockAlert sql.NullString `json:"stock_alert"`
+}
+
+func (q *Queries) RaiseItemStockAlert(ctx context.Context, arg RaiseItemStockAlertParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, raiseItemStockAlert, arg.ID, arg.StockAlert)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const resetRecoveredReorderRequests = `-- name: ResetRecoveredReorderRequests :exec
+UPDATE items
+SET reorder_requested_at = NULL
+WHERE reorder_requested_at IS NOT NULL
+  AND (SELECT COALESCE(SUM(stock.quantity), 0) FROM stock WHERE stock.item_id = items.id) > reorder_point
+`
+
+func (q *Queries) ResetRecoveredReorderRequests(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetRecoveredReorderRequests)
+	return err
+}
+
+const searchItems = `-- name: SearchItems :many
+SELECT i.id, i.sku, i.name, i.description, i.unit_price, i.category_id,
+       COALESCE(s.quantity, 0)::integer AS quantity,
+       (ts_rank(
+           setweight(to_tsvector('english', i.sku), 'A') ||
+           setweight(to_tsvector('english', i.name), 'A') ||
+           setweight(to_tsvector('english', COALESCE(i.description, '')), 'B'),
+           websearch_to_tsquery('english', $1)
+       ) + similarity(i.sku, $1))::real AS rank
+FROM items i
+LEFT JOIN (
+    SELECT stock.item_id, SUM(stock.quantity) AS quantity
+    FROM stock
+    GROUP BY stock.item_id
+) s ON s.item_id = i.id
+LEFT JOIN (
+    SELECT stock_reservations.item_id, SUM(stock_reservations.quantity) AS quantity
+    FROM stock_reservations
+    WHERE stock_reservations.expires_at IS NULL OR stock_reservations.expires_at > $2::timestamp
+    GROUP BY stock_reservations.item_id
+) r ON r.item_id = i.id
+WHERE i.archived_at IS NULL
+  AND (
+        setweight(to_tsvector('english', i.sku), 'A') ||
+        setweight(to_tsvector('english', i.name), 'A') ||
+        setweight(to_tsvector('english', COALESCE(i.description, '')), 'B')
+        @@ websearch_to_tsquery('english', $1)
+     OR i.sku % $1
+     OR i.sku ILIKE '%' || replace(replace(replace($1, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+  )
+  AND ($3::numeric IS NULL OR i.unit_price >= $3::numeric)
+  AND ($4::numeric IS NULL OR i.unit_price <= $4::numeric)
+  AND ($5::boolean IS NULL OR (COALESCE(s.quantity, 0) - COALESCE(r.quantity, 0) > 0) = $5::boolean)
+  AND ($6::uuid IS NULL OR i.category_id IN (
+    WITH RECURSIVE tree AS (
+        SELECT categories.id FROM categories WHERE categories.id = $6::uuid
+        UNION ALL
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+    )
+    SELECT id FROM tree
+  ))
+ORDER BY rank DESC, i.sku ASC
+LIMIT $7 OFFSET $8
+`
+
+type SearchItemsParams struct {
+	Query      string         `json:"query"`
+	AsOf       time.Time      `json:"as_of"`
+	MinPrice   sql.NullString `json:"min_price"`
+	MaxPrice   sql.NullString `json:"max_price"`
+	InStock    sql.NullBool   `json:"in_stock"`
+	CategoryID uuid.NullUUID  `json:"category_id"`
+	Limit      int32          `json:"limit"`
+	Offset     int32          `json:"offset"`
+}
+
+type SearchItemsRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Sku         string         `json:"sku"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	UnitPrice   string         `json:"unit_price"`
+	CategoryID  uuid.NullUUID  `json:"category_id"`
+	Quantity    int32          `json:"quantity"`
+	Rank        float32        `json:"rank"`
+}
+
+func (q *Queries) SearchItems(ctx context.Context, arg SearchItemsParams) ([]SearchItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchItems,
+		arg.Query,
+		arg.AsOf,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.InStock,
+		arg.CategoryID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchItemsRow{}
+	for rows.Next() {
+		var i SearchItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.UnitPrice,
+			&i.CategoryID,
+			&i.Quantity,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -142,17 +534,33 @@ SET name = $2,
     description = $3,
     sku = $4,
     unit_price = $5,
-    updated_at = $6
+    reorder_point = $6,
+    reorder_quantity = $7,
+    preferred_vendor_id = $8,
+    serialized = $9,
+    costing_method = $10,
+    base_unit = $11,
+    category_id = $12,
+    min_stock = $13,
+    updated_at = $14
 WHERE id = $1
 `
 
 type UpdateItemParams struct {
-	ID          uuid.UUID      `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Sku         string         `json:"sku"`
-	UnitPrice   string         `json:"unit_price"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID                uuid.UUID      `json:"id"`
+	Name              string         `json:"name"`
+	Description       sql.NullString `json:"description"`
+	Sku               string         `json:"sku"`
+	UnitPrice         string         `json:"unit_price"`
+	ReorderPoint      int32          `json:"reorder_point"`
+	ReorderQuantity   int32          `json:"reorder_quantity"`
+	PreferredVendorID uuid.NullUUID  `json:"preferred_vendor_id"`
+	Serialized        bool           `json:"serialized"`
+	CostingMethod     string         `json:"costing_method"`
+	BaseUnit          string         `json:"base_unit"`
+	CategoryID        uuid.NullUUID  `json:"category_id"`
+	MinStock          int32          `json:"min_stock"`
+	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
 func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) error {
@@ -162,6 +570,14 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) error {
 		arg.Description,
 		arg.Sku,
 		arg.UnitPrice,
+		arg.ReorderPoint,
+		arg.ReorderQuantity,
+		arg.PreferredVendorID,
+		arg.Serialized,
+		arg.CostingMethod,
+		arg.BaseUnit,
+		arg.CategoryID,
+		arg.MinStock,
 		arg.UpdatedAt,
 	)
 	return err
@@ -6,25 +6,294 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-type Item struct {
+type AssemblyOrderLine struct {
+	ID              uuid.UUID `json:"id"`
+	AssemblyOrderID uuid.UUID `json:"assembly_order_id"`
+	ItemID          uuid.UUID `json:"item_id"`
+	Quantity        int32     `json:"quantity"`
+}
+
+type AssemblyOrder struct {
+	ID          uuid.UUID      `json:"id"`
+	ItemID      uuid.UUID      `json:"item_id"`
+	WarehouseID uuid.UUID      `json:"warehouse_id"`
+	Quantity    int32          `json:"quantity"`
+	Status      string         `json:"status"`
+	Notes       sql.NullString `json:"notes"`
+	CreatedBy   sql.NullString `json:"created_by"`
+	CompletedAt sql.NullTime   `json:"completed_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type BillsOfMaterial struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	Kit       bool      `json:"kit"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BomComponent struct {
+	ID              uuid.UUID `json:"id"`
+	ItemID          uuid.UUID `json:"item_id"`
+	ComponentItemID uuid.UUID `json:"component_item_id"`
+	Quantity        int32     `json:"quantity"`
+}
+
+type Category struct {
 	ID          uuid.UUID      `json:"id"`
+	ParentID    uuid.NullUUID  `json:"parent_id"`
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type CategoryAttribute struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Required   bool      `json:"required"`
+	Options    []string  `json:"options"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CostEntry struct {
+	ID               uuid.UUID     `json:"id"`
+	ItemID           uuid.UUID     `json:"item_id"`
+	QuantityDelta    int32         `json:"quantity_delta"`
+	ValueDelta       string        `json:"value_delta"`
+	Reason           string        `json:"reason"`
+	SourceDocumentID uuid.NullUUID `json:"source_document_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type CostLayer struct {
+	ID                uuid.UUID     `json:"id"`
+	ItemID            uuid.UUID     `json:"item_id"`
+	Quantity          int32         `json:"quantity"`
+	RemainingQuantity int32         `json:"remaining_quantity"`
+	UnitCost          string        `json:"unit_cost"`
+	SourceDocumentID  uuid.NullUUID `json:"source_document_id"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+type ItemAttributeValue struct {
+	ItemID      uuid.UUID       `json:"item_id"`
+	AttributeID uuid.UUID       `json:"attribute_id"`
+	Value       json.RawMessage `json:"value"`
+}
+
+type ItemBarcode struct {
+	ID           uuid.UUID `json:"id"`
+	ItemID       uuid.UUID `json:"item_id"`
+	Code         string    `json:"code"`
+	Type         string    `json:"type"`
+	PackQuantity int32     `json:"pack_quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ItemPrice struct {
+	ID            uuid.UUID `json:"id"`
+	ItemID        uuid.UUID `json:"item_id"`
+	UnitPrice     string    `json:"unit_price"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ItemUnit struct {
+	ID               uuid.UUID `json:"id"`
+	ItemID           uuid.UUID `json:"item_id"`
+	Unit             string    `json:"unit"`
+	ConversionFactor int32     `json:"conversion_factor"`
+}
+
+type Item struct {
+	ID                 uuid.UUID       `json:"id"`
+	Name               string          `json:"name"`
+	Description        sql.NullString  `json:"description"`
+	Sku                string          `json:"sku"`
+	UnitPrice          string          `json:"unit_price"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	ReorderPoint       int32           `json:"reorder_point"`
+	ReorderQuantity    int32           `json:"reorder_quantity"`
+	PreferredVendorID  uuid.NullUUID   `json:"preferred_vendor_id"`
+	ReorderRequestedAt sql.NullTime    `json:"reorder_requested_at"`
+	Serialized         bool            `json:"serialized"`
+	CostingMethod      string          `json:"costing_method"`
+	BaseUnit           string          `json:"base_unit"`
+	CategoryID         uuid.NullUUID   `json:"category_id"`
+	ProductID          uuid.NullUUID   `json:"product_id"`
+	VariantOptions     json.RawMessage `json:"variant_options"`
+	MinStock           int32           `json:"min_stock"`
+	StockAlert         sql.NullString  `json:"stock_alert"`
+	ArchivedAt         sql.NullTime    `json:"archived_at"`
+}
+
+type Location struct {
+	ID          uuid.UUID `json:"id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ProcessedEvent struct {
+	EventType   string    `json:"event_type"`
+	SourceID    uuid.UUID `json:"source_id"`
+	ItemID      uuid.UUID `json:"item_id"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+type ProductAxis struct {
+	ID         uuid.UUID `json:"id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Name       string    `json:"name"`
+	Position   int32     `json:"position"`
+	AxisValues []string  `json:"axis_values"`
+}
+
+type Product struct {
+	ID          uuid.UUID      `json:"id"`
 	Sku         string         `json:"sku"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
 	UnitPrice   string         `json:"unit_price"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
 type Stock struct {
+	ID         uuid.UUID `json:"id"`
+	ItemID     uuid.UUID `json:"item_id"`
+	Quantity   int32     `json:"quantity"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+type StockCountLine struct {
+	ID                 uuid.UUID      `json:"id"`
+	CountID            uuid.UUID      `json:"count_id"`
+	ItemID             uuid.UUID      `json:"item_id"`
+	LocationID         uuid.UUID      `json:"location_id"`
+	ExpectedQuantity   int32          `json:"expected_quantity"`
+	CountedQuantity    sql.NullInt32  `json:"counted_quantity"`
+	CountedBy          sql.NullString `json:"counted_by"`
+	CountedAt          sql.NullTime   `json:"counted_at"`
+	AdjustmentQuantity sql.NullInt32  `json:"adjustment_quantity"`
+}
+
+type StockCount struct {
+	ID          uuid.UUID      `json:"id"`
+	WarehouseID uuid.UUID      `json:"warehouse_id"`
+	Status      string         `json:"status"`
+	Notes       sql.NullString `json:"notes"`
+	SnapshotAt  time.Time      `json:"snapshot_at"`
+	CreatedBy   sql.NullString `json:"created_by"`
+	PostedBy    sql.NullString `json:"posted_by"`
+	PostedAt    sql.NullTime   `json:"posted_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type StockLot struct {
+	ID         uuid.UUID    `json:"id"`
+	ItemID     uuid.UUID    `json:"item_id"`
+	LocationID uuid.UUID    `json:"location_id"`
+	LotNumber  string       `json:"lot_number"`
+	ExpiryDate sql.NullTime `json:"expiry_date"`
+	Quantity   int32        `json:"quantity"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+type StockMovement struct {
+	ID               uuid.UUID      `json:"id"`
+	ItemID           uuid.UUID      `json:"item_id"`
+	LocationID       uuid.UUID      `json:"location_id"`
+	QuantityDelta    int32          `json:"quantity_delta"`
+	BalanceAfter     int32          `json:"balance_after"`
+	Reason           string         `json:"reason"`
+	SourceDocumentID uuid.NullUUID  `json:"source_document_id"`
+	UserID           sql.NullString `json:"user_id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	LotID            uuid.NullUUID  `json:"lot_id"`
+}
+
+type StockReservation struct {
+	ID          uuid.UUID    `json:"id"`
+	ItemID      uuid.UUID    `json:"item_id"`
+	WarehouseID uuid.UUID    `json:"warehouse_id"`
+	Quantity    int32        `json:"quantity"`
+	SourceType  string       `json:"source_type"`
+	SourceID    uuid.UUID    `json:"source_id"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type StockSerial struct {
+	ID           uuid.UUID     `json:"id"`
+	ItemID       uuid.UUID     `json:"item_id"`
+	SerialNumber string        `json:"serial_number"`
+	Status       string        `json:"status"`
+	LocationID   uuid.UUID     `json:"location_id"`
+	OrderID      uuid.NullUUID `json:"order_id"`
+	ReturnID     uuid.NullUUID `json:"return_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type StockTransferLine struct {
+	ID         uuid.UUID `json:"id"`
+	TransferID uuid.UUID `json:"transfer_id"`
+	ItemID     uuid.UUID `json:"item_id"`
+	Quantity   int32     `json:"quantity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type StockTransferLot struct {
+	ID         uuid.UUID    `json:"id"`
+	LineID     uuid.UUID    `json:"line_id"`
+	LotNumber  string       `json:"lot_number"`
+	ExpiryDate sql.NullTime `json:"expiry_date"`
+	Quantity   int32        `json:"quantity"`
+}
+
+type StockTransferSerial struct {
+	ID           uuid.UUID `json:"id"`
+	LineID       uuid.UUID `json:"line_id"`
+	SerialNumber string    `json:"serial_number"`
+}
+
+type StockTransfer struct {
+	ID                    uuid.UUID      `json:"id"`
+	SourceLocationID      uuid.UUID      `json:"source_location_id"`
+	DestinationLocationID uuid.UUID      `json:"destination_location_id"`
+	Status                string         `json:"status"`
+	Notes                 sql.NullString `json:"notes"`
+	CreatedBy             sql.NullString `json:"created_by"`
+	ShippedAt             sql.NullTime   `json:"shipped_at"`
+	ReceivedAt            sql.NullTime   `json:"received_at"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+}
+
+type Warehouse struct {
 	ID        uuid.UUID `json:"id"`
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -6,22 +6,150 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AdjustStock(ctx context.Context, arg AdjustStockParams) error
+	AdjustStockLot(ctx context.Context, arg AdjustStockLotParams) error
+	ApplyDueItemPrices(ctx context.Context, asOf time.Time) ([]ApplyDueItemPricesRow, error)
+	ArchiveItem(ctx context.Context, arg ArchiveItemParams) (int64, error)
+	ClearItemStockAlert(ctx context.Context, id uuid.UUID) error
+	ClearReorderRequested(ctx context.Context, id uuid.UUID) error
+	ConsumeCostLayer(ctx context.Context, arg ConsumeCostLayerParams) error
+	CreateAssemblyOrder(ctx context.Context, arg CreateAssemblyOrderParams) error
+	CreateAssemblyOrderLine(ctx context.Context, arg CreateAssemblyOrderLineParams) error
+	CreateBOMComponent(ctx context.Context, arg CreateBOMComponentParams) error
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) error
+	CreateCategoryAttribute(ctx context.Context, arg CreateCategoryAttributeParams) error
+	CreateCostEntry(ctx context.Context, arg CreateCostEntryParams) error
+	CreateCostLayer(ctx context.Context, arg CreateCostLayerParams) error
 	CreateItem(ctx context.Context, arg CreateItemParams) error
+	CreateItemAttributeValue(ctx context.Context, arg CreateItemAttributeValueParams) error
+	CreateItemBarcode(ctx context.Context, arg CreateItemBarcodeParams) error
+	CreateItemPrice(ctx context.Context, arg CreateItemPriceParams) error
+	CreateItemUnit(ctx context.Context, arg CreateItemUnitParams) error
+	CreateLocation(ctx context.Context, arg CreateLocationParams) error
+	CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) (int64, error)
+	CreateProduct(ctx context.Context, arg CreateProductParams) error
+	CreateProductAxis(ctx context.Context, arg CreateProductAxisParams) error
 	CreateStock(ctx context.Context, arg CreateStockParams) error
-	DeleteItem(ctx context.Context, id uuid.UUID) error
+	CreateStockCount(ctx context.Context, arg CreateStockCountParams) error
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) error
+	CreateStockReservation(ctx context.Context, arg CreateStockReservationParams) error
+	CreateStockTransfer(ctx context.Context, arg CreateStockTransferParams) error
+	CreateStockTransferLine(ctx context.Context, arg CreateStockTransferLineParams) error
+	CreateStockTransferLot(ctx context.Context, arg CreateStockTransferLotParams) error
+	CreateStockTransferSerial(ctx context.Context, arg CreateStockTransferSerialParams) error
+	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) error
+	DeleteBOMComponentsByItemID(ctx context.Context, itemID uuid.UUID) error
+	DeleteBillOfMaterials(ctx context.Context, itemID uuid.UUID) error
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	DeleteCategoryAttribute(ctx context.Context, id uuid.UUID) error
+	DeleteExpiredStockReservations(ctx context.Context, asOf time.Time) (int64, error)
+	DeleteItemAttributeValuesByAttributeID(ctx context.Context, attributeID uuid.UUID) error
+	DeleteItemAttributeValuesByItemID(ctx context.Context, itemID uuid.UUID) error
+	DeleteItemBarcode(ctx context.Context, id uuid.UUID) error
+	DeleteItemPrice(ctx context.Context, id uuid.UUID) error
+	DeleteItemUnitsByItemID(ctx context.Context, itemID uuid.UUID) error
+	DeleteProductAxesByProductID(ctx context.Context, productID uuid.UUID) error
+	DeleteStockReservationsBySource(ctx context.Context, arg DeleteStockReservationsBySourceParams) (int64, error)
+	EnsureStock(ctx context.Context, arg EnsureStockParams) error
+	GetAssemblyOrderByID(ctx context.Context, id uuid.UUID) (GetAssemblyOrderByIDRow, error)
+	GetAssemblyOrderForUpdate(ctx context.Context, id uuid.UUID) (AssemblyOrder, error)
+	GetBillOfMaterials(ctx context.Context, itemID uuid.UUID) (BillsOfMaterial, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error)
+	GetDefaultLocationByWarehouseID(ctx context.Context, warehouseID uuid.UUID) (Location, error)
+	GetItemBarcodeByCode(ctx context.Context, code string) (ItemBarcode, error)
 	GetItemByID(ctx context.Context, id uuid.UUID) (Item, error)
 	GetItemBySKU(ctx context.Context, sku string) (Item, error)
-	GetStockByItemID(ctx context.Context, itemID uuid.UUID) (Stock, error)
-	GetStockQuantityForUpdate(ctx context.Context, itemID uuid.UUID) (int32, error)
+	GetItemCostingMethodForUpdate(ctx context.Context, id uuid.UUID) (string, error)
+	GetItemPriceAt(ctx context.Context, arg GetItemPriceAtParams) (ItemPrice, error)
+	GetItemPriceByID(ctx context.Context, id uuid.UUID) (ItemPrice, error)
+	GetLocationByID(ctx context.Context, id uuid.UUID) (Location, error)
+	GetProductByID(ctx context.Context, id uuid.UUID) (Product, error)
+	GetStockCountByID(ctx context.Context, id uuid.UUID) (GetStockCountByIDRow, error)
+	GetStockCountForUpdate(ctx context.Context, id uuid.UUID) (StockCount, error)
+	GetStockLotByNumberForUpdate(ctx context.Context, arg GetStockLotByNumberForUpdateParams) (StockLot, error)
+	GetStockQuantityForUpdate(ctx context.Context, arg GetStockQuantityForUpdateParams) (int32, error)
+	GetStockSerialForUpdate(ctx context.Context, arg GetStockSerialForUpdateParams) (GetStockSerialForUpdateRow, error)
+	GetStockTransferByID(ctx context.Context, id uuid.UUID) (GetStockTransferByIDRow, error)
+	GetStockTransferForUpdate(ctx context.Context, id uuid.UUID) (StockTransfer, error)
+	GetWarehouseByID(ctx context.Context, id uuid.UUID) (Warehouse, error)
+	IsBOMComponent(ctx context.Context, componentItemID uuid.UUID) (bool, error)
+	IssueStockSerial(ctx context.Context, arg IssueStockSerialParams) error
+	ListAssemblyOrderLines(ctx context.Context, assemblyOrderID uuid.UUID) ([]ListAssemblyOrderLinesRow, error)
+	ListAssemblyOrders(ctx context.Context, arg ListAssemblyOrdersParams) ([]ListAssemblyOrdersRow, error)
+	ListBOMComponents(ctx context.Context, itemID uuid.UUID) ([]ListBOMComponentsRow, error)
+	ListBOMParentItemIDs(ctx context.Context, componentItemID uuid.UUID) ([]uuid.UUID, error)
+	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListCategoryAttributesByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]CategoryAttribute, error)
+	ListCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	ListExpiringLots(ctx context.Context, expiresOnOrBefore time.Time) ([]ListExpiringLotsRow, error)
+	ListInheritedCategoryAttributes(ctx context.Context, id uuid.UUID) ([]CategoryAttribute, error)
+	ListItemAttributeValuesByItemIDs(ctx context.Context, itemIds []uuid.UUID) ([]ListItemAttributeValuesByItemIDsRow, error)
+	ListItemBarcodesByItemID(ctx context.Context, itemID uuid.UUID) ([]ItemBarcode, error)
+	ListItemPrices(ctx context.Context, arg ListItemPricesParams) ([]ItemPrice, error)
+	ListItemStockForUpdate(ctx context.Context, itemID uuid.UUID) ([]ListItemStockForUpdateRow, error)
+	ListItemUnitsByItemIDs(ctx context.Context, itemIds []uuid.UUID) ([]ItemUnit, error)
+	ListItemValuations(ctx context.Context, before time.Time) ([]ListItemValuationsRow, error)
 	ListItems(ctx context.Context, arg ListItemsParams) ([]Item, error)
+	ListItemsBelowReorderPoint(ctx context.Context) ([]ListItemsBelowReorderPointRow, error)
+	ListItemsForExport(ctx context.Context, arg ListItemsForExportParams) ([]ListItemsForExportRow, error)
+	ListLocationLotsForUpdate(ctx context.Context, arg ListLocationLotsForUpdateParams) ([]StockLot, error)
+	ListLocationsByWarehouseID(ctx context.Context, warehouseID uuid.UUID) ([]Location, error)
+	ListOpenCostLayersForUpdate(ctx context.Context, itemID uuid.UUID) ([]CostLayer, error)
+	ListOrderCostOfGoodsSold(ctx context.Context, sourceDocumentID uuid.NullUUID) ([]ListOrderCostOfGoodsSoldRow, error)
+	ListProductAxesByProductIDs(ctx context.Context, productIds []uuid.UUID) ([]ProductAxis, error)
+	ListProductVariants(ctx context.Context, productID uuid.NullUUID) ([]ListProductVariantsRow, error)
+	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
+	ListStockByItemID(ctx context.Context, itemID uuid.UUID) ([]ListStockByItemIDRow, error)
+	ListStockCountLines(ctx context.Context, countID uuid.UUID) ([]ListStockCountLinesRow, error)
+	ListStockCounts(ctx context.Context, arg ListStockCountsParams) ([]ListStockCountsRow, error)
+	ListStockLotsByItemID(ctx context.Context, itemID uuid.UUID) ([]ListStockLotsByItemIDRow, error)
+	ListStockMovementsByItemID(ctx context.Context, arg ListStockMovementsByItemIDParams) ([]StockMovement, error)
+	ListStockReservationsByItemID(ctx context.Context, arg ListStockReservationsByItemIDParams) ([]StockReservation, error)
+	ListStockReservationsBySource(ctx context.Context, arg ListStockReservationsBySourceParams) ([]StockReservation, error)
+	ListStockSerialsByItemID(ctx context.Context, arg ListStockSerialsByItemIDParams) ([]ListStockSerialsByItemIDRow, error)
+	ListStockSerialsBySerialNumber(ctx context.Context, serialNumber string) ([]ListStockSerialsBySerialNumberRow, error)
+	ListStockTransferLines(ctx context.Context, transferID uuid.UUID) ([]ListStockTransferLinesRow, error)
+	ListStockTransferLotsByTransferID(ctx context.Context, transferID uuid.UUID) ([]StockTransferLot, error)
+	ListStockTransferSerialsByTransferID(ctx context.Context, transferID uuid.UUID) ([]StockTransferSerial, error)
+	ListStockTransfers(ctx context.Context, arg ListStockTransfersParams) ([]ListStockTransfersRow, error)
+	ListWarehouseLotsForUpdate(ctx context.Context, arg ListWarehouseLotsForUpdateParams) ([]StockLot, error)
+	ListWarehouseStockForUpdate(ctx context.Context, arg ListWarehouseStockForUpdateParams) ([]ListWarehouseStockForUpdateRow, error)
+	ListWarehouses(ctx context.Context, arg ListWarehousesParams) ([]Warehouse, error)
+	LockItem(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	MarkReorderRequested(ctx context.Context, arg MarkReorderRequestedParams) error
+	MoveStockSerial(ctx context.Context, arg MoveStockSerialParams) error
+	NextInternalBarcodeNumber(ctx context.Context) (int64, error)
+	RaiseItemStockAlert(ctx context.Context, arg RaiseItemStockAlertParams) (uuid.UUID, error)
+	ReceiveStockSerial(ctx context.Context, arg ReceiveStockSerialParams) (uuid.UUID, error)
+	RecordStockCountLine(ctx context.Context, arg RecordStockCountLineParams) error
+	ResetRecoveredReorderRequests(ctx context.Context) error
+	SearchItems(ctx context.Context, arg SearchItemsParams) ([]SearchItemsRow, error)
+	SetStockCountLineAdjustment(ctx context.Context, arg SetStockCountLineAdjustmentParams) error
+	SetStockCountSnapshotAt(ctx context.Context, arg SetStockCountSnapshotAtParams) error
+	SnapshotStockCountLines(ctx context.Context, arg SnapshotStockCountLinesParams) error
+	SnapshotUnstockedCountLines(ctx context.Context, arg SnapshotUnstockedCountLinesParams) error
+	StockCountIncludesItem(ctx context.Context, arg StockCountIncludesItemParams) (bool, error)
+	SumInTransitQuantity(ctx context.Context, itemID uuid.UUID) (int32, error)
+	SumItemCost(ctx context.Context, itemID uuid.UUID) (SumItemCostRow, error)
+	SumLocationLotQuantity(ctx context.Context, arg SumLocationLotQuantityParams) (int32, error)
+	SumReservedQuantity(ctx context.Context, arg SumReservedQuantityParams) (int32, error)
+	SumWarehouseReservedQuantity(ctx context.Context, arg SumWarehouseReservedQuantityParams) (int32, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error
+	UpdateCategoryAttribute(ctx context.Context, arg UpdateCategoryAttributeParams) error
 	UpdateItem(ctx context.Context, arg UpdateItemParams) error
-	UpdateStock(ctx context.Context, arg UpdateStockParams) error
+	UpdateLocation(ctx context.Context, arg UpdateLocationParams) error
+	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	UpdateStockCountStatus(ctx context.Context, arg UpdateStockCountStatusParams) error
+	UpdateStockTransferStatus(ctx context.Context, arg UpdateStockTransferStatusParams) error
+	UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams) error
+	UpsertBillOfMaterials(ctx context.Context, arg UpsertBillOfMaterialsParams) error
+	UpsertStockLot(ctx context.Context, arg UpsertStockLotParams) (uuid.UUID, error)
 }
 
 var _ Querier = (*Queries)(nil)
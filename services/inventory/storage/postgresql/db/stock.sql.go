@@ -14,38 +14,41 @@ import (
 
 const adjustStock = `-- name: AdjustStock :exec
 UPDATE stock
-SET quantity = quantity + $2,
+SET quantity = quantity + $3,
     updated_at = CURRENT_TIMESTAMP
-WHERE item_id = $1
+WHERE item_id = $1 AND location_id = $2
 `
 
 type AdjustStockParams struct {
-	ItemID   uuid.UUID `json:"item_id"`
-	Quantity int32     `json:"quantity"`
+	ItemID     uuid.UUID `json:"item_id"`
+	LocationID uuid.UUID `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
 }
 
 func (q *Queries) AdjustStock(ctx context.Context, arg AdjustStockParams) error {
-	_, err := q.db.ExecContext(ctx, adjustStock, arg.ItemID, arg.Quantity)
+	_, err := q.db.ExecContext(ctx, adjustStock, arg.ItemID, arg.LocationID, arg.Quantity)
 	return err
 }
 
 const createStock = `-- name: CreateStock :exec
-INSERT INTO stock (id, item_id, quantity, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO stock (id, item_id, location_id, quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type CreateStockParams struct {
-	ID        uuid.UUID `json:"id"`
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id"`
+	ItemID     uuid.UUID `json:"item_id"`
+	LocationID uuid.UUID `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 func (q *Queries) CreateStock(ctx context.Context, arg CreateStockParams) error {
 	_, err := q.db.ExecContext(ctx, createStock,
 		arg.ID,
 		arg.ItemID,
+		arg.LocationID,
 		arg.Quantity,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -53,50 +56,168 @@ func (q *Queries) CreateStock(ctx context.Context, arg CreateStockParams) error
 	return err
 }
 
-const getStockByItemID = `-- name: GetStockByItemID :one
-SELECT id, item_id, quantity, created_at, updated_at
-FROM stock
-WHERE item_id = $1
+const ensureStock = `-- name: EnsureStock :exec
+INSERT INTO stock (id, item_id, location_id, quantity, created_at, updated_at)
+VALUES ($1, $2, $3, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (item_id, location_id) DO NOTHING
 `
 
-func (q *Queries) GetStockByItemID(ctx context.Context, itemID uuid.UUID) (Stock, error) {
-	row := q.db.QueryRowContext(ctx, getStockByItemID, itemID)
-	var i Stock
-	err := row.Scan(
-		&i.ID,
-		&i.ItemID,
-		&i.Quantity,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type EnsureStockParams struct {
+	ID         uuid.UUID `json:"id"`
+	ItemID     uuid.UUID `json:"item_id"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+func (q *Queries) EnsureStock(ctx context.Context, arg EnsureStockParams) error {
+	_, err := q.db.ExecContext(ctx, ensureStock, arg.ID, arg.ItemID, arg.LocationID)
+	return err
 }
 
 const getStockQuantityForUpdate = `-- name: GetStockQuantityForUpdate :one
-SELECT quantity FROM stock WHERE item_id = $1 FOR UPDATE
+SELECT quantity FROM stock WHERE item_id = $1 AND location_id = $2 FOR UPDATE
 `
 
-func (q *Queries) GetStockQuantityForUpdate(ctx context.Context, itemID uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, getStockQuantityForUpdate, itemID)
+type GetStockQuantityForUpdateParams struct {
+	ItemID     uuid.UUID `json:"item_id"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+func (q *Queries) GetStockQuantityForUpdate(ctx context.Context, arg GetStockQuantityForUpdateParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getStockQuantityForUpdate, arg.ItemID, arg.LocationID)
 	var quantity int32
 	err := row.Scan(&quantity)
 	return quantity, err
 }
 
-const updateStock = `-- name: UpdateStock :exec
-UPDATE stock
-SET quantity = $2,
-    updated_at = $3
+const listItemStockForUpdate = `-- name: ListItemStockForUpdate :many
+SELECT location_id, quantity
+FROM stock
 WHERE item_id = $1
+ORDER BY location_id
+FOR UPDATE
 `
 
-type UpdateStockParams struct {
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
-	UpdatedAt time.Time `json:"updated_at"`
+type ListItemStockForUpdateRow struct {
+	LocationID uuid.UUID `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
 }
 
-func (q *Queries) UpdateStock(ctx context.Context, arg UpdateStockParams) error {
-	_, err := q.db.ExecContext(ctx, updateStock, arg.ItemID, arg.Quantity, arg.UpdatedAt)
-	return err
+func (q *Queries) ListItemStockForUpdate(ctx context.Context, itemID uuid.UUID) ([]ListItemStockForUpdateRow, error) {
+	rows, err := q.db.QueryContext(ctx, listItemStockForUpdate, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListItemStockForUpdateRow{}
+	for rows.Next() {
+		var i ListItemStockForUpdateRow
+		if err := rows.Scan(&i.LocationID, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockByItemID = `-- name: ListStockByItemID :many
+SELECT s.id, s.item_id, s.quantity, s.created_at, s.updated_at, s.location_id,
+       l.code AS location_code, l.warehouse_id, w.code AS warehouse_code
+FROM stock s
+JOIN locations l ON l.id = s.location_id
+JOIN warehouses w ON w.id = l.warehouse_id
+WHERE s.item_id = $1
+ORDER BY w.code ASC, l.code ASC
+`
+
+type ListStockByItemIDRow struct {
+	ID            uuid.UUID `json:"id"`
+	ItemID        uuid.UUID `json:"item_id"`
+	Quantity      int32     `json:"quantity"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	LocationID    uuid.UUID `json:"location_id"`
+	LocationCode  string    `json:"location_code"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+}
+
+func (q *Queries) ListStockByItemID(ctx context.Context, itemID uuid.UUID) ([]ListStockByItemIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStockByItemID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStockByItemIDRow{}
+	for rows.Next() {
+		var i ListStockByItemIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.Quantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LocationID,
+			&i.LocationCode,
+			&i.WarehouseID,
+			&i.WarehouseCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWarehouseStockForUpdate = `-- name: ListWarehouseStockForUpdate :many
+SELECT s.location_id, s.quantity
+FROM stock s
+JOIN locations l ON l.id = s.location_id
+WHERE s.item_id = $1 AND l.warehouse_id = $2
+ORDER BY l.is_default DESC, l.code ASC
+FOR UPDATE OF s
+`
+
+type ListWarehouseStockForUpdateParams struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+}
+
+type ListWarehouseStockForUpdateRow struct {
+	LocationID uuid.UUID `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
+}
+
+func (q *Queries) ListWarehouseStockForUpdate(ctx context.Context, arg ListWarehouseStockForUpdateParams) ([]ListWarehouseStockForUpdateRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWarehouseStockForUpdate, arg.ItemID, arg.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWarehouseStockForUpdateRow{}
+	for rows.Next() {
+		var i ListWarehouseStockForUpdateRow
+		if err := rows.Scan(&i.LocationID, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
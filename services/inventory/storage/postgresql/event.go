@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordProcessedEvent marks the event a stock change was made for as processed for the item,
+// within the change's transaction. An event whose change was already recorded is refused with
+// ErrConflict. Changes not made for an event are not recorded.
+func recordProcessedEvent(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, source model.StockMovementSource) error {
+	if source.EventType == "" || source.SourceDocumentID == nil {
+		return nil
+	}
+
+	recorded, err := qtx.CreateProcessedEvent(ctx, db.CreateProcessedEventParams{
+		EventType:   source.EventType,
+		SourceID:    *source.SourceDocumentID,
+		ItemID:      itemID,
+		ProcessedAt: time.Now(),
+	})
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if recorded == 0 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+// stockLineFailureReason describes why applying a line failed
+func stockLineFailureReason(err error) model.StockLineFailureReason {
+	switch err {
+	case errors.ErrNotFound:
+		return model.StockLineFailureNotFound
+	case errors.ErrConflict:
+		return model.StockLineFailureConflict
+	default:
+		return model.StockLineFailureRejected
+	}
+}
+
+// eventReservationSource names the source document whose reservations an event's stock changes
+// fulfil. Only sales orders hold reservations; for other events no document is named.
+func eventReservationSource(source model.StockMovementSource) (model.ReservationSourceType, uuid.UUID) {
+	if source.Reason == model.StockMovementReasonSale && source.SourceDocumentID != nil {
+		return model.ReservationSourceSalesOrder, *source.SourceDocumentID
+	}
+	return "", uuid.Nil
+}
+
+// ApplyStockEventLines applies the lines of a stock event to a warehouse in one transaction, so
+// either every line is applied or none is. The warehouse stock of the lines' items is locked
+// first, in item order, so concurrent events over the same items wait for each other instead of
+// deadlocking. Lines already applied for the event are skipped and left out of the lines
+// returned. Every issue the warehouse's available stock cannot cover, the stock on hand less what
+// other documents hold reserved, is reported before anything is written; a line failing for
+// another reason is reported on its own. Failed lines are returned
+// with ErrBadRequest.
+func (s *Storage) ApplyStockEventLines(ctx context.Context, warehouseID string, lines []model.StockEventLine, source model.StockMovementSource) ([]model.StockEventLine, []model.StockLineFailure, error) {
+	warehouseUUID, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return nil, nil, errors.ErrBadRequest
+	}
+
+	ordered := slices.Clone(lines)
+	slices.SortFunc(ordered, func(a, b model.StockEventLine) int {
+		return strings.Compare(a.ItemID, b.ItemID)
+	})
+
+	itemUUIDs := make(map[string]uuid.UUID, len(ordered))
+	for _, line := range ordered {
+		itemUUID, err := uuid.Parse(line.ItemID)
+		if err != nil {
+			failure := model.StockLineFailure{
+				ItemID:   line.ItemID,
+				Quantity: abs(line.Quantity),
+				Reason:   model.StockLineFailureRejected,
+			}
+			return nil, []model.StockLineFailure{failure}, errors.ErrBadRequest
+		}
+		itemUUIDs[line.ItemID] = itemUUID
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	// Stock reserved for the event's own document is what the event takes, so only the
+	// reservations of other documents are held back from issues
+	reservationType, reservationID := eventReservationSource(source)
+	asOf := time.Now().UTC()
+
+	pending := make([]model.StockEventLine, 0, len(ordered))
+	failures := make([]model.StockLineFailure, 0)
+	for _, line := range ordered {
+		itemUUID := itemUUIDs[line.ItemID]
+
+		if err := recordProcessedEvent(ctx, qtx, itemUUID, source); err == errors.ErrConflict {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		available, err := availableWarehouseStock(ctx, qtx, itemUUID, warehouseUUID, reservationType, reservationID, asOf)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if line.Quantity < 0 && available < -line.Quantity {
+			failures = append(failures, model.StockLineFailure{
+				ItemID:    line.ItemID,
+				Quantity:  -line.Quantity,
+				Available: &available,
+				Reason:    model.StockLineFailureInsufficientStock,
+			})
+			continue
+		}
+
+		pending = append(pending, line)
+	}
+	if len(failures) > 0 {
+		return nil, failures, errors.ErrBadRequest
+	}
+
+	for _, line := range pending {
+		itemUUID := itemUUIDs[line.ItemID]
+
+		if line.Quantity > 0 {
+			err = receiveWarehouseLine(ctx, qtx, itemUUID, warehouseUUID, line.Quantity, line.Lots, line.SerialNumbers, line.Costs, source)
+		} else {
+			err = issueWarehouseLine(ctx, qtx, itemUUID, warehouseUUID, -line.Quantity, line.SerialNumbers, source)
+		}
+		if err == errors.ErrInternalServerError {
+			return nil, nil, err
+		}
+		if err != nil {
+			failure := model.StockLineFailure{
+				ItemID:   line.ItemID,
+				Quantity: abs(line.Quantity),
+				Reason:   stockLineFailureReason(err),
+			}
+			return nil, []model.StockLineFailure{failure}, errors.ErrBadRequest
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.ErrInternalServerError
+	}
+
+	return pending, nil, nil
+}
@@ -0,0 +1,248 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertNullTimeToPtr converts a nullable database date to an optional time
+func convertNullTimeToPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
+
+func (s *Storage) ListStockLotsByItemID(ctx context.Context, itemID string) ([]model.StockLot, error) {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListStockLotsByItemID(ctx, itemUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lots := make([]model.StockLot, 0, len(rows))
+	for _, row := range rows {
+		lots = append(lots, model.StockLot{
+			ID:            row.ID,
+			ItemID:        row.ItemID,
+			LocationID:    row.LocationID,
+			LocationCode:  row.LocationCode,
+			WarehouseID:   row.WarehouseID,
+			WarehouseCode: row.WarehouseCode,
+			LotNumber:     row.LotNumber,
+			ExpiryDate:    convertNullTimeToPtr(row.ExpiryDate),
+			Quantity:      int(row.Quantity),
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		})
+	}
+
+	return lots, nil
+}
+
+// ListExpiringLots lists lots with stock that expire on or before the given date, soonest first
+func (s *Storage) ListExpiringLots(ctx context.Context, expiresOnOrBefore time.Time) ([]model.ExpiringLot, error) {
+	rows, err := s.queries.ListExpiringLots(ctx, expiresOnOrBefore)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lots := make([]model.ExpiringLot, 0, len(rows))
+	for _, row := range rows {
+		lots = append(lots, model.ExpiringLot{
+			StockLot: model.StockLot{
+				ID:            row.ID,
+				ItemID:        row.ItemID,
+				LocationID:    row.LocationID,
+				LocationCode:  row.LocationCode,
+				WarehouseID:   row.WarehouseID,
+				WarehouseCode: row.WarehouseCode,
+				LotNumber:     row.LotNumber,
+				ExpiryDate:    convertNullTimeToPtr(row.ExpiryDate),
+				Quantity:      int(row.Quantity),
+				CreatedAt:     row.CreatedAt,
+				UpdatedAt:     row.UpdatedAt,
+			},
+			SKU:  row.Sku,
+			Name: row.Name,
+		})
+	}
+
+	return lots, nil
+}
+
+// receiveLocationStock books an increase to a location, under the lot when one is given
+func receiveLocationStock(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, quantity int, lot *model.LotRef, source model.StockMovementSource) error {
+	if lot == nil {
+		return adjustLocationStock(ctx, qtx, itemID, locationID, quantity, nil, source)
+	}
+
+	lotParams := db.UpsertStockLotParams{
+		ID:         uuid.New(),
+		ItemID:     itemID,
+		LocationID: locationID,
+		LotNumber:  strings.TrimSpace(lot.LotNumber),
+		ExpiryDate: convertOptionalTimeToNullTime(lot.ExpiryDate),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	lotID, err := qtx.UpsertStockLot(ctx, lotParams)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return errors.ErrNotFound
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return adjustLocationStock(ctx, qtx, itemID, locationID, quantity, &lotID, source)
+}
+
+// issueLotStock takes a quantity out of one lot at a location
+func issueLotStock(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, quantity int, lotNumber string, source model.StockMovementSource) error {
+	lotParams := db.GetStockLotByNumberForUpdateParams{
+		ItemID:     itemID,
+		LocationID: locationID,
+		LotNumber:  strings.TrimSpace(lotNumber),
+	}
+	lot, err := qtx.GetStockLotByNumberForUpdate(ctx, lotParams)
+	if err == sql.ErrNoRows {
+		return errors.ErrBadRequest
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if int(lot.Quantity) < quantity {
+		return errors.ErrBadRequest
+	}
+
+	return adjustLocationStock(ctx, qtx, itemID, locationID, -quantity, &lot.ID, source)
+}
+
+// issueLocationStock takes a quantity out of a location, consuming its lots in FEFO order before
+// unlotted stock. Each lot consumed is recorded as its own movement.
+func issueLocationStock(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, quantity int, source model.StockMovementSource) error {
+	_, err := issueLocationLots(ctx, qtx, itemID, locationID, quantity, source)
+	return err
+}
+
+// issueLocationLots takes a quantity out of a location like issueLocationStock and returns the
+// lots it was taken from
+func issueLocationLots(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, quantity int, source model.StockMovementSource) ([]model.LotQuantity, error) {
+	lotsParams := db.ListLocationLotsForUpdateParams{
+		ItemID:     itemID,
+		LocationID: locationID,
+	}
+	lots, err := qtx.ListLocationLotsForUpdate(ctx, lotsParams)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	consumed, remaining, err := consumeLots(ctx, qtx, lots, quantity, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if remaining > 0 {
+		if err := adjustLocationStock(ctx, qtx, itemID, locationID, -remaining, nil, source); err != nil {
+			return nil, err
+		}
+	}
+
+	return consumed, nil
+}
+
+// issueWarehouseStock takes a quantity out of a warehouse, consuming its lots in FEFO order and
+// then unlotted stock from the default location first and the other locations in code order
+func issueWarehouseStock(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, quantity int, source model.StockMovementSource) error {
+	lotsParams := db.ListWarehouseLotsForUpdateParams{
+		ItemID:      itemID,
+		WarehouseID: warehouseID,
+	}
+	lots, err := qtx.ListWarehouseLotsForUpdate(ctx, lotsParams)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	_, remaining, err := consumeLots(ctx, qtx, lots, quantity, source)
+	if err != nil {
+		return err
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	// Every lot in the warehouse is used up, so what is left at each location is unlotted
+	stockParams := db.ListWarehouseStockForUpdateParams{
+		ItemID:      itemID,
+		WarehouseID: warehouseID,
+	}
+	rows, err := qtx.ListWarehouseStockForUpdate(ctx, stockParams)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if len(rows) == 0 {
+		return errors.ErrNotFound
+	}
+
+	for _, row := range rows {
+		if remaining == 0 {
+			break
+		}
+		take := min(remaining, int(row.Quantity))
+		if take == 0 {
+			continue
+		}
+		if err := adjustLocationStock(ctx, qtx, itemID, row.LocationID, -take, nil, source); err != nil {
+			return err
+		}
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return errors.ErrBadRequest
+	}
+
+	return nil
+}
+
+// consumeLots takes up to quantity from the lots in the order given and returns the lots taken
+// from and what is left
+func consumeLots(ctx context.Context, qtx *db.Queries, lots []db.StockLot, quantity int, source model.StockMovementSource) ([]model.LotQuantity, int, error) {
+	consumed := make([]model.LotQuantity, 0)
+	remaining := quantity
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+		take := min(remaining, int(lot.Quantity))
+		if take == 0 {
+			continue
+		}
+		if err := adjustLocationStock(ctx, qtx, lot.ItemID, lot.LocationID, -take, &lot.ID, source); err != nil {
+			return nil, 0, err
+		}
+		consumed = append(consumed, model.LotQuantity{
+			LotRef: model.LotRef{
+				LotNumber:  lot.LotNumber,
+				ExpiryDate: convertNullTimeToPtr(lot.ExpiryDate),
+			},
+			Quantity: take,
+		})
+		remaining -= take
+	}
+	return consumed, remaining, nil
+}
@@ -0,0 +1,74 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// convertDBStockMovementToModel converts sqlc generated db.StockMovement to model.StockMovement
+func convertDBStockMovementToModel(dbMovement db.StockMovement) model.StockMovement {
+	movement := model.StockMovement{
+		ID:            dbMovement.ID,
+		ItemID:        dbMovement.ItemID,
+		LocationID:    dbMovement.LocationID,
+		QuantityDelta: int(dbMovement.QuantityDelta),
+		BalanceAfter:  int(dbMovement.BalanceAfter),
+		Reason:        model.StockMovementReason(dbMovement.Reason),
+		CreatedAt:     dbMovement.CreatedAt,
+	}
+
+	if dbMovement.SourceDocumentID.Valid {
+		sourceDocumentID := dbMovement.SourceDocumentID.UUID
+		movement.SourceDocumentID = &sourceDocumentID
+	}
+	if dbMovement.LotID.Valid {
+		lotID := dbMovement.LotID.UUID
+		movement.LotID = &lotID
+	}
+	if dbMovement.UserID.Valid {
+		movement.UserID = dbMovement.UserID.String
+	}
+
+	return movement
+}
+
+// convertOptionalTimeToNullTime converts an optional time to sql.NullTime
+func convertOptionalTimeToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func (s *Storage) ListStockMovements(ctx context.Context, filter model.StockMovementFilter, limit, offset int) ([]model.StockMovement, error) {
+	itemUUID, err := uuid.Parse(filter.ItemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListStockMovementsByItemIDParams{
+		ItemID:   itemUUID,
+		FromDate: convertOptionalTimeToNullTime(filter.From),
+		ToDate:   convertOptionalTimeToNullTime(filter.To),
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	}
+
+	dbMovements, err := s.queries.ListStockMovementsByItemID(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	movements := make([]model.StockMovement, 0, len(dbMovements))
+	for _, dbMovement := range dbMovements {
+		movements = append(movements, convertDBStockMovementToModel(dbMovement))
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func convertDBItemPriceToModel(dbPrice db.ItemPrice) model.ItemPrice {
+	price := model.ItemPrice{
+		ID:            dbPrice.ID,
+		ItemID:        dbPrice.ItemID,
+		EffectiveFrom: dbPrice.EffectiveFrom,
+		CreatedAt:     dbPrice.CreatedAt,
+	}
+	if unitPrice, err := strconv.ParseFloat(dbPrice.UnitPrice, 64); err == nil {
+		price.UnitPrice = unitPrice
+	}
+	return price
+}
+
+// recordItemPrice adds an entry to an item's price history within a transaction
+func recordItemPrice(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, unitPrice float64, effectiveFrom time.Time) error {
+	err := qtx.CreateItemPrice(ctx, db.CreateItemPriceParams{
+		ID:            uuid.New(),
+		ItemID:        itemID,
+		UnitPrice:     strconv.FormatFloat(unitPrice, 'f', 2, 64),
+		EffectiveFrom: effectiveFrom.UTC(),
+		CreatedAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *Storage) CreateItemPrice(ctx context.Context, price model.ItemPrice) error {
+	params := db.CreateItemPriceParams{
+		ID:            price.ID,
+		ItemID:        price.ItemID,
+		UnitPrice:     strconv.FormatFloat(price.UnitPrice, 'f', 2, 64),
+		EffectiveFrom: price.EffectiveFrom,
+		CreatedAt:     price.CreatedAt,
+	}
+
+	if err := s.queries.CreateItemPrice(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				return errors.ErrConflict
+			case "23503":
+				return errors.ErrNotFound
+			case "23514":
+				return errors.ErrBadRequest
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetItemPriceByID(ctx context.Context, id string) (model.ItemPrice, error) {
+	priceID, err := uuid.Parse(id)
+	if err != nil {
+		return model.ItemPrice{}, errors.ErrBadRequest
+	}
+
+	dbPrice, err := s.queries.GetItemPriceByID(ctx, priceID)
+	if err == sql.ErrNoRows {
+		return model.ItemPrice{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.ItemPrice{}, errors.ErrInternalServerError
+	}
+
+	return convertDBItemPriceToModel(dbPrice), nil
+}
+
+// GetItemPriceAt returns the price of an item in effect at a time
+func (s *Storage) GetItemPriceAt(ctx context.Context, itemID string, at time.Time) (model.ItemPrice, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return model.ItemPrice{}, errors.ErrBadRequest
+	}
+
+	params := db.GetItemPriceAtParams{
+		ItemID:        id,
+		EffectiveFrom: at.UTC(),
+	}
+
+	dbPrice, err := s.queries.GetItemPriceAt(ctx, params)
+	if err == sql.ErrNoRows {
+		return model.ItemPrice{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.ItemPrice{}, errors.ErrInternalServerError
+	}
+
+	return convertDBItemPriceToModel(dbPrice), nil
+}
+
+func (s *Storage) ListItemPrices(ctx context.Context, itemID string, limit, offset int) ([]model.ItemPrice, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListItemPricesParams{
+		ItemID: id,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	rows, err := s.queries.ListItemPrices(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	prices := make([]model.ItemPrice, 0, len(rows))
+	for _, row := range rows {
+		prices = append(prices, convertDBItemPriceToModel(row))
+	}
+
+	return prices, nil
+}
+
+func (s *Storage) DeleteItemPrice(ctx context.Context, id uuid.UUID) error {
+	if err := s.queries.DeleteItemPrice(ctx, id); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ApplyDueItemPrices sets the unit price of every item whose price in effect at asOf differs
+// from it, and returns the new prices
+func (s *Storage) ApplyDueItemPrices(ctx context.Context, asOf time.Time) ([]model.ItemPrice, error) {
+	rows, err := s.queries.ApplyDueItemPrices(ctx, asOf.UTC())
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	prices := make([]model.ItemPrice, 0, len(rows))
+	for _, row := range rows {
+		price := model.ItemPrice{ItemID: row.ID}
+		if unitPrice, err := strconv.ParseFloat(row.UnitPrice, 64); err == nil {
+			price.UnitPrice = unitPrice
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
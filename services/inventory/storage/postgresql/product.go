@@ -0,0 +1,285 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBProductToModel converts sqlc generated db.Product to model.Product
+func convertDBProductToModel(dbProduct db.Product) model.Product {
+	product := model.Product{
+		ID:        dbProduct.ID,
+		SKU:       dbProduct.Sku,
+		Name:      dbProduct.Name,
+		CreatedAt: dbProduct.CreatedAt,
+		UpdatedAt: dbProduct.UpdatedAt,
+	}
+
+	if dbProduct.Description.Valid {
+		product.Description = dbProduct.Description.String
+	}
+
+	if unitPrice, err := strconv.ParseFloat(dbProduct.UnitPrice, 64); err == nil {
+		product.UnitPrice = unitPrice
+	}
+
+	return product
+}
+
+// convertDBVariantOptions decodes the variant options of an item; items that are not variants
+// have none
+func convertDBVariantOptions(raw json.RawMessage) map[string]string {
+	var options map[string]string
+	if err := json.Unmarshal(raw, &options); err != nil || len(options) == 0 {
+		return nil
+	}
+	return options
+}
+
+// convertModelVariantOptions encodes the variant options of an item as a JSON object
+func convertModelVariantOptions(options map[string]string) json.RawMessage {
+	if len(options) == 0 {
+		return json.RawMessage("{}")
+	}
+	raw, err := json.Marshal(options)
+	if err != nil {
+		return json.RawMessage("{}")
+	}
+	return raw
+}
+
+// loadProductAxes fills in the axes of the products given
+func loadProductAxes(ctx context.Context, qtx *db.Queries, products []model.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	rows, err := qtx.ListProductAxesByProductIDs(ctx, productIDs)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	axes := make(map[uuid.UUID][]model.ProductAxis, len(products))
+	for _, row := range rows {
+		axes[row.ProductID] = append(axes[row.ProductID], model.ProductAxis{
+			Name:   row.Name,
+			Values: row.AxisValues,
+		})
+	}
+
+	for i := range products {
+		products[i].Axes = axes[products[i].ID]
+	}
+
+	return nil
+}
+
+// replaceProductAxes replaces the axes of a product, keeping their order
+func replaceProductAxes(ctx context.Context, qtx *db.Queries, productID uuid.UUID, axes []model.ProductAxis) error {
+	if err := qtx.DeleteProductAxesByProductID(ctx, productID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	for i, axis := range axes {
+		err := qtx.CreateProductAxis(ctx, db.CreateProductAxisParams{
+			ID:         uuid.New(),
+			ProductID:  productID,
+			Name:       strings.TrimSpace(axis.Name),
+			Position:   int32(i),
+			AxisValues: axis.Values,
+		})
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return errors.ErrBadRequest
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	return nil
+}
+
+// createVariantItems stores the variant items of a product. A SKU that is already taken is a
+// conflict.
+func createVariantItems(ctx context.Context, qtx *db.Queries, variants []model.Item) error {
+	for _, variant := range variants {
+		variant.SKU = strings.ToUpper(strings.TrimSpace(variant.SKU))
+		if err := qtx.CreateItem(ctx, convertModelItemToCreateParams(variant)); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return errors.ErrConflict
+			}
+			return errors.ErrInternalServerError
+		}
+		if err := recordItemPrice(ctx, qtx, variant.ID, variant.UnitPrice, variant.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateProduct stores a product together with its axes and variant items
+func (s *Storage) CreateProduct(ctx context.Context, product model.Product, variants []model.Item) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.CreateProductParams{
+		ID:          product.ID,
+		Sku:         strings.ToUpper(strings.TrimSpace(product.SKU)),
+		Name:        strings.TrimSpace(product.Name),
+		Description: convertDescriptionToNullString(product.Description),
+		UnitPrice:   strconv.FormatFloat(product.UnitPrice, 'f', 2, 64),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+	if err := qtx.CreateProduct(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+
+	if err := replaceProductAxes(ctx, qtx, product.ID, product.Axes); err != nil {
+		return err
+	}
+
+	if err := createVariantItems(ctx, qtx, variants); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetProductByID(ctx context.Context, id string) (model.Product, error) {
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		return model.Product{}, errors.ErrBadRequest
+	}
+
+	dbProduct, err := s.queries.GetProductByID(ctx, productID)
+	if err == sql.ErrNoRows {
+		return model.Product{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.Product{}, errors.ErrInternalServerError
+	}
+
+	products := []model.Product{convertDBProductToModel(dbProduct)}
+	if err := loadProductAxes(ctx, s.queries, products); err != nil {
+		return model.Product{}, err
+	}
+
+	return products[0], nil
+}
+
+func (s *Storage) ListProducts(ctx context.Context, limit, offset int) ([]model.Product, error) {
+	dbProducts, err := s.queries.ListProducts(ctx, db.ListProductsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	products := make([]model.Product, 0, len(dbProducts))
+	for _, dbProduct := range dbProducts {
+		products = append(products, convertDBProductToModel(dbProduct))
+	}
+
+	if err := loadProductAxes(ctx, s.queries, products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// UpdateProduct saves a product, replaces its axes and stores the new variant items
+func (s *Storage) UpdateProduct(ctx context.Context, product model.Product, variants []model.Item) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.UpdateProductParams{
+		ID:          product.ID,
+		Name:        strings.TrimSpace(product.Name),
+		Description: convertDescriptionToNullString(product.Description),
+		UnitPrice:   strconv.FormatFloat(product.UnitPrice, 'f', 2, 64),
+		UpdatedAt:   product.UpdatedAt,
+	}
+	if err := qtx.UpdateProduct(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := replaceProductAxes(ctx, qtx, product.ID, product.Axes); err != nil {
+		return err
+	}
+
+	if err := createVariantItems(ctx, qtx, variants); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ListProductVariants lists the variant items of a product with their stock on hand and in
+// transit
+func (s *Storage) ListProductVariants(ctx context.Context, productID string) ([]model.ProductVariant, error) {
+	id, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.ListProductVariants(ctx, uuid.NullUUID{UUID: id, Valid: true})
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	variants := make([]model.ProductVariant, 0, len(rows))
+	for _, row := range rows {
+		variant := model.ProductVariant{
+			ItemID:    row.ID,
+			SKU:       row.Sku,
+			Name:      row.Name,
+			Options:   convertDBVariantOptions(row.VariantOptions),
+			Quantity:  int(row.Quantity),
+			InTransit: int(row.InTransit),
+		}
+		if unitPrice, err := strconv.ParseFloat(row.UnitPrice, 64); err == nil {
+			variant.UnitPrice = unitPrice
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
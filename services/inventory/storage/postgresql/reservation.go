@@ -0,0 +1,249 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func convertDBStockReservationToModel(dbReservation db.StockReservation) model.StockReservation {
+	reservation := model.StockReservation{
+		ID:          dbReservation.ID,
+		ItemID:      dbReservation.ItemID,
+		WarehouseID: dbReservation.WarehouseID,
+		Quantity:    int(dbReservation.Quantity),
+		SourceType:  model.ReservationSourceType(dbReservation.SourceType),
+		SourceID:    dbReservation.SourceID,
+		CreatedAt:   dbReservation.CreatedAt,
+	}
+	if dbReservation.ExpiresAt.Valid {
+		reservation.ExpiresAt = &dbReservation.ExpiresAt.Time
+	}
+	return reservation
+}
+
+// ReplaceStockReservations releases the reservations held for a source document and creates the
+// given ones in their place. A reservation may not exceed the stock on hand in its warehouse less
+// what other documents hold reserved there; if any does, ErrConflict is returned and the
+// document keeps its earlier reservations.
+func (s *Storage) ReplaceStockReservations(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID, reservations []model.StockReservation) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	deleteParams := db.DeleteStockReservationsBySourceParams{
+		SourceType: sourceType.String(),
+		SourceID:   sourceID,
+	}
+	if _, err := qtx.DeleteStockReservationsBySource(ctx, deleteParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	// Stock is locked in item order, as when stock events are applied, so that concurrent
+	// reservations cannot deadlock
+	ordered := slices.Clone(reservations)
+	slices.SortFunc(ordered, func(a, b model.StockReservation) int {
+		return strings.Compare(a.ItemID.String(), b.ItemID.String())
+	})
+
+	for _, reservation := range ordered {
+		available, err := availableWarehouseStock(ctx, qtx, reservation.ItemID, reservation.WarehouseID, sourceType, sourceID, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if reservation.Quantity > available {
+			return errors.ErrConflict
+		}
+
+		params := db.CreateStockReservationParams{
+			ID:          reservation.ID,
+			ItemID:      reservation.ItemID,
+			WarehouseID: reservation.WarehouseID,
+			Quantity:    int32(reservation.Quantity),
+			SourceType:  sourceType.String(),
+			SourceID:    sourceID,
+			CreatedAt:   reservation.CreatedAt,
+		}
+		if reservation.ExpiresAt != nil {
+			params.ExpiresAt = sql.NullTime{Time: *reservation.ExpiresAt, Valid: true}
+		}
+
+		if err := qtx.CreateStockReservation(ctx, params); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok {
+				switch pqErr.Code {
+				case "23505":
+					return errors.ErrConflict
+				case "23503", "23514":
+					return errors.ErrBadRequest
+				}
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// availableWarehouseStock locks an item's stock in a warehouse and returns the quantity on hand
+// there less what documents other than the given one hold reserved at asOf
+func availableWarehouseStock(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, sourceType model.ReservationSourceType, sourceID uuid.UUID, asOf time.Time) (int, error) {
+	onHand, reserved, err := warehouseStock(ctx, qtx, itemID, warehouseID, sourceType, sourceID, asOf)
+	if err != nil {
+		return 0, err
+	}
+	return onHand - reserved, nil
+}
+
+// warehouseStock locks an item's stock in a warehouse and returns the quantity on hand there and
+// what documents other than the given one hold reserved at asOf
+func warehouseStock(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, sourceType model.ReservationSourceType, sourceID uuid.UUID, asOf time.Time) (int, int, error) {
+	stockParams := db.ListWarehouseStockForUpdateParams{
+		ItemID:      itemID,
+		WarehouseID: warehouseID,
+	}
+	rows, err := qtx.ListWarehouseStockForUpdate(ctx, stockParams)
+	if err != nil {
+		return 0, 0, errors.ErrInternalServerError
+	}
+
+	onHand := 0
+	for _, row := range rows {
+		onHand += int(row.Quantity)
+	}
+
+	reservedParams := db.SumWarehouseReservedQuantityParams{
+		ItemID:            itemID,
+		WarehouseID:       warehouseID,
+		ExcludeSourceType: sourceType.String(),
+		ExcludeSourceID:   sourceID,
+		AsOf:              asOf,
+	}
+	reserved, err := qtx.SumWarehouseReservedQuantity(ctx, reservedParams)
+	if err != nil {
+		return 0, 0, errors.ErrInternalServerError
+	}
+
+	return onHand, int(reserved), nil
+}
+
+// checkUnreservedStock locks the warehouse stock of the items about to be issued, in item order
+// as stock events do, and refuses with ErrConflict an issue that would take stock documents hold
+// reserved there. Issues that only reduce an item's stock, such as manual adjustments, transfers,
+// assembly builds and counts, may not drive it below what sales orders have reserved. An issue
+// beyond the stock on hand is left to the issue itself to reject.
+func checkUnreservedStock(ctx context.Context, qtx *db.Queries, warehouseID uuid.UUID, quantities map[uuid.UUID]int) error {
+	itemIDs := slices.SortedFunc(maps.Keys(quantities), func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	asOf := time.Now().UTC()
+	for _, itemID := range itemIDs {
+		quantity := quantities[itemID]
+		if quantity <= 0 {
+			continue
+		}
+
+		onHand, reserved, err := warehouseStock(ctx, qtx, itemID, warehouseID, "", uuid.Nil, asOf)
+		if err != nil {
+			return err
+		}
+		if quantity <= onHand && quantity > onHand-reserved {
+			return errors.ErrConflict
+		}
+	}
+
+	return nil
+}
+
+// ListStockReservationsBySource returns the reservations held for a source document that have
+// not expired at asOf
+func (s *Storage) ListStockReservationsBySource(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID, asOf time.Time) ([]model.StockReservation, error) {
+	params := db.ListStockReservationsBySourceParams{
+		SourceType: sourceType.String(),
+		SourceID:   sourceID,
+		AsOf:       asOf,
+	}
+
+	rows, err := s.queries.ListStockReservationsBySource(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	reservations := make([]model.StockReservation, 0, len(rows))
+	for _, row := range rows {
+		reservations = append(reservations, convertDBStockReservationToModel(row))
+	}
+
+	return reservations, nil
+}
+
+// ListStockReservationsByItemID returns the reservations of an item that have not expired at asOf
+func (s *Storage) ListStockReservationsByItemID(ctx context.Context, itemID string, asOf time.Time, limit, offset int) ([]model.StockReservation, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListStockReservationsByItemIDParams{
+		ItemID: id,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+		AsOf:   asOf,
+	}
+
+	rows, err := s.queries.ListStockReservationsByItemID(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	reservations := make([]model.StockReservation, 0, len(rows))
+	for _, row := range rows {
+		reservations = append(reservations, convertDBStockReservationToModel(row))
+	}
+
+	return reservations, nil
+}
+
+// ReleaseStockReservations removes the reservations held for a source document and returns how
+// many there were
+func (s *Storage) ReleaseStockReservations(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID) (int64, error) {
+	params := db.DeleteStockReservationsBySourceParams{
+		SourceType: sourceType.String(),
+		SourceID:   sourceID,
+	}
+
+	released, err := s.queries.DeleteStockReservationsBySource(ctx, params)
+	if err != nil {
+		return 0, errors.ErrInternalServerError
+	}
+
+	return released, nil
+}
+
+// DeleteExpiredStockReservations removes the reservations that expired at or before asOf and
+// returns how many there were
+func (s *Storage) DeleteExpiredStockReservations(ctx context.Context, asOf time.Time) (int64, error) {
+	deleted, err := s.queries.DeleteExpiredStockReservations(ctx, asOf)
+	if err != nil {
+		return 0, errors.ErrInternalServerError
+	}
+
+	return deleted, nil
+}
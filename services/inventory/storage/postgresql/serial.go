@@ -0,0 +1,240 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertNullUUIDToPtr converts a nullable database ID to an optional ID
+func convertNullUUIDToPtr(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := id.UUID
+	return &value
+}
+
+func (s *Storage) ListStockSerials(ctx context.Context, filter model.StockSerialFilter, limit, offset int) ([]model.StockSerial, error) {
+	itemUUID, err := uuid.Parse(filter.ItemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListStockSerialsByItemIDParams{
+		ItemID: itemUUID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.Status != "" {
+		params.Status = sql.NullString{String: string(filter.Status), Valid: true}
+	}
+
+	rows, err := s.queries.ListStockSerialsByItemID(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials := make([]model.StockSerial, 0, len(rows))
+	for _, row := range rows {
+		serials = append(serials, model.StockSerial{
+			ID:            row.ID,
+			ItemID:        row.ItemID,
+			SerialNumber:  row.SerialNumber,
+			Status:        model.StockSerialStatus(row.Status),
+			LocationID:    row.LocationID,
+			LocationCode:  row.LocationCode,
+			WarehouseID:   row.WarehouseID,
+			WarehouseCode: row.WarehouseCode,
+			OrderID:       convertNullUUIDToPtr(row.OrderID),
+			ReturnID:      convertNullUUIDToPtr(row.ReturnID),
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		})
+	}
+
+	return serials, nil
+}
+
+// LookupSerials finds the units carrying a serial number. Serial numbers are unique per item, so
+// the same number may be found on more than one item.
+func (s *Storage) LookupSerials(ctx context.Context, serialNumber string) ([]model.SerialLookup, error) {
+	rows, err := s.queries.ListStockSerialsBySerialNumber(ctx, strings.TrimSpace(serialNumber))
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials := make([]model.SerialLookup, 0, len(rows))
+	for _, row := range rows {
+		serials = append(serials, model.SerialLookup{
+			StockSerial: model.StockSerial{
+				ID:            row.ID,
+				ItemID:        row.ItemID,
+				SerialNumber:  row.SerialNumber,
+				Status:        model.StockSerialStatus(row.Status),
+				LocationID:    row.LocationID,
+				LocationCode:  row.LocationCode,
+				WarehouseID:   row.WarehouseID,
+				WarehouseCode: row.WarehouseCode,
+				OrderID:       convertNullUUIDToPtr(row.OrderID),
+				ReturnID:      convertNullUUIDToPtr(row.ReturnID),
+				CreatedAt:     row.CreatedAt,
+				UpdatedAt:     row.UpdatedAt,
+			},
+			SKU:  row.Sku,
+			Name: row.Name,
+		})
+	}
+
+	return serials, nil
+}
+
+// checkSerialNumbers makes sure a change of quantity units to a serialized item names exactly one
+// serial number per unit, and that no serial numbers are given for other items. It returns the
+// serial numbers trimmed.
+func checkSerialNumbers(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, quantity int, serialNumbers []string) ([]string, error) {
+	item, err := qtx.GetItemByID(ctx, itemID)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	if !item.Serialized {
+		if len(serialNumbers) > 0 {
+			return nil, errors.ErrBadRequest
+		}
+		return nil, nil
+	}
+
+	if len(serialNumbers) != quantity {
+		return nil, errors.ErrBadRequest
+	}
+
+	seen := make(map[string]bool, len(serialNumbers))
+	trimmed := make([]string, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		serialNumber = strings.TrimSpace(serialNumber)
+		if serialNumber == "" || seen[serialNumber] {
+			return nil, errors.ErrBadRequest
+		}
+		seen[serialNumber] = true
+		trimmed = append(trimmed, serialNumber)
+	}
+
+	return trimmed, nil
+}
+
+// receiveSerials puts units into stock at a location. A serial seen before is brought back into
+// stock; one that is already in stock or in transit is a conflict.
+func receiveSerials(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, serialNumbers []string) error {
+	for _, serialNumber := range serialNumbers {
+		params := db.ReceiveStockSerialParams{
+			ID:           uuid.New(),
+			ItemID:       itemID,
+			SerialNumber: serialNumber,
+			LocationID:   locationID,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if _, err := qtx.ReceiveStockSerial(ctx, params); err != nil {
+			if err == sql.ErrNoRows {
+				return errors.ErrConflict
+			}
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return errors.ErrNotFound
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	return nil
+}
+
+// issueSerials takes units out of stock, marking them sold, returned, in transit or removed
+// according to the reason for the change. It returns the units so the caller can check where they were held.
+func issueSerials(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, serialNumbers []string, source model.StockMovementSource) ([]db.GetStockSerialForUpdateRow, error) {
+	status := model.StockSerialStatusRemoved
+	var orderID, returnID uuid.NullUUID
+	switch source.Reason {
+	case model.StockMovementReasonSale:
+		status = model.StockSerialStatusSold
+		orderID = convertOptionalIDToNullUUID(source.SourceDocumentID)
+	case model.StockMovementReasonReturn:
+		status = model.StockSerialStatusReturned
+		returnID = convertOptionalIDToNullUUID(source.SourceDocumentID)
+	case model.StockMovementReasonTransfer:
+		status = model.StockSerialStatusInTransit
+	}
+
+	serials := make([]db.GetStockSerialForUpdateRow, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		lockParams := db.GetStockSerialForUpdateParams{
+			ItemID:       itemID,
+			SerialNumber: serialNumber,
+		}
+		serial, err := qtx.GetStockSerialForUpdate(ctx, lockParams)
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrBadRequest
+		}
+		if err != nil {
+			return nil, errors.ErrInternalServerError
+		}
+
+		if serial.Status != string(model.StockSerialStatusInStock) {
+			return nil, errors.ErrBadRequest
+		}
+
+		issueParams := db.IssueStockSerialParams{
+			ID:        serial.ID,
+			Status:    string(status),
+			OrderID:   orderID,
+			ReturnID:  returnID,
+			UpdatedAt: time.Now(),
+		}
+		if err := qtx.IssueStockSerial(ctx, issueParams); err != nil {
+			return nil, errors.ErrInternalServerError
+		}
+
+		serials = append(serials, serial)
+	}
+
+	return serials, nil
+}
+
+// issueWarehouseSerials takes units out of a warehouse. The stock of each location the units were
+// held at goes down by the number of units taken from it.
+func issueWarehouseSerials(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, serialNumbers []string, source model.StockMovementSource) error {
+	serials, err := issueSerials(ctx, qtx, itemID, serialNumbers, source)
+	if err != nil {
+		return err
+	}
+
+	locationIDs := make([]uuid.UUID, 0, len(serials))
+	quantities := make(map[uuid.UUID]int, len(serials))
+	for _, serial := range serials {
+		if serial.WarehouseID != warehouseID {
+			return errors.ErrBadRequest
+		}
+		if _, seen := quantities[serial.LocationID]; !seen {
+			locationIDs = append(locationIDs, serial.LocationID)
+		}
+		quantities[serial.LocationID]++
+	}
+
+	for _, locationID := range locationIDs {
+		if err := issueLocationStock(ctx, qtx, itemID, locationID, quantities[locationID], source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
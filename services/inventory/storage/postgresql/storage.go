@@ -8,6 +8,7 @@ import (
 	"microservice-challenge/services/inventory/storage/postgresql/db"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -43,9 +44,35 @@ func convertDBItemToModel(dbItem db.Item) model.Item {
 		item.UnitPrice = unitPrice
 	}
 
+	item.ReorderPoint = int(dbItem.ReorderPoint)
+	item.ReorderQuantity = int(dbItem.ReorderQuantity)
+	item.MinStock = int(dbItem.MinStock)
+	if dbItem.PreferredVendorID.Valid {
+		vendorID := dbItem.PreferredVendorID.UUID
+		item.PreferredVendorID = &vendorID
+	}
+	item.Serialized = dbItem.Serialized
+	item.CostingMethod = model.CostingMethod(dbItem.CostingMethod)
+	item.BaseUnit = dbItem.BaseUnit
+	item.CategoryID = convertNullUUIDToPtr(dbItem.CategoryID)
+	item.ProductID = convertNullUUIDToPtr(dbItem.ProductID)
+	item.VariantOptions = convertDBVariantOptions(dbItem.VariantOptions)
+	if dbItem.ArchivedAt.Valid {
+		archivedAt := dbItem.ArchivedAt.Time
+		item.ArchivedAt = &archivedAt
+	}
+
 	return item
 }
 
+// convertOptionalIDToNullUUID converts an optional ID to uuid.NullUUID
+func convertOptionalIDToNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
 // convertModelItemToCreateParams converts model.Item to sqlc CreateItemParams
 func convertModelItemToCreateParams(item model.Item) db.CreateItemParams {
 	params := db.CreateItemParams{
@@ -53,6 +80,18 @@ func convertModelItemToCreateParams(item model.Item) db.CreateItemParams {
 		Name:      item.Name,
 		Sku:       item.SKU,
 		UnitPrice: strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+
+		ReorderPoint:      int32(item.ReorderPoint),
+		ReorderQuantity:   int32(item.ReorderQuantity),
+		MinStock:          int32(item.MinStock),
+		PreferredVendorID: convertOptionalIDToNullUUID(item.PreferredVendorID),
+		Serialized:        item.Serialized,
+		CostingMethod:     string(item.CostingMethod),
+		BaseUnit:          item.BaseUnit,
+		CategoryID:        convertOptionalIDToNullUUID(item.CategoryID),
+		ProductID:         convertOptionalIDToNullUUID(item.ProductID),
+		VariantOptions:    convertModelVariantOptions(item.VariantOptions),
+
 		CreatedAt: item.CreatedAt,
 		UpdatedAt: item.UpdatedAt,
 	}
@@ -74,6 +113,16 @@ func convertModelItemToUpdateParams(item model.Item) db.UpdateItemParams {
 		Name:      item.Name,
 		Sku:       item.SKU,
 		UnitPrice: strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+
+		ReorderPoint:      int32(item.ReorderPoint),
+		ReorderQuantity:   int32(item.ReorderQuantity),
+		MinStock:          int32(item.MinStock),
+		PreferredVendorID: convertOptionalIDToNullUUID(item.PreferredVendorID),
+		Serialized:        item.Serialized,
+		CostingMethod:     string(item.CostingMethod),
+		BaseUnit:          item.BaseUnit,
+		CategoryID:        convertOptionalIDToNullUUID(item.CategoryID),
+
 		UpdatedAt: item.UpdatedAt,
 	}
 
@@ -87,55 +136,69 @@ func convertModelItemToUpdateParams(item model.Item) db.UpdateItemParams {
 	return params
 }
 
-// convertDBStockToModel converts sqlc generated db.Stock to model.Stock
-func convertDBStockToModel(dbStock db.Stock) model.Stock {
-	return model.Stock{
-		ID:        dbStock.ID,
-		ItemID:    dbStock.ItemID,
-		Quantity:  int(dbStock.Quantity),
-		CreatedAt: dbStock.CreatedAt,
-		UpdatedAt: dbStock.UpdatedAt,
-	}
-}
-
 // convertModelStockToCreateParams converts model.Stock to sqlc CreateStockParams
 func convertModelStockToCreateParams(stock model.Stock) db.CreateStockParams {
 	return db.CreateStockParams{
-		ID:        stock.ID,
-		ItemID:    stock.ItemID,
-		Quantity:  int32(stock.Quantity),
-		CreatedAt: stock.CreatedAt,
-		UpdatedAt: stock.UpdatedAt,
+		ID:         stock.ID,
+		ItemID:     stock.ItemID,
+		LocationID: stock.LocationID,
+		Quantity:   int32(stock.Quantity),
+		CreatedAt:  stock.CreatedAt,
+		UpdatedAt:  stock.UpdatedAt,
 	}
 }
 
-// convertModelStockToUpdateParams converts model.Stock to sqlc UpdateStockParams
-func convertModelStockToUpdateParams(stock model.Stock) db.UpdateStockParams {
-	return db.UpdateStockParams{
-		ItemID:    stock.ItemID,
-		Quantity:  int32(stock.Quantity),
-		UpdatedAt: stock.UpdatedAt,
+func (s *Storage) CreateItem(ctx context.Context, item model.Item) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := createItem(ctx, qtx, item); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
 	}
+
+	return nil
 }
 
-func (s *Storage) CreateItem(ctx context.Context, item model.Item) error {
+// createItem writes a new item with its first price, units and attribute values inside the
+// caller's transaction
+func createItem(ctx context.Context, qtx *db.Queries, item model.Item) error {
 	item.SKU = strings.ToUpper(strings.TrimSpace(item.SKU))
 	item.Name = strings.TrimSpace(item.Name)
 	item.Description = strings.TrimSpace(item.Description)
 
 	params := convertModelItemToCreateParams(item)
-	err := s.queries.CreateItem(ctx, params)
+	err := qtx.CreateItem(ctx, params)
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			if pqErr.Code == "23505" {
 				return errors.ErrConflict
 			}
+			if pqErr.Code == "23503" {
+				return errors.ErrBadRequest
+			}
 		}
 		return errors.ErrInternalServerError
 	}
 
-	return nil
+	if err := recordItemPrice(ctx, qtx, item.ID, item.UnitPrice, item.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := replaceItemUnits(ctx, qtx, item.ID, item.Units); err != nil {
+		return err
+	}
+
+	return replaceItemAttributes(ctx, qtx, item)
 }
 
 func (s *Storage) GetItemByID(ctx context.Context, id string) (model.Item, error) {
@@ -152,7 +215,15 @@ func (s *Storage) GetItemByID(ctx context.Context, id string) (model.Item, error
 		return model.Item{}, errors.ErrInternalServerError
 	}
 
-	return convertDBItemToModel(dbItem), nil
+	items := []model.Item{convertDBItemToModel(dbItem)}
+	if err := loadItemUnits(ctx, s.queries, items); err != nil {
+		return model.Item{}, err
+	}
+	if err := loadItemAttributes(ctx, s.queries, items); err != nil {
+		return model.Item{}, err
+	}
+
+	return items[0], nil
 }
 
 func (s *Storage) GetItemBySKU(ctx context.Context, sku string) (model.Item, error) {
@@ -165,14 +236,30 @@ func (s *Storage) GetItemBySKU(ctx context.Context, sku string) (model.Item, err
 		return model.Item{}, errors.ErrInternalServerError
 	}
 
-	return convertDBItemToModel(dbItem), nil
+	items := []model.Item{convertDBItemToModel(dbItem)}
+	if err := loadItemUnits(ctx, s.queries, items); err != nil {
+		return model.Item{}, err
+	}
+	if err := loadItemAttributes(ctx, s.queries, items); err != nil {
+		return model.Item{}, err
+	}
+
+	return items[0], nil
 }
 
-func (s *Storage) ListItems(ctx context.Context, limit, offset int) ([]model.Item, error) {
+// ListItems lists items, narrowed to a category and its descendants when the filter names one
+func (s *Storage) ListItems(ctx context.Context, filter model.ItemFilter, limit, offset int) ([]model.Item, error) {
 	params := db.ListItemsParams{
 		Limit:  int32(limit),
 		Offset: int32(offset),
 	}
+	if filter.CategoryID != "" {
+		categoryID, err := uuid.Parse(filter.CategoryID)
+		if err != nil {
+			return nil, errors.ErrBadRequest
+		}
+		params.CategoryID = uuid.NullUUID{UUID: categoryID, Valid: true}
+	}
 
 	dbItems, err := s.queries.ListItems(ctx, params)
 	if err != nil {
@@ -184,11 +271,136 @@ func (s *Storage) ListItems(ctx context.Context, limit, offset int) ([]model.Ite
 		items = append(items, convertDBItemToModel(dbItem))
 	}
 
+	if err := loadItemUnits(ctx, s.queries, items); err != nil {
+		return nil, err
+	}
+	if err := loadItemAttributes(ctx, s.queries, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s *Storage) SearchItems(ctx context.Context, filter model.ItemSearchFilter, limit, offset int) ([]model.ItemSearchResult, error) {
+	params := db.SearchItemsParams{
+		Query:  filter.Query,
+		AsOf:   time.Now().UTC(),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.MinPrice != nil {
+		params.MinPrice = sql.NullString{String: strconv.FormatFloat(*filter.MinPrice, 'f', 2, 64), Valid: true}
+	}
+	if filter.MaxPrice != nil {
+		params.MaxPrice = sql.NullString{String: strconv.FormatFloat(*filter.MaxPrice, 'f', 2, 64), Valid: true}
+	}
+	if filter.InStock != nil {
+		params.InStock = sql.NullBool{Bool: *filter.InStock, Valid: true}
+	}
+	if filter.CategoryID != "" {
+		categoryID, err := uuid.Parse(filter.CategoryID)
+		if err != nil {
+			return nil, errors.ErrBadRequest
+		}
+		params.CategoryID = uuid.NullUUID{UUID: categoryID, Valid: true}
+	}
+
+	rows, err := s.queries.SearchItems(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	results := make([]model.ItemSearchResult, 0, len(rows))
+	for _, row := range rows {
+		result := model.ItemSearchResult{
+			ItemID:   row.ID,
+			SKU:      row.Sku,
+			Name:     row.Name,
+			Quantity: int(row.Quantity),
+			Rank:     float64(row.Rank),
+		}
+		if row.Description.Valid {
+			result.Description = row.Description.String
+		}
+		if unitPrice, err := strconv.ParseFloat(row.UnitPrice, 64); err == nil {
+			result.UnitPrice = unitPrice
+		}
+		if row.CategoryID.Valid {
+			categoryID := row.CategoryID.UUID
+			result.CategoryID = &categoryID
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ListItemsForExport lists items in SKU order, starting after afterSKU, with their stock on hand
+func (s *Storage) ListItemsForExport(ctx context.Context, afterSKU string, limit int) ([]model.ItemExportRow, error) {
+	params := db.ListItemsForExportParams{
+		Sku:   afterSKU,
+		Limit: int32(limit),
+	}
+
+	rows, err := s.queries.ListItemsForExport(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	items := make([]model.ItemExportRow, 0, len(rows))
+	for _, row := range rows {
+		item := model.ItemExportRow{
+			ItemID:          row.ID,
+			SKU:             row.Sku,
+			Name:            row.Name,
+			ReorderPoint:    int(row.ReorderPoint),
+			ReorderQuantity: int(row.ReorderQuantity),
+			MinStock:        int(row.MinStock),
+			CostingMethod:   model.CostingMethod(row.CostingMethod),
+			BaseUnit:        row.BaseUnit,
+			Serialized:      row.Serialized,
+			Quantity:        int(row.Quantity),
+		}
+		if row.Description.Valid {
+			item.Description = row.Description.String
+		}
+		if unitPrice, err := strconv.ParseFloat(row.UnitPrice, 64); err == nil {
+			item.UnitPrice = unitPrice
+		}
+		if row.CategoryID.Valid {
+			categoryID := row.CategoryID.UUID
+			item.CategoryID = &categoryID
+		}
+		items = append(items, item)
+	}
+
 	return items, nil
 }
 
 func (s *Storage) UpdateItem(ctx context.Context, item model.Item) error {
-	_, err := s.queries.GetItemByID(ctx, item.ID)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := updateItem(ctx, qtx, item); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// updateItem writes an item's changes inside the caller's transaction, recording a changed price
+// in its price history
+func updateItem(ctx context.Context, qtx *db.Queries, item model.Item) error {
+	existing, err := qtx.GetItemByID(ctx, item.ID)
 	if err == sql.ErrNoRows {
 		return errors.ErrNotFound
 	}
@@ -201,27 +413,124 @@ func (s *Storage) UpdateItem(ctx context.Context, item model.Item) error {
 	item.Description = strings.TrimSpace(item.Description)
 
 	params := convertModelItemToUpdateParams(item)
-	err = s.queries.UpdateItem(ctx, params)
+	err = qtx.UpdateItem(ctx, params)
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			if pqErr.Code == "23505" {
 				return errors.ErrConflict
 			}
+			if pqErr.Code == "23503" {
+				return errors.ErrBadRequest
+			}
 		}
 		return errors.ErrInternalServerError
 	}
 
-	return nil
+	// A price change made on the item takes effect at once
+	if currentPrice, _ := strconv.ParseFloat(existing.UnitPrice, 64); strconv.FormatFloat(currentPrice, 'f', 2, 64) != params.UnitPrice {
+		if err := recordItemPrice(ctx, qtx, item.ID, item.UnitPrice, item.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := replaceItemUnits(ctx, qtx, item.ID, item.Units); err != nil {
+		return err
+	}
+
+	return replaceItemAttributes(ctx, qtx, item)
 }
 
-func (s *Storage) DeleteItem(ctx context.Context, id string) error {
+// ImportItems writes the items of an import in one transaction. New items get a stock row at the
+// warehouse's default location, and their opening stock is received there as an adjustment,
+// valued at the unit cost when one is given. If any item fails nothing is written, and the index
+// of that item is returned with the error; the index is -1 when the failure concerns no item.
+func (s *Storage) ImportItems(ctx context.Context, items []model.ItemImport, warehouseID string, source model.StockMovementSource) (int, error) {
+	warehouseUUID, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return -1, errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	location, err := qtx.GetDefaultLocationByWarehouseID(ctx, warehouseUUID)
+	if err == sql.ErrNoRows {
+		return -1, errors.ErrNotFound
+	}
+	if err != nil {
+		return -1, errors.ErrInternalServerError
+	}
+
+	for i, item := range items {
+		if !item.New {
+			if err := updateItem(ctx, qtx, item.Item); err != nil {
+				return i, err
+			}
+			continue
+		}
+
+		if err := createItem(ctx, qtx, item.Item); err != nil {
+			return i, err
+		}
+
+		stockParams := db.EnsureStockParams{
+			ID:         uuid.New(),
+			ItemID:     item.Item.ID,
+			LocationID: location.ID,
+		}
+		if err := qtx.EnsureStock(ctx, stockParams); err != nil {
+			return i, errors.ErrInternalServerError
+		}
+
+		if item.OpeningStock > 0 {
+			var costs []model.UnitCostQuantity
+			if item.UnitCost != nil {
+				costs = []model.UnitCostQuantity{{Quantity: item.OpeningStock, UnitCost: *item.UnitCost}}
+			}
+			if err := receiveWarehouseLine(ctx, qtx, item.Item.ID, warehouseUUID, item.OpeningStock, nil, nil, costs, source); err != nil {
+				return i, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, errors.ErrInternalServerError
+	}
+
+	return -1, nil
+}
+
+// ArchiveItem archives an item. The checks on its stock and use are repeated inside the
+// transaction: the item's stock rows are locked, then the item itself, which every stock change
+// also locks, so stock received or an assembly order drafted since the caller looked is seen. An
+// item with stock on hand or in transit, used as a component on a bill of materials or included
+// in a draft assembly order is not archived and ErrConflict is returned.
+func (s *Storage) ArchiveItem(ctx context.Context, id string, archivedAt time.Time) error {
 	itemID, err := uuid.Parse(id)
 	if err != nil {
 		return errors.ErrBadRequest
 	}
 
-	_, err = s.queries.GetItemByID(ctx, itemID)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	// Stock rows before the item, in the order stock changes take them
+	if _, err := qtx.ListItemStockForUpdate(ctx, itemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	_, err = qtx.LockItem(ctx, itemID)
 	if err == sql.ErrNoRows {
 		return errors.ErrNotFound
 	}
@@ -229,29 +538,192 @@ func (s *Storage) DeleteItem(ctx context.Context, id string) error {
 		return errors.ErrInternalServerError
 	}
 
-	err = s.queries.DeleteItem(ctx, itemID)
+	stockRows, err := qtx.ListStockByItemID(ctx, itemID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	for _, row := range stockRows {
+		if row.Quantity != 0 {
+			return errors.ErrConflict
+		}
+	}
+
+	inTransit, err := qtx.SumInTransitQuantity(ctx, itemID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if inTransit != 0 {
+		return errors.ErrConflict
+	}
+
+	isComponent, err := qtx.IsBOMComponent(ctx, itemID)
 	if err != nil {
 		return errors.ErrInternalServerError
 	}
+	if isComponent {
+		return errors.ErrConflict
+	}
+
+	assemblyParams := db.ListAssemblyOrdersParams{
+		Status: sql.NullString{String: string(model.AssemblyOrderStatusDraft), Valid: true},
+		ItemID: uuid.NullUUID{UUID: itemID, Valid: true},
+		Limit:  1,
+	}
+	assemblyOrders, err := qtx.ListAssemblyOrders(ctx, assemblyParams)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if len(assemblyOrders) > 0 {
+		return errors.ErrConflict
+	}
+
+	params := db.ArchiveItemParams{
+		ID:         itemID,
+		ArchivedAt: sql.NullTime{Time: archivedAt, Valid: true},
+	}
+	if _, err := qtx.ArchiveItem(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) ListReorderSuggestions(ctx context.Context) ([]model.ReorderSuggestion, error) {
+	rows, err := s.queries.ListItemsBelowReorderPoint(ctx)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	suggestions := make([]model.ReorderSuggestion, 0, len(rows))
+	for _, row := range rows {
+		suggestion := model.ReorderSuggestion{
+			ItemID:          row.ID,
+			SKU:             row.Sku,
+			Name:            row.Name,
+			Quantity:        int(row.Quantity),
+			ReorderPoint:    int(row.ReorderPoint),
+			ReorderQuantity: int(row.ReorderQuantity),
+		}
+		if row.PreferredVendorID.Valid {
+			vendorID := row.PreferredVendorID.UUID
+			suggestion.PreferredVendorID = &vendorID
+		}
+		if row.ReorderRequestedAt.Valid {
+			requestedAt := row.ReorderRequestedAt.Time
+			suggestion.RequestedAt = &requestedAt
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+func (s *Storage) MarkReorderRequested(ctx context.Context, itemID uuid.UUID, requestedAt time.Time) error {
+	params := db.MarkReorderRequestedParams{
+		ID:                 itemID,
+		ReorderRequestedAt: sql.NullTime{Time: requestedAt, Valid: true},
+	}
+
+	if err := s.queries.MarkReorderRequested(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
 
 	return nil
 }
 
-func (s *Storage) GetStockByItemID(ctx context.Context, itemID string) (model.Stock, error) {
+// ClearReorderRequested makes an item eligible for a new reorder request, as when the request
+// did not lead to a purchase order
+func (s *Storage) ClearReorderRequested(ctx context.Context, itemID uuid.UUID) error {
+	if err := s.queries.ClearReorderRequested(ctx, itemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) ResetRecoveredReorderRequests(ctx context.Context) error {
+	if err := s.queries.ResetRecoveredReorderRequests(ctx); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// RaiseStockAlert records a stock alert for an item and reports whether it is new. An alert is
+// not raised again while one is active, except that a low alert escalates to depleted.
+func (s *Storage) RaiseStockAlert(ctx context.Context, itemID uuid.UUID, level model.StockAlertLevel) (bool, error) {
+	params := db.RaiseItemStockAlertParams{
+		ID:         itemID,
+		StockAlert: sql.NullString{String: level.String(), Valid: true},
+	}
+
+	if _, err := s.queries.RaiseItemStockAlert(ctx, params); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+func (s *Storage) ClearStockAlert(ctx context.Context, itemID uuid.UUID) error {
+	if err := s.queries.ClearItemStockAlert(ctx, itemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetStockByItemID(ctx context.Context, itemID string) (model.ItemStock, error) {
 	itemUUID, err := uuid.Parse(itemID)
 	if err != nil {
-		return model.Stock{}, errors.ErrBadRequest
+		return model.ItemStock{}, errors.ErrBadRequest
 	}
 
-	dbStock, err := s.queries.GetStockByItemID(ctx, itemUUID)
-	if err == sql.ErrNoRows {
-		return model.Stock{}, errors.ErrNotFound
+	rows, err := s.queries.ListStockByItemID(ctx, itemUUID)
+	if err != nil {
+		return model.ItemStock{}, errors.ErrInternalServerError
+	}
+
+	inTransit, err := s.queries.SumInTransitQuantity(ctx, itemUUID)
+	if err != nil {
+		return model.ItemStock{}, errors.ErrInternalServerError
 	}
+
+	reservedParams := db.SumReservedQuantityParams{
+		ItemID: itemUUID,
+		AsOf:   time.Now().UTC(),
+	}
+	reserved, err := s.queries.SumReservedQuantity(ctx, reservedParams)
 	if err != nil {
-		return model.Stock{}, errors.ErrInternalServerError
+		return model.ItemStock{}, errors.ErrInternalServerError
+	}
+
+	stock := model.ItemStock{
+		ItemID:    itemUUID,
+		Reserved:  int(reserved),
+		InTransit: int(inTransit),
+		Locations: make([]model.LocationStock, 0, len(rows)),
+	}
+	for _, row := range rows {
+		stock.Quantity += int(row.Quantity)
+		stock.Locations = append(stock.Locations, model.LocationStock{
+			LocationID:    row.LocationID,
+			LocationCode:  row.LocationCode,
+			WarehouseID:   row.WarehouseID,
+			WarehouseCode: row.WarehouseCode,
+			Quantity:      int(row.Quantity),
+			UpdatedAt:     row.UpdatedAt,
+		})
 	}
+	stock.Available = stock.Quantity - stock.Reserved
 
-	return convertDBStockToModel(dbStock), nil
+	return stock, nil
 }
 
 func (s *Storage) CreateStock(ctx context.Context, stock model.Stock) error {
@@ -270,23 +742,107 @@ func (s *Storage) CreateStock(ctx context.Context, stock model.Stock) error {
 	return nil
 }
 
-func (s *Storage) UpdateStock(ctx context.Context, stock model.Stock) error {
-	params := convertModelStockToUpdateParams(stock)
-	err := s.queries.UpdateStock(ctx, params)
+// AdjustStock changes the quantity held at a single location. Increases are booked to the given
+// lot, or left unlotted. Decreases take from the given lot, or without one consume the location's
+// lots in FEFO order before unlotted stock, and may not take stock reserved in the location's
+// warehouse. Serialized items name one serial number per unit, and the units taken out must be
+// held at the location. Every change is valued in the cost ledger.
+func (s *Storage) AdjustStock(ctx context.Context, itemID, locationID string, quantityDelta int, lot *model.LotRef, serialNumbers []string, source model.StockMovementSource) error {
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	locationUUID, err := uuid.Parse(locationID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	if quantityDelta < 0 {
+		location, err := qtx.GetLocationByID(ctx, locationUUID)
+		if err == sql.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+		if err := checkUnreservedStock(ctx, qtx, location.WarehouseID, map[uuid.UUID]int{itemUUID: -quantityDelta}); err != nil {
+			return err
+		}
+	}
+
+	serialNumbers, err = checkSerialNumbers(ctx, qtx, itemUUID, abs(quantityDelta), serialNumbers)
+	if err != nil {
+		return err
+	}
 
+	if quantityDelta > 0 {
+		err = receiveCost(ctx, qtx, itemUUID, quantityDelta, nil, source)
+	} else {
+		_, err = issueCost(ctx, qtx, itemUUID, -quantityDelta, source)
+	}
 	if err != nil {
+		return err
+	}
+
+	if quantityDelta > 0 {
+		if err := receiveSerials(ctx, qtx, itemUUID, locationUUID, serialNumbers); err != nil {
+			return err
+		}
+	} else {
+		serials, err := issueSerials(ctx, qtx, itemUUID, serialNumbers, source)
+		if err != nil {
+			return err
+		}
+		for _, serial := range serials {
+			if serial.LocationID != locationUUID {
+				return errors.ErrBadRequest
+			}
+		}
+	}
+
+	switch {
+	case quantityDelta > 0:
+		err = receiveLocationStock(ctx, qtx, itemUUID, locationUUID, quantityDelta, lot, source)
+	case lot != nil:
+		err = issueLotStock(ctx, qtx, itemUUID, locationUUID, -quantityDelta, lot.LotNumber, source)
+	default:
+		err = issueLocationStock(ctx, qtx, itemUUID, locationUUID, -quantityDelta, source)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return errors.ErrInternalServerError
 	}
 
 	return nil
 }
 
-func (s *Storage) AdjustStock(ctx context.Context, itemID string, quantityDelta int) error {
+// ReceiveWarehouseStock books a receipt to the warehouse's default location. The lots are
+// received under their lot numbers and any quantity they do not cover is received unlotted.
+// Serialized items name one serial number per unit received. Each cost opens a cost layer; any
+// quantity the costs do not cover is valued at the item's moving average cost.
+func (s *Storage) ReceiveWarehouseStock(ctx context.Context, itemID, warehouseID string, quantity int, lots []model.LotQuantity, serialNumbers []string, costs []model.UnitCostQuantity, source model.StockMovementSource) error {
 	itemUUID, err := uuid.Parse(itemID)
 	if err != nil {
 		return errors.ErrBadRequest
 	}
 
+	warehouseUUID, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.ErrInternalServerError
@@ -295,7 +851,103 @@ func (s *Storage) AdjustStock(ctx context.Context, itemID string, quantityDelta
 
 	qtx := s.queries.WithTx(tx)
 
-	currentQuantity, err := qtx.GetStockQuantityForUpdate(ctx, itemUUID)
+	if err := receiveWarehouseLine(ctx, qtx, itemUUID, warehouseUUID, quantity, lots, serialNumbers, costs, source); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// receiveWarehouseLine books a receipt of an item to the warehouse's default location inside the
+// caller's transaction; see ReceiveWarehouseStock
+func receiveWarehouseLine(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, quantity int, lots []model.LotQuantity, serialNumbers []string, costs []model.UnitCostQuantity, source model.StockMovementSource) error {
+	serialNumbers, err := checkSerialNumbers(ctx, qtx, itemID, quantity, serialNumbers)
+	if err != nil {
+		return err
+	}
+
+	if err := receiveCost(ctx, qtx, itemID, quantity, costs, source); err != nil {
+		return err
+	}
+
+	location, err := qtx.GetDefaultLocationByWarehouseID(ctx, warehouseID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := receiveSerials(ctx, qtx, itemID, location.ID, serialNumbers); err != nil {
+		return err
+	}
+
+	unlotted := quantity
+	for _, lot := range lots {
+		if err := receiveLocationStock(ctx, qtx, itemID, location.ID, lot.Quantity, &lot.LotRef, source); err != nil {
+			return err
+		}
+		unlotted -= lot.Quantity
+	}
+	if unlotted < 0 {
+		return errors.ErrBadRequest
+	}
+	if unlotted > 0 {
+		if err := receiveLocationStock(ctx, qtx, itemID, location.ID, unlotted, nil, source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// issueWarehouseLine takes a quantity of an item out of a warehouse inside the caller's
+// transaction. Serialized items take the named units from the locations holding them; other
+// items consume the warehouse's lots in FEFO order, then unlotted stock from the default location
+// first and the other locations in code order.
+func issueWarehouseLine(ctx context.Context, qtx *db.Queries, itemID, warehouseID uuid.UUID, quantity int, serialNumbers []string, source model.StockMovementSource) error {
+	serialNumbers, err := checkSerialNumbers(ctx, qtx, itemID, quantity, serialNumbers)
+	if err != nil {
+		return err
+	}
+
+	if _, err := issueCost(ctx, qtx, itemID, quantity, source); err != nil {
+		return err
+	}
+
+	if len(serialNumbers) > 0 {
+		return issueWarehouseSerials(ctx, qtx, itemID, warehouseID, serialNumbers, source)
+	}
+	return issueWarehouseStock(ctx, qtx, itemID, warehouseID, quantity, source)
+}
+
+// adjustLocationStock applies a delta to one stock row inside the caller's transaction,
+// creating the row when stock is first booked to the location, and records the change in
+// the movement ledger. A change without a lot may not touch stock held in lots.
+func adjustLocationStock(ctx context.Context, qtx *db.Queries, itemID, locationID uuid.UUID, quantityDelta int, lotID *uuid.UUID, source model.StockMovementSource) error {
+	if quantityDelta > 0 {
+		ensureParams := db.EnsureStockParams{
+			ID:         uuid.New(),
+			ItemID:     itemID,
+			LocationID: locationID,
+		}
+		if err := qtx.EnsureStock(ctx, ensureParams); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return errors.ErrNotFound
+			}
+			return errors.ErrInternalServerError
+		}
+	}
+
+	lockParams := db.GetStockQuantityForUpdateParams{
+		ItemID:     itemID,
+		LocationID: locationID,
+	}
+	currentQuantity, err := qtx.GetStockQuantityForUpdate(ctx, lockParams)
 	if err == sql.ErrNoRows {
 		return errors.ErrNotFound
 	}
@@ -308,17 +960,64 @@ func (s *Storage) AdjustStock(ctx context.Context, itemID string, quantityDelta
 		return errors.ErrBadRequest
 	}
 
+	if lotID != nil {
+		lotParams := db.AdjustStockLotParams{
+			ID:       *lotID,
+			Quantity: int32(quantityDelta),
+		}
+		if err := qtx.AdjustStockLot(ctx, lotParams); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23514" {
+				return errors.ErrBadRequest
+			}
+			return errors.ErrInternalServerError
+		}
+	} else if quantityDelta < 0 {
+		sumParams := db.SumLocationLotQuantityParams{
+			ItemID:     itemID,
+			LocationID: locationID,
+		}
+		lotQuantity, err := qtx.SumLocationLotQuantity(ctx, sumParams)
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+		if newQuantity < int(lotQuantity) {
+			return errors.ErrBadRequest
+		}
+	}
+
 	adjustParams := db.AdjustStockParams{
-		ItemID:   itemUUID,
-		Quantity: int32(quantityDelta),
+		ItemID:     itemID,
+		LocationID: locationID,
+		Quantity:   int32(quantityDelta),
 	}
 	if err := qtx.AdjustStock(ctx, adjustParams); err != nil {
 		return errors.ErrInternalServerError
 	}
 
-	if err := tx.Commit(); err != nil {
+	movementParams := db.CreateStockMovementParams{
+		ID:               uuid.New(),
+		ItemID:           itemID,
+		LocationID:       locationID,
+		QuantityDelta:    int32(quantityDelta),
+		BalanceAfter:     int32(newQuantity),
+		Reason:           string(source.Reason),
+		SourceDocumentID: convertOptionalIDToNullUUID(source.SourceDocumentID),
+		CreatedAt:        time.Now(),
+		LotID:            convertOptionalIDToNullUUID(lotID),
+	}
+	if source.UserID != "" {
+		movementParams.UserID = sql.NullString{String: source.UserID, Valid: true}
+	}
+	if err := qtx.CreateStockMovement(ctx, movementParams); err != nil {
 		return errors.ErrInternalServerError
 	}
 
 	return nil
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
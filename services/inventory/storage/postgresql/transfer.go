@@ -0,0 +1,507 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBStockTransferToModel converts sqlc generated db.StockTransfer to model.StockTransfer
+func convertDBStockTransferToModel(dbTransfer db.StockTransfer) model.StockTransfer {
+	transfer := model.StockTransfer{
+		ID:                    dbTransfer.ID,
+		SourceLocationID:      dbTransfer.SourceLocationID,
+		DestinationLocationID: dbTransfer.DestinationLocationID,
+		Status:                model.StockTransferStatus(dbTransfer.Status),
+		ShippedAt:             convertNullTimeToPtr(dbTransfer.ShippedAt),
+		ReceivedAt:            convertNullTimeToPtr(dbTransfer.ReceivedAt),
+		CreatedAt:             dbTransfer.CreatedAt,
+		UpdatedAt:             dbTransfer.UpdatedAt,
+	}
+
+	if dbTransfer.Notes.Valid {
+		transfer.Notes = dbTransfer.Notes.String
+	}
+	if dbTransfer.CreatedBy.Valid {
+		transfer.CreatedBy = dbTransfer.CreatedBy.String
+	}
+
+	return transfer
+}
+
+// CreateStockTransfer saves a draft transfer with its lines. The serial numbers of serialized
+// items are checked against the quantities but the units stay in stock until the transfer ships.
+func (s *Storage) CreateStockTransfer(ctx context.Context, transfer model.StockTransfer, lines []model.StockTransferLine) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.CreateStockTransferParams{
+		ID:                    transfer.ID,
+		SourceLocationID:      transfer.SourceLocationID,
+		DestinationLocationID: transfer.DestinationLocationID,
+		Status:                string(transfer.Status),
+		CreatedAt:             transfer.CreatedAt,
+		UpdatedAt:             transfer.UpdatedAt,
+	}
+	if transfer.Notes != "" {
+		params.Notes = sql.NullString{String: transfer.Notes, Valid: true}
+	}
+	if transfer.CreatedBy != "" {
+		params.CreatedBy = sql.NullString{String: transfer.CreatedBy, Valid: true}
+	}
+	if err := qtx.CreateStockTransfer(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503":
+				return errors.ErrNotFound
+			case "23514":
+				return errors.ErrBadRequest
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	for _, line := range lines {
+		serialNumbers, err := checkSerialNumbers(ctx, qtx, line.ItemID, line.Quantity, line.SerialNumbers)
+		if err != nil {
+			return err
+		}
+
+		lineParams := db.CreateStockTransferLineParams{
+			ID:         line.ID,
+			TransferID: transfer.ID,
+			ItemID:     line.ItemID,
+			Quantity:   int32(line.Quantity),
+			CreatedAt:  transfer.CreatedAt,
+		}
+		if err := qtx.CreateStockTransferLine(ctx, lineParams); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return errors.ErrBadRequest
+			}
+			return errors.ErrInternalServerError
+		}
+
+		for _, serialNumber := range serialNumbers {
+			serialParams := db.CreateStockTransferSerialParams{
+				ID:           uuid.New(),
+				LineID:       line.ID,
+				SerialNumber: serialNumber,
+			}
+			if err := qtx.CreateStockTransferSerial(ctx, serialParams); err != nil {
+				return errors.ErrInternalServerError
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetStockTransferByID(ctx context.Context, id string) (model.StockTransfer, error) {
+	transferID, err := uuid.Parse(id)
+	if err != nil {
+		return model.StockTransfer{}, errors.ErrBadRequest
+	}
+
+	row, err := s.queries.GetStockTransferByID(ctx, transferID)
+	if err == sql.ErrNoRows {
+		return model.StockTransfer{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.StockTransfer{}, errors.ErrInternalServerError
+	}
+
+	transfer := convertDBStockTransferToModel(db.StockTransfer{
+		ID:                    row.ID,
+		SourceLocationID:      row.SourceLocationID,
+		DestinationLocationID: row.DestinationLocationID,
+		Status:                row.Status,
+		Notes:                 row.Notes,
+		CreatedBy:             row.CreatedBy,
+		ShippedAt:             row.ShippedAt,
+		ReceivedAt:            row.ReceivedAt,
+		CreatedAt:             row.CreatedAt,
+		UpdatedAt:             row.UpdatedAt,
+	})
+	transfer.SourceLocationCode = row.SourceLocationCode
+	transfer.SourceWarehouseID = row.SourceWarehouseID
+	transfer.DestinationLocationCode = row.DestinationLocationCode
+	transfer.DestinationWarehouseID = row.DestinationWarehouseID
+
+	return transfer, nil
+}
+
+func (s *Storage) ListStockTransfers(ctx context.Context, filter model.StockTransferFilter, limit, offset int) ([]model.StockTransfer, error) {
+	params := db.ListStockTransfersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if filter.Status != "" {
+		params.Status = sql.NullString{String: string(filter.Status), Valid: true}
+	}
+
+	rows, err := s.queries.ListStockTransfers(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	transfers := make([]model.StockTransfer, 0, len(rows))
+	for _, row := range rows {
+		transfer := convertDBStockTransferToModel(db.StockTransfer{
+			ID:                    row.ID,
+			SourceLocationID:      row.SourceLocationID,
+			DestinationLocationID: row.DestinationLocationID,
+			Status:                row.Status,
+			Notes:                 row.Notes,
+			CreatedBy:             row.CreatedBy,
+			ShippedAt:             row.ShippedAt,
+			ReceivedAt:            row.ReceivedAt,
+			CreatedAt:             row.CreatedAt,
+			UpdatedAt:             row.UpdatedAt,
+		})
+		transfer.SourceLocationCode = row.SourceLocationCode
+		transfer.SourceWarehouseID = row.SourceWarehouseID
+		transfer.DestinationLocationCode = row.DestinationLocationCode
+		transfer.DestinationWarehouseID = row.DestinationWarehouseID
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, nil
+}
+
+func (s *Storage) ListStockTransferLines(ctx context.Context, transferID string) ([]model.StockTransferLine, error) {
+	transferUUID, err := uuid.Parse(transferID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	return listStockTransferLines(ctx, s.queries, transferUUID)
+}
+
+// ShipStockTransfer takes the stock of a draft transfer out of the source location and puts the
+// transfer in transit. The lots the stock came from are recorded on the lines, and the units of
+// serialized items are marked in transit. Stock reserved in the source warehouse cannot be
+// shipped.
+func (s *Storage) ShipStockTransfer(ctx context.Context, transferID string, userID string) error {
+	transferUUID, err := uuid.Parse(transferID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	transfer, err := lockStockTransfer(ctx, qtx, transferUUID, model.StockTransferStatusDraft)
+	if err != nil {
+		return err
+	}
+
+	lines, err := listStockTransferLines(ctx, qtx, transferUUID)
+	if err != nil {
+		return err
+	}
+
+	sourceLocation, err := qtx.GetLocationByID(ctx, transfer.SourceLocationID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	quantities := make(map[uuid.UUID]int, len(lines))
+	for _, line := range lines {
+		quantities[line.ItemID] += line.Quantity
+	}
+	if err := checkUnreservedStock(ctx, qtx, sourceLocation.WarehouseID, quantities); err != nil {
+		return err
+	}
+
+	source := model.StockMovementSource{
+		Reason:           model.StockMovementReasonTransfer,
+		SourceDocumentID: &transferUUID,
+		UserID:           userID,
+	}
+
+	for _, line := range lines {
+		if len(line.SerialNumbers) > 0 {
+			serials, err := issueSerials(ctx, qtx, line.ItemID, line.SerialNumbers, source)
+			if err != nil {
+				return err
+			}
+			for _, serial := range serials {
+				if serial.LocationID != transfer.SourceLocationID {
+					return errors.ErrBadRequest
+				}
+			}
+		}
+
+		lots, err := issueLocationLots(ctx, qtx, line.ItemID, transfer.SourceLocationID, line.Quantity, source)
+		if err != nil {
+			return err
+		}
+
+		for _, lot := range lots {
+			lotParams := db.CreateStockTransferLotParams{
+				ID:         uuid.New(),
+				LineID:     line.ID,
+				LotNumber:  lot.LotNumber,
+				ExpiryDate: convertOptionalTimeToNullTime(lot.ExpiryDate),
+				Quantity:   int32(lot.Quantity),
+			}
+			if err := qtx.CreateStockTransferLot(ctx, lotParams); err != nil {
+				return errors.ErrInternalServerError
+			}
+		}
+	}
+
+	statusParams := db.UpdateStockTransferStatusParams{
+		ID:        transferUUID,
+		Status:    string(model.StockTransferStatusInTransit),
+		ShippedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt: time.Now(),
+	}
+	if err := qtx.UpdateStockTransferStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ReceiveStockTransfer books the stock of a transfer in transit into the destination location
+// under the lots it was shipped from, and closes the transfer
+func (s *Storage) ReceiveStockTransfer(ctx context.Context, transferID string, userID string) error {
+	transferUUID, err := uuid.Parse(transferID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	transfer, err := lockStockTransfer(ctx, qtx, transferUUID, model.StockTransferStatusInTransit)
+	if err != nil {
+		return err
+	}
+
+	source := model.StockMovementSource{
+		Reason:           model.StockMovementReasonTransfer,
+		SourceDocumentID: &transferUUID,
+		UserID:           userID,
+	}
+	if err := arriveStockTransfer(ctx, qtx, transferUUID, transfer.DestinationLocationID, source); err != nil {
+		return err
+	}
+
+	statusParams := db.UpdateStockTransferStatusParams{
+		ID:         transferUUID,
+		Status:     string(model.StockTransferStatusReceived),
+		ShippedAt:  transfer.ShippedAt,
+		ReceivedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt:  time.Now(),
+	}
+	if err := qtx.UpdateStockTransferStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// CancelStockTransfer cancels a transfer that has not been received. Stock already shipped is
+// booked back into the source location.
+func (s *Storage) CancelStockTransfer(ctx context.Context, transferID string, userID string) error {
+	transferUUID, err := uuid.Parse(transferID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	transfer, err := lockStockTransfer(ctx, qtx, transferUUID, model.StockTransferStatusDraft, model.StockTransferStatusInTransit)
+	if err != nil {
+		return err
+	}
+
+	if transfer.Status == string(model.StockTransferStatusInTransit) {
+		source := model.StockMovementSource{
+			Reason:           model.StockMovementReasonTransfer,
+			SourceDocumentID: &transferUUID,
+			UserID:           userID,
+		}
+		if err := arriveStockTransfer(ctx, qtx, transferUUID, transfer.SourceLocationID, source); err != nil {
+			return err
+		}
+	}
+
+	statusParams := db.UpdateStockTransferStatusParams{
+		ID:        transferUUID,
+		Status:    string(model.StockTransferStatusCancelled),
+		ShippedAt: transfer.ShippedAt,
+		UpdatedAt: time.Now(),
+	}
+	if err := qtx.UpdateStockTransferStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// lockStockTransfer locks a transfer for the rest of the transaction, making sure it is in one of
+// the given statuses
+func lockStockTransfer(ctx context.Context, qtx *db.Queries, transferID uuid.UUID, statuses ...model.StockTransferStatus) (db.StockTransfer, error) {
+	transfer, err := qtx.GetStockTransferForUpdate(ctx, transferID)
+	if err == sql.ErrNoRows {
+		return db.StockTransfer{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return db.StockTransfer{}, errors.ErrInternalServerError
+	}
+
+	for _, status := range statuses {
+		if transfer.Status == string(status) {
+			return transfer, nil
+		}
+	}
+
+	return db.StockTransfer{}, errors.ErrBadRequest
+}
+
+// listStockTransferLines lists the lines of a transfer with the lots they were shipped from and
+// the serial numbers of the units they move
+func listStockTransferLines(ctx context.Context, qtx *db.Queries, transferID uuid.UUID) ([]model.StockTransferLine, error) {
+	rows, err := qtx.ListStockTransferLines(ctx, transferID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lots, err := qtx.ListStockTransferLotsByTransferID(ctx, transferID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials, err := qtx.ListStockTransferSerialsByTransferID(ctx, transferID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lineLots := make(map[uuid.UUID][]model.StockTransferLot)
+	for _, lot := range lots {
+		lineLots[lot.LineID] = append(lineLots[lot.LineID], model.StockTransferLot{
+			LotNumber:  lot.LotNumber,
+			ExpiryDate: convertNullTimeToPtr(lot.ExpiryDate),
+			Quantity:   int(lot.Quantity),
+		})
+	}
+
+	lineSerials := make(map[uuid.UUID][]string)
+	for _, serial := range serials {
+		lineSerials[serial.LineID] = append(lineSerials[serial.LineID], serial.SerialNumber)
+	}
+
+	lines := make([]model.StockTransferLine, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, model.StockTransferLine{
+			ID:            row.ID,
+			ItemID:        row.ItemID,
+			SKU:           row.Sku,
+			Name:          row.Name,
+			Quantity:      int(row.Quantity),
+			Lots:          lineLots[row.ID],
+			SerialNumbers: lineSerials[row.ID],
+		})
+	}
+
+	return lines, nil
+}
+
+// arriveStockTransfer books the stock of a shipped transfer into a location under the lots it was
+// shipped from, and puts its units back in stock there
+func arriveStockTransfer(ctx context.Context, qtx *db.Queries, transferID, locationID uuid.UUID, source model.StockMovementSource) error {
+	lines, err := listStockTransferLines(ctx, qtx, transferID)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		for _, serialNumber := range line.SerialNumbers {
+			lockParams := db.GetStockSerialForUpdateParams{
+				ItemID:       line.ItemID,
+				SerialNumber: serialNumber,
+			}
+			serial, err := qtx.GetStockSerialForUpdate(ctx, lockParams)
+			if err != nil {
+				return errors.ErrInternalServerError
+			}
+			if serial.Status != string(model.StockSerialStatusInTransit) {
+				return errors.ErrConflict
+			}
+
+			moveParams := db.MoveStockSerialParams{
+				ID:         serial.ID,
+				Status:     string(model.StockSerialStatusInStock),
+				LocationID: locationID,
+				UpdatedAt:  time.Now(),
+			}
+			if err := qtx.MoveStockSerial(ctx, moveParams); err != nil {
+				return errors.ErrInternalServerError
+			}
+		}
+
+		unlotted := line.Quantity
+		for _, lot := range line.Lots {
+			lotRef := &model.LotRef{
+				LotNumber:  lot.LotNumber,
+				ExpiryDate: lot.ExpiryDate,
+			}
+			if err := receiveLocationStock(ctx, qtx, line.ItemID, locationID, lot.Quantity, lotRef, source); err != nil {
+				return err
+			}
+			unlotted -= lot.Quantity
+		}
+		if unlotted > 0 {
+			if err := receiveLocationStock(ctx, qtx, line.ItemID, locationID, unlotted, nil, source); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// loadItemUnits fills in the units of measure of the items given
+func loadItemUnits(ctx context.Context, qtx *db.Queries, items []model.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	rows, err := qtx.ListItemUnitsByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	units := make(map[uuid.UUID][]model.ItemUnit, len(items))
+	for _, row := range rows {
+		units[row.ItemID] = append(units[row.ItemID], model.ItemUnit{
+			Unit:             row.Unit,
+			ConversionFactor: int(row.ConversionFactor),
+		})
+	}
+
+	for i := range items {
+		items[i].Units = units[items[i].ID]
+	}
+
+	return nil
+}
+
+// replaceItemUnits replaces the units of measure of an item
+func replaceItemUnits(ctx context.Context, qtx *db.Queries, itemID uuid.UUID, units []model.ItemUnit) error {
+	if err := qtx.DeleteItemUnitsByItemID(ctx, itemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	for _, unit := range units {
+		err := qtx.CreateItemUnit(ctx, db.CreateItemUnitParams{
+			ID:               uuid.New(),
+			ItemID:           itemID,
+			Unit:             strings.TrimSpace(unit.Unit),
+			ConversionFactor: int32(unit.ConversionFactor),
+		})
+		if err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/inventory/storage/postgresql/db"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBWarehouseToModel converts sqlc generated db.Warehouse to model.Warehouse
+func convertDBWarehouseToModel(dbWarehouse db.Warehouse) model.Warehouse {
+	return model.Warehouse{
+		ID:        dbWarehouse.ID,
+		Code:      dbWarehouse.Code,
+		Name:      dbWarehouse.Name,
+		CreatedAt: dbWarehouse.CreatedAt,
+		UpdatedAt: dbWarehouse.UpdatedAt,
+	}
+}
+
+// convertDBLocationToModel converts sqlc generated db.Location to model.Location
+func convertDBLocationToModel(dbLocation db.Location) model.Location {
+	return model.Location{
+		ID:          dbLocation.ID,
+		WarehouseID: dbLocation.WarehouseID,
+		Code:        dbLocation.Code,
+		Name:        dbLocation.Name,
+		IsDefault:   dbLocation.IsDefault,
+		CreatedAt:   dbLocation.CreatedAt,
+		UpdatedAt:   dbLocation.UpdatedAt,
+	}
+}
+
+// convertModelLocationToCreateParams converts model.Location to sqlc CreateLocationParams
+func convertModelLocationToCreateParams(location model.Location) db.CreateLocationParams {
+	return db.CreateLocationParams{
+		ID:          location.ID,
+		WarehouseID: location.WarehouseID,
+		Code:        strings.ToUpper(strings.TrimSpace(location.Code)),
+		Name:        strings.TrimSpace(location.Name),
+		IsDefault:   location.IsDefault,
+		CreatedAt:   location.CreatedAt,
+		UpdatedAt:   location.UpdatedAt,
+	}
+}
+
+// CreateWarehouse stores a warehouse together with its default location
+func (s *Storage) CreateWarehouse(ctx context.Context, warehouse model.Warehouse, defaultLocation model.Location) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	params := db.CreateWarehouseParams{
+		ID:        warehouse.ID,
+		Code:      strings.ToUpper(strings.TrimSpace(warehouse.Code)),
+		Name:      strings.TrimSpace(warehouse.Name),
+		CreatedAt: warehouse.CreatedAt,
+		UpdatedAt: warehouse.UpdatedAt,
+	}
+	if err := qtx.CreateWarehouse(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+
+	if err := qtx.CreateLocation(ctx, convertModelLocationToCreateParams(defaultLocation)); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetWarehouseByID(ctx context.Context, id string) (model.Warehouse, error) {
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return model.Warehouse{}, errors.ErrBadRequest
+	}
+
+	dbWarehouse, err := s.queries.GetWarehouseByID(ctx, warehouseID)
+	if err == sql.ErrNoRows {
+		return model.Warehouse{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.Warehouse{}, errors.ErrInternalServerError
+	}
+
+	return convertDBWarehouseToModel(dbWarehouse), nil
+}
+
+func (s *Storage) ListWarehouses(ctx context.Context, limit, offset int) ([]model.Warehouse, error) {
+	params := db.ListWarehousesParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	dbWarehouses, err := s.queries.ListWarehouses(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	warehouses := make([]model.Warehouse, 0, len(dbWarehouses))
+	for _, dbWarehouse := range dbWarehouses {
+		warehouses = append(warehouses, convertDBWarehouseToModel(dbWarehouse))
+	}
+
+	return warehouses, nil
+}
+
+func (s *Storage) UpdateWarehouse(ctx context.Context, warehouse model.Warehouse) error {
+	params := db.UpdateWarehouseParams{
+		ID:        warehouse.ID,
+		Code:      strings.ToUpper(strings.TrimSpace(warehouse.Code)),
+		Name:      strings.TrimSpace(warehouse.Name),
+		UpdatedAt: warehouse.UpdatedAt,
+	}
+
+	if err := s.queries.UpdateWarehouse(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateLocation(ctx context.Context, location model.Location) error {
+	if err := s.queries.CreateLocation(ctx, convertModelLocationToCreateParams(location)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				return errors.ErrConflict
+			case "23503":
+				return errors.ErrNotFound
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetLocationByID(ctx context.Context, id string) (model.Location, error) {
+	locationID, err := uuid.Parse(id)
+	if err != nil {
+		return model.Location{}, errors.ErrBadRequest
+	}
+
+	dbLocation, err := s.queries.GetLocationByID(ctx, locationID)
+	if err == sql.ErrNoRows {
+		return model.Location{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.Location{}, errors.ErrInternalServerError
+	}
+
+	return convertDBLocationToModel(dbLocation), nil
+}
+
+func (s *Storage) GetDefaultLocation(ctx context.Context, warehouseID string) (model.Location, error) {
+	warehouseUUID, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return model.Location{}, errors.ErrBadRequest
+	}
+
+	dbLocation, err := s.queries.GetDefaultLocationByWarehouseID(ctx, warehouseUUID)
+	if err == sql.ErrNoRows {
+		return model.Location{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.Location{}, errors.ErrInternalServerError
+	}
+
+	return convertDBLocationToModel(dbLocation), nil
+}
+
+func (s *Storage) ListLocationsByWarehouseID(ctx context.Context, warehouseID string) ([]model.Location, error) {
+	warehouseUUID, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbLocations, err := s.queries.ListLocationsByWarehouseID(ctx, warehouseUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	locations := make([]model.Location, 0, len(dbLocations))
+	for _, dbLocation := range dbLocations {
+		locations = append(locations, convertDBLocationToModel(dbLocation))
+	}
+
+	return locations, nil
+}
+
+func (s *Storage) UpdateLocation(ctx context.Context, location model.Location) error {
+	params := db.UpdateLocationParams{
+		ID:        location.ID,
+		Code:      strings.ToUpper(strings.TrimSpace(location.Code)),
+		Name:      strings.TrimSpace(location.Name),
+		UpdatedAt: location.UpdatedAt,
+	}
+
+	if err := s.queries.UpdateLocation(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrConflict
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -3,18 +3,114 @@ package storage
 import (
 	"context"
 	"microservice-challenge/services/inventory/model"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Storage interface {
 	CreateItem(ctx context.Context, item model.Item) error
 	GetItemByID(ctx context.Context, id string) (model.Item, error)
 	GetItemBySKU(ctx context.Context, sku string) (model.Item, error)
-	ListItems(ctx context.Context, limit, offset int) ([]model.Item, error)
+	ListItems(ctx context.Context, filter model.ItemFilter, limit, offset int) ([]model.Item, error)
+	SearchItems(ctx context.Context, filter model.ItemSearchFilter, limit, offset int) ([]model.ItemSearchResult, error)
+	ListItemsForExport(ctx context.Context, afterSKU string, limit int) ([]model.ItemExportRow, error)
 	UpdateItem(ctx context.Context, item model.Item) error
-	DeleteItem(ctx context.Context, id string) error
+	ImportItems(ctx context.Context, items []model.ItemImport, warehouseID string, source model.StockMovementSource) (int, error)
+	ArchiveItem(ctx context.Context, id string, archivedAt time.Time) error
+
+	CreateCategory(ctx context.Context, category model.Category, attributes []model.CategoryAttribute) error
+	GetCategoryByID(ctx context.Context, id string) (model.Category, error)
+	ListCategories(ctx context.Context, filter model.CategoryFilter, limit, offset int) ([]model.Category, error)
+	UpdateCategory(ctx context.Context, category model.Category, attributes []model.CategoryAttribute) error
+	DeleteCategory(ctx context.Context, id string) error
+	ListCategoryAttributes(ctx context.Context, categoryID string) ([]model.CategoryAttribute, error)
+
+	CreateProduct(ctx context.Context, product model.Product, variants []model.Item) error
+	GetProductByID(ctx context.Context, id string) (model.Product, error)
+	ListProducts(ctx context.Context, limit, offset int) ([]model.Product, error)
+	UpdateProduct(ctx context.Context, product model.Product, variants []model.Item) error
+	ListProductVariants(ctx context.Context, productID string) ([]model.ProductVariant, error)
+
+	CreateItemBarcode(ctx context.Context, barcode model.ItemBarcode) error
+	GetItemBarcodeByCode(ctx context.Context, code string) (model.ItemBarcode, error)
+	ListItemBarcodes(ctx context.Context, itemID string) ([]model.ItemBarcode, error)
+	DeleteItemBarcode(ctx context.Context, id uuid.UUID) error
+	NextInternalBarcodeNumber(ctx context.Context) (int64, error)
+
+	CreateItemPrice(ctx context.Context, price model.ItemPrice) error
+	GetItemPriceByID(ctx context.Context, id string) (model.ItemPrice, error)
+	GetItemPriceAt(ctx context.Context, itemID string, at time.Time) (model.ItemPrice, error)
+	ListItemPrices(ctx context.Context, itemID string, limit, offset int) ([]model.ItemPrice, error)
+	DeleteItemPrice(ctx context.Context, id uuid.UUID) error
+	ApplyDueItemPrices(ctx context.Context, asOf time.Time) ([]model.ItemPrice, error)
+
+	ReplaceStockReservations(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID, reservations []model.StockReservation) error
+	ListStockReservationsBySource(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID, asOf time.Time) ([]model.StockReservation, error)
+	ListStockReservationsByItemID(ctx context.Context, itemID string, asOf time.Time, limit, offset int) ([]model.StockReservation, error)
+	ReleaseStockReservations(ctx context.Context, sourceType model.ReservationSourceType, sourceID uuid.UUID) (int64, error)
+	DeleteExpiredStockReservations(ctx context.Context, asOf time.Time) (int64, error)
+
+	GetBillOfMaterials(ctx context.Context, itemID string) (model.BillOfMaterials, error)
+	SaveBillOfMaterials(ctx context.Context, bom model.BillOfMaterials) error
+	DeleteBillOfMaterials(ctx context.Context, itemID string) error
+
+	CreateAssemblyOrder(ctx context.Context, order model.AssemblyOrder, lines []model.AssemblyOrderLine) error
+	GetAssemblyOrderByID(ctx context.Context, id string) (model.AssemblyOrder, error)
+	ListAssemblyOrders(ctx context.Context, filter model.AssemblyOrderFilter, limit, offset int) ([]model.AssemblyOrder, error)
+	ListAssemblyOrderLines(ctx context.Context, orderID string) ([]model.AssemblyOrderLine, error)
+	CompleteAssemblyOrder(ctx context.Context, orderID string, userID string) error
+	CancelAssemblyOrder(ctx context.Context, orderID string) error
+
+	ListReorderSuggestions(ctx context.Context) ([]model.ReorderSuggestion, error)
+	MarkReorderRequested(ctx context.Context, itemID uuid.UUID, requestedAt time.Time) error
+	ClearReorderRequested(ctx context.Context, itemID uuid.UUID) error
+	ResetRecoveredReorderRequests(ctx context.Context) error
 
-	GetStockByItemID(ctx context.Context, itemID string) (model.Stock, error)
+	RaiseStockAlert(ctx context.Context, itemID uuid.UUID, level model.StockAlertLevel) (bool, error)
+	ClearStockAlert(ctx context.Context, itemID uuid.UUID) error
+
+	GetStockByItemID(ctx context.Context, itemID string) (model.ItemStock, error)
 	CreateStock(ctx context.Context, stock model.Stock) error
-	UpdateStock(ctx context.Context, stock model.Stock) error
-	AdjustStock(ctx context.Context, itemID string, quantityDelta int) error
+	AdjustStock(ctx context.Context, itemID, locationID string, quantityDelta int, lot *model.LotRef, serialNumbers []string, source model.StockMovementSource) error
+	ReceiveWarehouseStock(ctx context.Context, itemID, warehouseID string, quantity int, lots []model.LotQuantity, serialNumbers []string, costs []model.UnitCostQuantity, source model.StockMovementSource) error
+	ApplyStockEventLines(ctx context.Context, warehouseID string, lines []model.StockEventLine, source model.StockMovementSource) ([]model.StockEventLine, []model.StockLineFailure, error)
+
+	ListStockLotsByItemID(ctx context.Context, itemID string) ([]model.StockLot, error)
+	ListExpiringLots(ctx context.Context, expiresOnOrBefore time.Time) ([]model.ExpiringLot, error)
+
+	ListStockSerials(ctx context.Context, filter model.StockSerialFilter, limit, offset int) ([]model.StockSerial, error)
+	LookupSerials(ctx context.Context, serialNumber string) ([]model.SerialLookup, error)
+
+	ListStockMovements(ctx context.Context, filter model.StockMovementFilter, limit, offset int) ([]model.StockMovement, error)
+
+	CreateStockCount(ctx context.Context, count model.StockCount, itemIDs []uuid.UUID) error
+	GetStockCountByID(ctx context.Context, id string) (model.StockCount, error)
+	ListStockCounts(ctx context.Context, filter model.StockCountFilter, limit, offset int) ([]model.StockCount, error)
+	ListStockCountLines(ctx context.Context, countID string) ([]model.StockCountLine, error)
+	RecordStockCounts(ctx context.Context, countID string, entries []model.StockCountEntry, countedBy string) error
+	PostStockCount(ctx context.Context, countID string, postedBy string) error
+	CancelStockCount(ctx context.Context, countID string) error
+
+	CreateStockTransfer(ctx context.Context, transfer model.StockTransfer, lines []model.StockTransferLine) error
+	GetStockTransferByID(ctx context.Context, id string) (model.StockTransfer, error)
+	ListStockTransfers(ctx context.Context, filter model.StockTransferFilter, limit, offset int) ([]model.StockTransfer, error)
+	ListStockTransferLines(ctx context.Context, transferID string) ([]model.StockTransferLine, error)
+	ShipStockTransfer(ctx context.Context, transferID string, userID string) error
+	ReceiveStockTransfer(ctx context.Context, transferID string, userID string) error
+	CancelStockTransfer(ctx context.Context, transferID string, userID string) error
+
+	ListItemValuations(ctx context.Context, before time.Time) ([]model.ItemValuation, error)
+	ListOrderCostOfGoodsSold(ctx context.Context, orderID string) ([]model.ItemCostOfGoodsSold, error)
+
+	CreateWarehouse(ctx context.Context, warehouse model.Warehouse, defaultLocation model.Location) error
+	GetWarehouseByID(ctx context.Context, id string) (model.Warehouse, error)
+	ListWarehouses(ctx context.Context, limit, offset int) ([]model.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, warehouse model.Warehouse) error
+
+	CreateLocation(ctx context.Context, location model.Location) error
+	GetLocationByID(ctx context.Context, id string) (model.Location, error)
+	GetDefaultLocation(ctx context.Context, warehouseID string) (model.Location, error)
+	ListLocationsByWarehouseID(ctx context.Context, warehouseID string) ([]model.Location, error)
+	UpdateLocation(ctx context.Context, location model.Location) error
 }
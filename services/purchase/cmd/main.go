@@ -33,6 +33,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -115,7 +116,35 @@ func main() {
 
 	storage := postgresql.NewStorage(db)
 
-	service := purchaseservice.NewService(storage, natsClient, contactClient, inventoryClient, authClient, logger)
+	defaultWarehouseID := uuid.Nil
+	if value := os.Getenv("DEFAULT_WAREHOUSE_ID"); value != "" {
+		warehouseID, err := uuid.Parse(value)
+		if err != nil {
+			logger.Fatal(ctx, "invalid DEFAULT_WAREHOUSE_ID", zap.String("value", value))
+		}
+		defaultWarehouseID = warehouseID
+	}
+
+	service := purchaseservice.NewService(storage, natsClient, contactClient, inventoryClient, authClient, defaultWarehouseID, logger)
+
+	if err := service.StartEventSubscriptions(ctx); err != nil {
+		logger.Fatal(ctx, "failed to start NATS subscriptions", zap.Error(err))
+	}
+
+	logger.Info(ctx, "NATS event subscriptions started")
+
+	overdueCheckInterval := time.Hour
+	if value := os.Getenv("OVERDUE_CHECK_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Fatal(ctx, "invalid OVERDUE_CHECK_INTERVAL", zap.String("value", value))
+		}
+		overdueCheckInterval = interval
+	}
+
+	go service.StartOverdueMonitor(ctx, overdueCheckInterval)
+
+	logger.Info(ctx, "overdue order monitor started", zap.Duration("interval", overdueCheckInterval))
 
 	handler := httphandler.NewHandler(service, logger)
 	r := router.NewRouter(handler, logger, cfg.JWT.Secret, db)
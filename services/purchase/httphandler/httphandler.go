@@ -11,6 +11,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -51,7 +52,15 @@ func (h *Handler) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 	limit, offset := pagination.GetLimitOffset(r)
 
-	orders, err := h.service.ListOrders(ctx, limit, offset)
+	var orders []model.PurchaseOrder
+	var err error
+	if r.URL.Query().Get("overdue") == "true" {
+		orders, err = h.service.ListOverdueOrders(ctx, limit, offset)
+	} else if itemID := r.URL.Query().Get("open_for_item"); itemID != "" {
+		orders, err = h.service.ListOpenOrdersByItemID(ctx, itemID, limit, offset)
+	} else {
+		orders, err = h.service.ListOrders(ctx, limit, offset)
+	}
 	if err != nil {
 		h.logger.Error(ctx, "failed to list orders", zap.Error(err))
 		response.SendErrorResponse(w, err)
@@ -116,7 +125,15 @@ func (h *Handler) ReceiveOrder(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id := chi.URLParam(r, "id")
 
-	order, err := h.service.ReceiveOrder(ctx, id)
+	// The body is optional: a receipt without landed-cost charges needs no payload
+	var req model.ReceivePurchaseOrderRequest
+	if r.ContentLength != 0 {
+		if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+			return
+		}
+	}
+
+	order, err := h.service.ReceiveOrder(ctx, id, req)
 	if err != nil {
 		h.logger.Error(ctx, "failed to receive order", zap.Error(err))
 		response.SendErrorResponse(w, err)
@@ -139,3 +156,256 @@ func (h *Handler) PayOrder(w http.ResponseWriter, r *http.Request) {
 
 	response.SendSuccessResponse(w, http.StatusOK, "Purchase order paid successfully", order, nil)
 }
+
+// parseUUIDQueryParam parses an optional UUID query parameter, returning nil when it is absent
+func parseUUIDQueryParam(r *http.Request, name string) (*uuid.UUID, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	return &id, nil
+}
+
+func (h *Handler) ListCatalogItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	vendorID, err := parseUUIDQueryParam(r, "vendor_id")
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+	itemID, err := parseUUIDQueryParam(r, "item_id")
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	filter := model.VendorCatalogFilter{
+		VendorID: vendorID,
+		ItemID:   itemID,
+	}
+
+	items, err := h.service.ListCatalogItems(ctx, filter, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list catalog items", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor catalog items retrieved successfully", items, nil)
+}
+
+func (h *Handler) GetCatalogItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	item, err := h.service.GetCatalogItemByID(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get catalog item", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor catalog item retrieved successfully", item, nil)
+}
+
+func (h *Handler) CreateCatalogItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req model.CreateVendorCatalogItemRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	item, err := h.service.CreateCatalogItem(ctx, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create catalog item", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Vendor catalog item created successfully", item, nil)
+}
+
+func (h *Handler) UpdateCatalogItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateVendorCatalogItemRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	item, err := h.service.UpdateCatalogItem(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to update catalog item", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor catalog item updated successfully", item, nil)
+}
+
+func (h *Handler) DeleteCatalogItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	if err := h.service.DeleteCatalogItem(ctx, id); err != nil {
+		h.logger.Error(ctx, "failed to delete catalog item", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor catalog item deleted successfully", nil, nil)
+}
+
+func (h *Handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	order, err := h.service.CancelOrder(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to cancel order", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Purchase order cancelled successfully", order, nil)
+}
+
+func (h *Handler) CreateReturn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.CreatePurchaseReturnRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	ret, err := h.service.CreateReturn(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to create purchase return", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Purchase return created successfully", ret, nil)
+}
+
+func (h *Handler) ListReturns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	returns, err := h.service.ListReturns(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list purchase returns", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Purchase returns retrieved successfully", returns, nil)
+}
+
+func (h *Handler) ListDebitNotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, offset := pagination.GetLimitOffset(r)
+
+	vendorID, err := parseUUIDQueryParam(r, "vendor_id")
+	if err != nil {
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	notes, err := h.service.ListDebitNotes(ctx, vendorID, limit, offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list debit notes", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Debit notes retrieved successfully", notes, nil)
+}
+
+func (h *Handler) GetVendorBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vendorID := chi.URLParam(r, "vendor_id")
+
+	balance, err := h.service.GetVendorBalance(ctx, vendorID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get vendor balance", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor balance retrieved successfully", balance, nil)
+}
+
+func (h *Handler) AddOrderCharge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	var req model.CreateLandedCostChargeRequest
+	if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+		return
+	}
+
+	charge, err := h.service.AddOrderCharge(ctx, id, req)
+	if err != nil {
+		h.logger.Error(ctx, "failed to add order charge", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusCreated, "Landed cost charge added successfully", charge, nil)
+}
+
+func (h *Handler) ListOrderCharges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	charges, err := h.service.ListOrderCharges(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "failed to list order charges", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Landed cost charges retrieved successfully", charges, nil)
+}
+
+func (h *Handler) DeleteOrderCharge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+	chargeID := chi.URLParam(r, "charge_id")
+
+	if err := h.service.DeleteOrderCharge(ctx, id, chargeID); err != nil {
+		h.logger.Error(ctx, "failed to delete order charge", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Landed cost charge deleted successfully", nil, nil)
+}
+
+func (h *Handler) GetVendorPerformance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vendorID := chi.URLParam(r, "vendor_id")
+
+	performance, err := h.service.GetVendorPerformance(ctx, vendorID)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get vendor performance", zap.Error(err))
+		response.SendErrorResponse(w, err)
+		return
+	}
+
+	response.SendSuccessResponse(w, http.StatusOK, "Vendor performance retrieved successfully", performance, nil)
+}
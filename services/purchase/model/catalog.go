@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VendorCatalogItem struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	VendorID uuid.UUID `json:"vendor_id" db:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ItemID   uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	VendorSKU        string  `json:"vendor_sku" db:"vendor_sku" example:"ACME-LAP-16"`
+	Cost             float64 `json:"cost" db:"cost" example:"999.50"`
+	MinOrderQuantity int     `json:"min_order_quantity" db:"min_order_quantity" example:"5"`
+	LeadTimeDays     int     `json:"lead_time_days" db:"lead_time_days" example:"14"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type VendorCatalogFilter struct {
+	VendorID *uuid.UUID
+	ItemID   *uuid.UUID
+}
+
+type CreateVendorCatalogItemRequest struct {
+	VendorID         uuid.UUID `json:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ItemID           uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	VendorSKU        string    `json:"vendor_sku" example:"ACME-LAP-16"`
+	Cost             float64   `json:"cost" example:"999.50"`
+	MinOrderQuantity int       `json:"min_order_quantity" example:"5"`
+	LeadTimeDays     int       `json:"lead_time_days" example:"14"`
+}
+
+type UpdateVendorCatalogItemRequest struct {
+	VendorSKU        string  `json:"vendor_sku" example:"ACME-LAP-16"`
+	Cost             float64 `json:"cost" example:"949.00"`
+	MinOrderQuantity int     `json:"min_order_quantity" example:"10"`
+	LeadTimeDays     int     `json:"lead_time_days" example:"10"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LandedCostChargeType string
+
+const (
+	LandedCostChargeTypeFreight   LandedCostChargeType = "Freight"
+	LandedCostChargeTypeDuty      LandedCostChargeType = "Duty"
+	LandedCostChargeTypeInsurance LandedCostChargeType = "Insurance"
+	LandedCostChargeTypeOther     LandedCostChargeType = "Other"
+)
+
+type AllocationMethod string
+
+const (
+	AllocationMethodValue    AllocationMethod = "Value"
+	AllocationMethodQuantity AllocationMethod = "Quantity"
+	AllocationMethodWeight   AllocationMethod = "Weight"
+)
+
+type LandedCostCharge struct {
+	ID      uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440008"`
+	OrderID uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	ChargeType       LandedCostChargeType `json:"charge_type" db:"charge_type" example:"Freight"`
+	Description      string               `json:"description" db:"description" example:"Ocean freight Shanghai - Rotterdam"`
+	Amount           float64              `json:"amount" db:"amount" example:"150.00"`
+	AllocationMethod AllocationMethod     `json:"allocation_method" db:"allocation_method" example:"Value"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type CreateLandedCostChargeRequest struct {
+	ChargeType       LandedCostChargeType `json:"charge_type" example:"Freight"`
+	Description      string               `json:"description" example:"Ocean freight Shanghai - Rotterdam"`
+	Amount           float64              `json:"amount" example:"150.00"`
+	AllocationMethod AllocationMethod     `json:"allocation_method" example:"Value"`
+}
+
+// ReceivePurchaseOrderRequest carries the landed-cost charges known at the time of receipt, the
+// lots the goods arrived in and the serial numbers of serialized items. The body is optional for
+// orders without serialized items; charges already attached to the order are allocated as well,
+// and quantities not covered by a lot are received without one.
+type ReceivePurchaseOrderRequest struct {
+	Charges []CreateLandedCostChargeRequest `json:"charges"`
+	Lots    []ReceiveLotRequest             `json:"lots"`
+	Serials []ItemSerialsRequest            `json:"serials"`
+}
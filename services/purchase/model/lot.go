@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReceivedLot records the quantity of an item received under a vendor lot number
+type ReceivedLot struct {
+	ID      uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440009"`
+	OrderID uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ItemID  uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	LotNumber  string     `json:"lot_number" db:"lot_number" example:"LOT-2025-114"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" db:"expiry_date" example:"2026-05-31T00:00:00Z"`
+	Quantity   int        `json:"quantity" db:"quantity" example:"24"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type ReceiveLotRequest struct {
+	ItemID     uuid.UUID  `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	LotNumber  string     `json:"lot_number" example:"LOT-2025-114"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" example:"2026-05-31T00:00:00Z"`
+	Quantity   int        `json:"quantity" example:"24"`
+}
@@ -9,18 +9,25 @@ import (
 type PurchaseOrderStatus string
 
 const (
-	PurchaseOrderStatusDraft    PurchaseOrderStatus = "Draft"
-	PurchaseOrderStatusReceived PurchaseOrderStatus = "Received"
-	PurchaseOrderStatusPaid     PurchaseOrderStatus = "Paid"
+	PurchaseOrderStatusDraft     PurchaseOrderStatus = "Draft"
+	PurchaseOrderStatusReceived  PurchaseOrderStatus = "Received"
+	PurchaseOrderStatusPaid      PurchaseOrderStatus = "Paid"
+	PurchaseOrderStatusCancelled PurchaseOrderStatus = "Cancelled"
 )
 
 type PurchaseOrder struct {
 	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	VendorID uuid.UUID `json:"vendor_id" db:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 
+	// WarehouseID is the warehouse the order is received into; nil means the configured default warehouse
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+
 	Status      PurchaseOrderStatus `json:"status" db:"status" example:"Draft"`
 	TotalAmount float64             `json:"total_amount" db:"total_amount" example:"2599.98"`
 
+	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date,omitempty" db:"expected_delivery_date" example:"2025-12-01T00:00:00Z"`
+	ReceivedAt           *time.Time `json:"received_at,omitempty" db:"received_at" example:"2025-11-28T09:30:00Z"`
+
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
 }
@@ -34,25 +41,61 @@ type PurchaseOrderItem struct {
 	UnitPrice float64 `json:"unit_price" db:"unit_price" example:"1299.99"`
 	Subtotal  float64 `json:"subtotal" db:"subtotal" example:"2599.98"`
 
+	// Unit is the unit of measure Quantity and UnitPrice are in; BaseQuantity is the quantity in
+	// the item's base unit, ConversionFactor base units to each unit
+	Unit             string `json:"unit" db:"unit" example:"each"`
+	ConversionFactor int    `json:"conversion_factor" db:"conversion_factor" example:"1"`
+	BaseQuantity     int    `json:"base_quantity" db:"base_quantity" example:"2"`
+
+	UnitWeight     float64  `json:"unit_weight" db:"unit_weight" example:"2.5"`
+	LandedUnitCost *float64 `json:"landed_unit_cost,omitempty" db:"landed_unit_cost" example:"1337.49"`
+
+	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date,omitempty" db:"expected_delivery_date" example:"2025-12-01T00:00:00Z"`
+
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
 }
 
 type PurchaseOrderWithItems struct {
 	PurchaseOrder
-	Items []PurchaseOrderItem `json:"items"`
+	Items   []PurchaseOrderItem `json:"items"`
+	Charges []LandedCostCharge  `json:"charges,omitempty"`
+	Lots    []ReceivedLot       `json:"lots,omitempty"`
+	Serials []ReceivedSerial    `json:"serials,omitempty"`
 }
 
 type CreatePurchaseOrderRequest struct {
-	VendorID uuid.UUID                        `json:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Items    []CreatePurchaseOrderItemRequest `json:"items"`
+	VendorID             uuid.UUID                        `json:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID          *uuid.UUID                       `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	ExpectedDeliveryDate *time.Time                       `json:"expected_delivery_date,omitempty" example:"2025-12-01T00:00:00Z"`
+	Items                []CreatePurchaseOrderItemRequest `json:"items"`
 }
 
 type CreatePurchaseOrderItemRequest struct {
-	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
-	Quantity int       `json:"quantity" example:"2"`
+	ItemID     uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Quantity   int       `json:"quantity" example:"2"`
+	UnitPrice  *float64  `json:"unit_price,omitempty" example:"999.50"`
+	UnitWeight float64   `json:"unit_weight,omitempty" example:"2.5"`
+
+	// Unit defaults to the item's base unit; UnitPrice and UnitWeight are per unit
+	Unit string `json:"unit,omitempty" example:"each"`
+
+	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date,omitempty" example:"2025-12-01T00:00:00Z"`
 }
 
 type UpdatePurchaseOrderRequest struct {
-	Items []CreatePurchaseOrderItemRequest `json:"items"`
+	WarehouseID          *uuid.UUID                       `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	ExpectedDeliveryDate *time.Time                       `json:"expected_delivery_date,omitempty" example:"2025-12-01T00:00:00Z"`
+	Items                []CreatePurchaseOrderItemRequest `json:"items"`
+}
+
+// VendorDeliveryPerformance summarises how reliably a vendor delivers by the expected date.
+// Only received orders that had an expected delivery date are counted.
+type VendorDeliveryPerformance struct {
+	VendorID        uuid.UUID `json:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ReceivedOrders  int       `json:"received_orders" example:"20"`
+	OnTimeOrders    int       `json:"on_time_orders" example:"17"`
+	LateOrders      int       `json:"late_orders" example:"3"`
+	OnTimeRate      float64   `json:"on_time_rate" example:"85.00"`
+	AverageDaysLate float64   `json:"average_days_late" example:"2.33"`
 }
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DebitNoteStatus string
+
+const (
+	DebitNoteStatusOpen    DebitNoteStatus = "Open"
+	DebitNoteStatusApplied DebitNoteStatus = "Applied"
+)
+
+type PurchaseReturn struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	OrderID  uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	VendorID uuid.UUID `json:"vendor_id" db:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+
+	Reason      string  `json:"reason" db:"reason" example:"Damaged in transit"`
+	TotalAmount float64 `json:"total_amount" db:"total_amount" example:"1299.99"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type PurchaseReturnItem struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440006"`
+	ReturnID uuid.UUID `json:"return_id" db:"return_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	ItemID   uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	Quantity  int     `json:"quantity" db:"quantity" example:"1"`
+	UnitPrice float64 `json:"unit_price" db:"unit_price" example:"1299.99"`
+	Subtotal  float64 `json:"subtotal" db:"subtotal" example:"1299.99"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type DebitNote struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440007"`
+	VendorID uuid.UUID `json:"vendor_id" db:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderID  uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ReturnID uuid.UUID `json:"return_id" db:"return_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+
+	Amount float64         `json:"amount" db:"amount" example:"1299.99"`
+	Status DebitNoteStatus `json:"status" db:"status" example:"Open"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
+}
+
+type PurchaseReturnWithItems struct {
+	PurchaseReturn
+	Items     []PurchaseReturnItem `json:"items"`
+	Serials   []ReturnedSerial     `json:"serials,omitempty"`
+	DebitNote *DebitNote           `json:"debit_note,omitempty"`
+}
+
+type VendorBalance struct {
+	VendorID      uuid.UUID `json:"vendor_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	PayableAmount float64   `json:"payable_amount" example:"2599.98"`
+	DebitAmount   float64   `json:"debit_amount" example:"1299.99"`
+	Balance       float64   `json:"balance" example:"1299.99"`
+}
+
+// CreatePurchaseReturnRequest names the serial numbers of the units sent back for items whose
+// receipt captured serial numbers
+type CreatePurchaseReturnRequest struct {
+	Reason  string                            `json:"reason" example:"Damaged in transit"`
+	Items   []CreatePurchaseReturnItemRequest `json:"items"`
+	Serials []ItemSerialsRequest              `json:"serials"`
+}
+
+type CreatePurchaseReturnItemRequest struct {
+	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Quantity int       `json:"quantity" example:"1"`
+}
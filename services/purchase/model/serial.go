@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReceivedSerial records one unit of a serialized item received on an order
+type ReceivedSerial struct {
+	ID      uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	OrderID uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ItemID  uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	SerialNumber string `json:"serial_number" db:"serial_number" example:"SN-5CD1234XYZ"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// ReturnedSerial records one unit of a serialized item sent back to the vendor on a return
+type ReturnedSerial struct {
+	ID       uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440011"`
+	ReturnID uuid.UUID `json:"return_id" db:"return_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	ItemID   uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	SerialNumber string `json:"serial_number" db:"serial_number" example:"SN-5CD1234XYZ"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
+// ItemSerialsRequest names the serial numbers of the units of one item on a receipt or return
+type ItemSerialsRequest struct {
+	ItemID        uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	SerialNumbers []string  `json:"serial_numbers" example:"SN-5CD1234XYZ"`
+}
@@ -28,6 +28,9 @@ func (r *CreatePurchaseOrderItemRequest) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(&r.ItemID, validation.Required),
 		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+		validation.Field(&r.UnitPrice, validation.Min(0.0)),
+		validation.Field(&r.UnitWeight, validation.Min(0.0)),
+		validation.Field(&r.Unit, validation.Length(0, 20)),
 	)
 }
 
@@ -47,3 +50,122 @@ func (r *UpdatePurchaseOrderRequest) Validate() error {
 
 	return nil
 }
+
+func (r *CreateVendorCatalogItemRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.VendorID, validation.Required),
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.VendorSKU, validation.Length(0, 100)),
+		validation.Field(&r.Cost, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.MinOrderQuantity, validation.Min(0)),
+		validation.Field(&r.LeadTimeDays, validation.Min(0)),
+	)
+}
+
+func (r *UpdateVendorCatalogItemRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.VendorSKU, validation.Length(0, 100)),
+		validation.Field(&r.Cost, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.MinOrderQuantity, validation.Min(0)),
+		validation.Field(&r.LeadTimeDays, validation.Min(0)),
+	)
+}
+
+func (r *CreatePurchaseReturnRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Reason, validation.Length(0, 1000)),
+		validation.Field(&r.Items, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Serials, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each item in the items slice
+	for i, item := range r.Items {
+		if err := item.Validate(); err != nil {
+			return validation.NewError("items", fmt.Sprintf("item[%d]: %v", i, err))
+		}
+	}
+
+	// Validate each entry in the serials slice
+	for i, serials := range r.Serials {
+		if err := serials.Validate(); err != nil {
+			return validation.NewError("serials", fmt.Sprintf("serials[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *CreatePurchaseReturnItemRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+func (r *CreateLandedCostChargeRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ChargeType, validation.Required, validation.In(
+			LandedCostChargeTypeFreight,
+			LandedCostChargeTypeDuty,
+			LandedCostChargeTypeInsurance,
+			LandedCostChargeTypeOther,
+		)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&r.AllocationMethod, validation.In(
+			AllocationMethodValue,
+			AllocationMethodQuantity,
+			AllocationMethodWeight,
+		)),
+	)
+}
+
+func (r *ReceivePurchaseOrderRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Charges, validation.Length(0, 20)),
+		validation.Field(&r.Lots, validation.Length(0, 100)),
+		validation.Field(&r.Serials, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each charge in the charges slice
+	for i, charge := range r.Charges {
+		if err := charge.Validate(); err != nil {
+			return validation.NewError("charges", fmt.Sprintf("charge[%d]: %v", i, err))
+		}
+	}
+
+	// Validate each lot in the lots slice
+	for i, lot := range r.Lots {
+		if err := lot.Validate(); err != nil {
+			return validation.NewError("lots", fmt.Sprintf("lot[%d]: %v", i, err))
+		}
+	}
+
+	// Validate each entry in the serials slice
+	for i, serials := range r.Serials {
+		if err := serials.Validate(); err != nil {
+			return validation.NewError("serials", fmt.Sprintf("serials[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *ReceiveLotRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.LotNumber, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+func (r *ItemSerialsRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.SerialNumbers, validation.Required, validation.Length(1, 1000), validation.Each(validation.Required, validation.Length(1, 100))),
+	)
+}
@@ -0,0 +1,34 @@
+-- name: CreateCatalogItem :exec
+INSERT INTO vendor_catalog_items (id, vendor_id, item_id, vendor_sku, cost, min_order_quantity, lead_time_days, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetCatalogItemByID :one
+SELECT id, vendor_id, item_id, vendor_sku, cost, min_order_quantity, lead_time_days, created_at, updated_at
+FROM vendor_catalog_items
+WHERE id = $1;
+
+-- name: GetCatalogItemByVendorAndItem :one
+SELECT id, vendor_id, item_id, vendor_sku, cost, min_order_quantity, lead_time_days, created_at, updated_at
+FROM vendor_catalog_items
+WHERE vendor_id = $1 AND item_id = $2;
+
+-- name: ListCatalogItems :many
+SELECT id, vendor_id, item_id, vendor_sku, cost, min_order_quantity, lead_time_days, created_at, updated_at
+FROM vendor_catalog_items
+WHERE (sqlc.narg('vendor_id')::uuid IS NULL OR vendor_id = sqlc.narg('vendor_id'))
+  AND (sqlc.narg('item_id')::uuid IS NULL OR item_id = sqlc.narg('item_id'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpdateCatalogItem :exec
+UPDATE vendor_catalog_items
+SET vendor_sku = $2,
+    cost = $3,
+    min_order_quantity = $4,
+    lead_time_days = $5,
+    updated_at = $6
+WHERE id = $1;
+
+-- name: DeleteCatalogItem :exec
+DELETE FROM vendor_catalog_items
+WHERE id = $1;
@@ -0,0 +1,18 @@
+-- name: CreateOrderCharge :exec
+INSERT INTO purchase_order_charges (id, order_id, charge_type, description, amount, allocation_method, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetOrderChargeByID :one
+SELECT id, order_id, charge_type, description, amount, allocation_method, created_at, updated_at
+FROM purchase_order_charges
+WHERE id = $1;
+
+-- name: ListOrderChargesByOrderID :many
+SELECT id, order_id, charge_type, description, amount, allocation_method, created_at, updated_at
+FROM purchase_order_charges
+WHERE order_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteOrderCharge :exec
+DELETE FROM purchase_order_charges
+WHERE id = $1;
@@ -0,0 +1,20 @@
+-- name: CreateDebitNote :exec
+INSERT INTO debit_notes (id, vendor_id, order_id, return_id, amount, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetDebitNoteByReturnID :one
+SELECT id, vendor_id, order_id, return_id, amount, status, created_at, updated_at
+FROM debit_notes
+WHERE return_id = $1;
+
+-- name: ListDebitNotes :many
+SELECT id, vendor_id, order_id, return_id, amount, status, created_at, updated_at
+FROM debit_notes
+WHERE (sqlc.narg('vendor_id')::uuid IS NULL OR vendor_id = sqlc.narg('vendor_id'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetVendorBalance :one
+SELECT
+    COALESCE((SELECT SUM(po.total_amount) FROM purchase_orders po WHERE po.vendor_id = $1 AND po.status = 'Received'), 0)::numeric AS payable_amount,
+    COALESCE((SELECT SUM(dn.amount) FROM debit_notes dn WHERE dn.vendor_id = $1 AND dn.status = 'Open'), 0)::numeric AS debit_amount;
@@ -0,0 +1,9 @@
+-- name: CreateOrderLot :exec
+INSERT INTO purchase_order_lots (id, order_id, item_id, lot_number, expiry_date, quantity, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListOrderLotsByOrderID :many
+SELECT id, order_id, item_id, lot_number, expiry_date, quantity, created_at
+FROM purchase_order_lots
+WHERE order_id = $1
+ORDER BY created_at ASC, lot_number ASC;
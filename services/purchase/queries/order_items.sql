@@ -1,14 +1,18 @@
 -- name: CreateOrderItem :exec
-INSERT INTO purchase_order_items (id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+INSERT INTO purchase_order_items (id, order_id, item_id, quantity, unit_price, subtotal, unit_weight, expected_delivery_date, unit, conversion_factor, base_quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
 
 -- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at
+SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at, unit_weight, landed_unit_cost, expected_delivery_date, unit, conversion_factor, base_quantity
 FROM purchase_order_items
 WHERE order_id = $1
 ORDER BY created_at ASC;
 
+-- name: UpdateOrderItemLandedCost :exec
+UPDATE purchase_order_items
+SET landed_unit_cost = $2, updated_at = $3
+WHERE id = $1;
+
 -- name: DeleteOrderItemsByOrderID :exec
 DELETE FROM purchase_order_items
 WHERE order_id = $1;
-
@@ -1,24 +1,72 @@
 -- name: CreateOrder :exec
-INSERT INTO purchase_orders (id, vendor_id, status, total_amount, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO purchase_orders (id, vendor_id, status, total_amount, expected_delivery_date, created_at, updated_at, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetOrderByID :one
-SELECT id, vendor_id, status, total_amount, created_at, updated_at
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
 FROM purchase_orders
 WHERE id = $1;
 
+-- name: GetOrderByIDForUpdate :one
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
+FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
 -- name: ListOrders :many
-SELECT id, vendor_id, status, total_amount, created_at, updated_at
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
 FROM purchase_orders
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
+-- name: ListOpenOrdersByItemID :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND EXISTS (
+    SELECT 1 FROM purchase_order_items poi
+    WHERE poi.order_id = po.id AND poi.item_id = $1
+  )
+ORDER BY po.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListOverdueOrders :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND (
+    po.expected_delivery_date < sqlc.arg(as_of)::date
+    OR EXISTS (
+      SELECT 1 FROM purchase_order_items poi
+      WHERE poi.order_id = po.id AND poi.expected_delivery_date < sqlc.arg(as_of)::date
+    )
+  )
+ORDER BY po.expected_delivery_date ASC NULLS LAST, po.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: ListOrdersPendingOverdueNotice :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND po.overdue_notified_at IS NULL
+  AND (
+    po.expected_delivery_date < sqlc.arg(as_of)::date
+    OR EXISTS (
+      SELECT 1 FROM purchase_order_items poi
+      WHERE poi.order_id = po.id AND poi.expected_delivery_date < sqlc.arg(as_of)::date
+    )
+  )
+ORDER BY po.created_at ASC;
+
 -- name: UpdateOrder :exec
 UPDATE purchase_orders
 SET vendor_id = $2,
     status = $3,
     total_amount = $4,
-    updated_at = $5
+    expected_delivery_date = $5,
+    overdue_notified_at = NULL,
+    updated_at = $6,
+    warehouse_id = $7
 WHERE id = $1;
 
 -- name: UpdateOrderStatus :exec
@@ -27,3 +75,31 @@ SET status = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1;
 
+-- name: CancelDraftOrder :execrows
+UPDATE purchase_orders
+SET status = 'Cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'Draft';
+
+-- name: MarkOrderReceived :exec
+UPDATE purchase_orders
+SET status = 'Received',
+    received_at = sqlc.arg(received_at)::timestamp,
+    updated_at = sqlc.arg(received_at)::timestamp
+WHERE id = $1;
+
+-- name: MarkOrderOverdueNotified :exec
+UPDATE purchase_orders
+SET overdue_notified_at = sqlc.arg(notified_at)::timestamp
+WHERE id = $1;
+
+-- name: GetVendorDeliveryStats :one
+SELECT
+    COUNT(*) AS received_orders,
+    COUNT(*) FILTER (WHERE received_at::date <= expected_delivery_date) AS on_time_orders,
+    COUNT(*) FILTER (WHERE received_at::date > expected_delivery_date) AS late_orders,
+    COALESCE(AVG(received_at::date - expected_delivery_date) FILTER (WHERE received_at::date > expected_delivery_date), 0)::numeric AS average_days_late
+FROM purchase_orders
+WHERE vendor_id = $1
+  AND received_at IS NOT NULL
+  AND expected_delivery_date IS NOT NULL;
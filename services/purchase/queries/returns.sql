@@ -0,0 +1,26 @@
+-- name: CreateReturn :exec
+INSERT INTO purchase_returns (id, order_id, vendor_id, reason, total_amount, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CreateReturnItem :exec
+INSERT INTO purchase_return_items (id, return_id, item_id, quantity, unit_price, subtotal, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListReturnsByOrderID :many
+SELECT id, order_id, vendor_id, reason, total_amount, created_at
+FROM purchase_returns
+WHERE order_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetReturnItemsByReturnID :many
+SELECT id, return_id, item_id, quantity, unit_price, subtotal, created_at
+FROM purchase_return_items
+WHERE return_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetReturnedQuantitiesByOrderID :many
+SELECT ri.item_id, COALESCE(SUM(ri.quantity), 0)::integer AS quantity
+FROM purchase_return_items ri
+JOIN purchase_returns r ON r.id = ri.return_id
+WHERE r.order_id = $1
+GROUP BY ri.item_id;
@@ -0,0 +1,26 @@
+-- name: CreateOrderSerial :exec
+INSERT INTO purchase_order_serials (id, order_id, item_id, serial_number, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListOrderSerialsByOrderID :many
+SELECT id, order_id, item_id, serial_number, created_at
+FROM purchase_order_serials
+WHERE order_id = $1
+ORDER BY created_at ASC, serial_number ASC;
+
+-- name: CreateReturnSerial :exec
+INSERT INTO purchase_return_serials (id, return_id, item_id, serial_number, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListReturnSerialsByReturnID :many
+SELECT id, return_id, item_id, serial_number, created_at
+FROM purchase_return_serials
+WHERE return_id = $1
+ORDER BY created_at ASC, serial_number ASC;
+
+-- name: ListReturnedSerialsByOrderID :many
+SELECT rs.id, rs.return_id, rs.item_id, rs.serial_number, rs.created_at
+FROM purchase_return_serials rs
+JOIN purchase_returns r ON r.id = rs.return_id
+WHERE r.order_id = $1
+ORDER BY rs.created_at ASC, rs.serial_number ASC;
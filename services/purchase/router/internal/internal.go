@@ -47,6 +47,90 @@ func InitPurchaseRoutes(router chi.Router, handler *httphandler.Handler, authMid
 			Handler:     handler.PayOrder,
 			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/orders/{id}/charges",
+			Handler:     handler.ListOrderCharges,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/orders/{id}/charges",
+			Handler:     handler.AddOrderCharge,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/orders/{id}/charges/{charge_id}",
+			Handler:     handler.DeleteOrderCharge,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/orders/{id}/cancel",
+			Handler:     handler.CancelOrder,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/orders/{id}/returns",
+			Handler:     handler.CreateReturn,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/orders/{id}/returns",
+			Handler:     handler.ListReturns,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/debit-notes",
+			Handler:     handler.ListDebitNotes,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/vendors/{vendor_id}/balance",
+			Handler:     handler.GetVendorBalance,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/vendors/{vendor_id}/performance",
+			Handler:     handler.GetVendorPerformance,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/catalog",
+			Handler:     handler.ListCatalogItems,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/catalog/{id}",
+			Handler:     handler.GetCatalogItem,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("inventory_manager", "finance_manager")},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/catalog",
+			Handler:     handler.CreateCatalogItem,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/catalog/{id}",
+			Handler:     handler.UpdateCatalogItem,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/catalog/{id}",
+			Handler:     handler.DeleteCatalogItem,
+			Middlewares: []func(next http.Handler) http.Handler{authMiddleware.ValidateToken, authMiddleware.RequireRole("finance_manager")},
+		},
 	}
 
 	routerpkg.RegisterRoutes(router, routes)
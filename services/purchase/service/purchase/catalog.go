@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *Service) CreateCatalogItem(ctx context.Context, req model.CreateVendorCatalogItemRequest) (model.VendorCatalogItem, error) {
+	token, err := s.getTokenFromContext(ctx)
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrInternalServerError
+	}
+
+	if _, err := s.contactClient.GetVendorByID(ctx, req.VendorID.String(), token); err != nil {
+		s.logger.Error(ctx, "failed to validate vendor", zap.String("vendor_id", req.VendorID.String()), zap.Error(err))
+		if err == errors.ErrNotFound {
+			return model.VendorCatalogItem{}, errors.ErrBadRequest
+		}
+		return model.VendorCatalogItem{}, errors.ErrInternalServerError
+	}
+
+	inventoryItem, err := s.inventoryClient.GetItemByID(ctx, req.ItemID.String(), token)
+	if err != nil {
+		s.logger.Error(ctx, "failed to validate item", zap.String("item_id", req.ItemID.String()), zap.Error(err))
+		if err == errors.ErrNotFound {
+			return model.VendorCatalogItem{}, errors.ErrBadRequest
+		}
+		return model.VendorCatalogItem{}, errors.ErrInternalServerError
+	}
+	if inventoryItem.ArchivedAt != nil {
+		return model.VendorCatalogItem{}, errors.ErrBadRequest
+	}
+
+	item := model.VendorCatalogItem{
+		ID:               uuid.New(),
+		VendorID:         req.VendorID,
+		ItemID:           req.ItemID,
+		VendorSKU:        strings.TrimSpace(req.VendorSKU),
+		Cost:             req.Cost,
+		MinOrderQuantity: normalizeMinOrderQuantity(req.MinOrderQuantity),
+		LeadTimeDays:     req.LeadTimeDays,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := s.storage.CreateCatalogItem(ctx, item); err != nil {
+		return model.VendorCatalogItem{}, err
+	}
+
+	return item, nil
+}
+
+func (s *Service) GetCatalogItemByID(ctx context.Context, id string) (model.VendorCatalogItem, error) {
+	return s.storage.GetCatalogItemByID(ctx, id)
+}
+
+func (s *Service) ListCatalogItems(ctx context.Context, filter model.VendorCatalogFilter, limit, offset int) ([]model.VendorCatalogItem, error) {
+	return s.storage.ListCatalogItems(ctx, filter, limit, offset)
+}
+
+func (s *Service) UpdateCatalogItem(ctx context.Context, id string, req model.UpdateVendorCatalogItemRequest) (model.VendorCatalogItem, error) {
+	item, err := s.storage.GetCatalogItemByID(ctx, id)
+	if err != nil {
+		return model.VendorCatalogItem{}, err
+	}
+
+	item.VendorSKU = strings.TrimSpace(req.VendorSKU)
+	item.Cost = req.Cost
+	item.MinOrderQuantity = normalizeMinOrderQuantity(req.MinOrderQuantity)
+	item.LeadTimeDays = req.LeadTimeDays
+	item.UpdatedAt = time.Now()
+
+	if err := s.storage.UpdateCatalogItem(ctx, item); err != nil {
+		return model.VendorCatalogItem{}, err
+	}
+
+	return item, nil
+}
+
+func (s *Service) DeleteCatalogItem(ctx context.Context, id string) error {
+	return s.storage.DeleteCatalogItem(ctx, id)
+}
+
+// buildOrderItem validates a requested PO line against inventory and prices it.
+// An explicit unit price wins, then the vendor catalog cost; a line with neither is rejected, as
+// the item's selling price says nothing about what the vendor charges. The catalog cost is per
+// base unit and is scaled to the unit the line is entered in; the vendor's minimum order quantity
+// is in base units too. A quantity below the minimum is rejected, unless roundUpToMinimum is set,
+// as for lines generated by a reorder, in which case it is raised to the minimum.
+func (s *Service) buildOrderItem(ctx context.Context, token string, order model.PurchaseOrder, req model.CreatePurchaseOrderItemRequest, roundUpToMinimum bool) (model.PurchaseOrderItem, error) {
+	inventoryItem, err := s.inventoryClient.GetItemByID(ctx, req.ItemID.String(), token)
+	if err != nil {
+		return model.PurchaseOrderItem{}, err
+	}
+	if inventoryItem.ArchivedAt != nil {
+		return model.PurchaseOrderItem{}, errors.ErrBadRequest
+	}
+
+	factor, ok := inventoryItem.ConversionFactor(req.Unit)
+	if !ok {
+		return model.PurchaseOrderItem{}, errors.ErrBadRequest
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if unit == "" {
+		unit = inventoryItem.BaseUnit
+	}
+	quantity := req.Quantity
+
+	var unitPrice float64
+	expectedDeliveryDate := order.ExpectedDeliveryDate
+
+	catalogItem, err := s.storage.GetCatalogItemByVendorAndItem(ctx, order.VendorID.String(), req.ItemID.String())
+	switch {
+	case err == nil:
+		if quantity*factor < catalogItem.MinOrderQuantity {
+			if !roundUpToMinimum {
+				s.logger.Error(ctx, "quantity below vendor minimum order quantity",
+					zap.String("item_id", req.ItemID.String()),
+					zap.Int("quantity", quantity*factor),
+					zap.Int("min_order_quantity", catalogItem.MinOrderQuantity),
+				)
+				return model.PurchaseOrderItem{}, errors.ErrBadRequest
+			}
+			quantity = (catalogItem.MinOrderQuantity + factor - 1) / factor
+		}
+		unitPrice = catalogItem.Cost * float64(factor)
+		if expectedDeliveryDate == nil && catalogItem.LeadTimeDays > 0 {
+			leadTimeDate := time.Now().AddDate(0, 0, catalogItem.LeadTimeDays)
+			expectedDeliveryDate = truncateToDate(&leadTimeDate)
+		}
+	case err != errors.ErrNotFound:
+		return model.PurchaseOrderItem{}, err
+	case req.UnitPrice == nil:
+		s.logger.Error(ctx, "no catalog cost or unit price for purchase order line",
+			zap.String("item_id", req.ItemID.String()),
+			zap.String("vendor_id", order.VendorID.String()),
+		)
+		return model.PurchaseOrderItem{}, errors.ErrBadRequest
+	}
+
+	if req.UnitPrice != nil {
+		unitPrice = *req.UnitPrice
+	}
+	if req.ExpectedDeliveryDate != nil {
+		expectedDeliveryDate = truncateToDate(req.ExpectedDeliveryDate)
+	}
+
+	return model.PurchaseOrderItem{
+		ID:                   uuid.New(),
+		OrderID:              order.ID,
+		ItemID:               req.ItemID,
+		Quantity:             quantity,
+		UnitPrice:            unitPrice,
+		Subtotal:             unitPrice * float64(quantity),
+		Unit:                 unit,
+		ConversionFactor:     factor,
+		BaseQuantity:         quantity * factor,
+		UnitWeight:           req.UnitWeight,
+		ExpectedDeliveryDate: expectedDeliveryDate,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}, nil
+}
+
+func normalizeMinOrderQuantity(minOrderQuantity int) int {
+	if minOrderQuantity < 1 {
+		return 1
+	}
+	return minOrderQuantity
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/log"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/purchase/client"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeCatalogStorage serves vendor catalog entries keyed by item; methods buildOrderItem does
+// not use are left to the embedded nil interface
+type fakeCatalogStorage struct {
+	storage.Storage
+	catalog map[uuid.UUID]model.VendorCatalogItem
+}
+
+func (f *fakeCatalogStorage) GetCatalogItemByVendorAndItem(ctx context.Context, vendorID, itemID string) (model.VendorCatalogItem, error) {
+	item, ok := f.catalog[uuid.MustParse(itemID)]
+	if !ok || item.VendorID.String() != vendorID {
+		return model.VendorCatalogItem{}, errors.ErrNotFound
+	}
+	return item, nil
+}
+
+// newItemServer fakes the inventory item endpoint
+func newItemServer(t *testing.T, items map[uuid.UUID]inventorymodel.Item) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/items/"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		item, ok := items[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": http.StatusOK, "data": item})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBuildOrderItem(t *testing.T) {
+	vendorID := uuid.New()
+	cataloguedID := uuid.New()
+	uncataloguedID := uuid.New()
+
+	items := map[uuid.UUID]inventorymodel.Item{
+		cataloguedID: {
+			ID: cataloguedID, UnitPrice: 50, BaseUnit: "each",
+			Units: []inventorymodel.ItemUnit{{Unit: "case", ConversionFactor: 12}},
+		},
+		uncataloguedID: {ID: uncataloguedID, UnitPrice: 80, BaseUnit: "each"},
+	}
+	catalog := map[uuid.UUID]model.VendorCatalogItem{
+		cataloguedID: {VendorID: vendorID, ItemID: cataloguedID, Cost: 2.5, MinOrderQuantity: 30},
+	}
+
+	explicitPrice := 7.0
+
+	tests := []struct {
+		name             string
+		req              model.CreatePurchaseOrderItemRequest
+		roundUpToMinimum bool
+		wantQuantity     int
+		wantBaseQuantity int
+		wantUnitPrice    float64
+		wantErr          error
+	}{
+		{
+			name:             "catalog cost per base unit",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 40},
+			wantQuantity:     40,
+			wantBaseQuantity: 40,
+			wantUnitPrice:    2.5,
+		},
+		{
+			name:             "catalog cost scaled to the unit entered",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 3, Unit: "case"},
+			wantQuantity:     3,
+			wantBaseQuantity: 36,
+			wantUnitPrice:    30,
+		},
+		{
+			name:             "explicit unit price wins over the catalog cost",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 40, UnitPrice: &explicitPrice},
+			wantQuantity:     40,
+			wantBaseQuantity: 40,
+			wantUnitPrice:    7,
+		},
+		{
+			name:    "quantity below the minimum is rejected",
+			req:     model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 10},
+			wantErr: errors.ErrBadRequest,
+		},
+		{
+			name:             "reorder quantity is raised to the minimum",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 10},
+			roundUpToMinimum: true,
+			wantQuantity:     30,
+			wantBaseQuantity: 30,
+			wantUnitPrice:    2.5,
+		},
+		{
+			name:             "reorder quantity in a larger unit is raised to whole units",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 1, Unit: "case"},
+			roundUpToMinimum: true,
+			wantQuantity:     3,
+			wantBaseQuantity: 36,
+			wantUnitPrice:    30,
+		},
+		{
+			name:    "no catalog cost and no unit price is rejected",
+			req:     model.CreatePurchaseOrderItemRequest{ItemID: uncataloguedID, Quantity: 5},
+			wantErr: errors.ErrBadRequest,
+		},
+		{
+			name:             "explicit unit price without a catalog entry",
+			req:              model.CreatePurchaseOrderItemRequest{ItemID: uncataloguedID, Quantity: 5, UnitPrice: &explicitPrice},
+			wantQuantity:     5,
+			wantBaseQuantity: 5,
+			wantUnitPrice:    7,
+		},
+		{
+			name:    "unit the item is not kept in is rejected",
+			req:     model.CreatePurchaseOrderItemRequest{ItemID: cataloguedID, Quantity: 5, Unit: "pallet"},
+			wantErr: errors.ErrBadRequest,
+		},
+	}
+
+	server := newItemServer(t, items)
+	s := NewService(&fakeCatalogStorage{catalog: catalog}, nil, nil, client.NewInventoryClient(server.URL), nil, uuid.Nil, log.InitLogger(zap.NewNop()))
+	order := model.PurchaseOrder{ID: uuid.New(), VendorID: vendorID, Status: model.PurchaseOrderStatusDraft}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.buildOrderItem(context.Background(), "token", order, tt.req, tt.roundUpToMinimum)
+			if err != tt.wantErr {
+				t.Fatalf("buildOrderItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Quantity != tt.wantQuantity {
+				t.Errorf("quantity = %d, want %d", got.Quantity, tt.wantQuantity)
+			}
+			if got.BaseQuantity != tt.wantBaseQuantity {
+				t.Errorf("base quantity = %d, want %d", got.BaseQuantity, tt.wantBaseQuantity)
+			}
+			if got.UnitPrice != tt.wantUnitPrice {
+				t.Errorf("unit price = %v, want %v", got.UnitPrice, tt.wantUnitPrice)
+			}
+			if want := tt.wantUnitPrice * float64(tt.wantQuantity); got.Subtotal != want {
+				t.Errorf("subtotal = %v, want %v", got.Subtotal, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/services/purchase/model"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *Service) ListOverdueOrders(ctx context.Context, limit, offset int) ([]model.PurchaseOrder, error) {
+	return s.storage.ListOverdueOrders(ctx, time.Now(), limit, offset)
+}
+
+func (s *Service) GetVendorPerformance(ctx context.Context, vendorID string) (model.VendorDeliveryPerformance, error) {
+	return s.storage.GetVendorDeliveryPerformance(ctx, vendorID)
+}
+
+// StartOverdueMonitor periodically looks for draft orders whose expected delivery date, or the
+// expected date of one of their lines, has passed and publishes purchase.order.overdue for them.
+// Each order is reported once until its dates are changed. It blocks until ctx is done.
+func (s *Service) StartOverdueMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.checkOverdueOrders(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkOverdueOrders(ctx)
+		}
+	}
+}
+
+func (s *Service) checkOverdueOrders(ctx context.Context) {
+	now := time.Now()
+
+	orders, err := s.storage.ListOrdersPendingOverdueNotice(ctx, now)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list overdue orders", zap.Error(err))
+		return
+	}
+
+	today := truncateToDate(&now)
+	for _, order := range orders {
+		items, err := s.storage.GetOrderItemsByOrderID(ctx, order.ID.String())
+		if err != nil {
+			s.logger.Error(ctx, "failed to load overdue order items", zap.String("order_id", order.ID.String()), zap.Error(err))
+			continue
+		}
+
+		overdueItems := make([]map[string]interface{}, 0, len(items))
+		for _, item := range items {
+			if item.ExpectedDeliveryDate == nil || !item.ExpectedDeliveryDate.Before(*today) {
+				continue
+			}
+			overdueItems = append(overdueItems, map[string]interface{}{
+				"item_id":                item.ItemID.String(),
+				"quantity":               item.Quantity,
+				"unit":                   item.Unit,
+				"base_quantity":          item.BaseQuantity,
+				"expected_delivery_date": item.ExpectedDeliveryDate.Format(time.DateOnly),
+				"days_overdue":           daysBetween(*item.ExpectedDeliveryDate, *today),
+			})
+		}
+
+		event := map[string]interface{}{
+			"event_type": "purchase.order.overdue",
+			"order_id":   order.ID.String(),
+			"vendor_id":  order.VendorID.String(),
+			"items":      overdueItems,
+			"timestamp":  time.Now().Format(time.RFC3339),
+		}
+		if order.ExpectedDeliveryDate != nil {
+			event["expected_delivery_date"] = order.ExpectedDeliveryDate.Format(time.DateOnly)
+			if order.ExpectedDeliveryDate.Before(*today) {
+				event["days_overdue"] = daysBetween(*order.ExpectedDeliveryDate, *today)
+			}
+		}
+
+		if err := s.natsClient.Publish("purchase.order.overdue", event); err != nil {
+			s.logger.Error(ctx, "failed to publish purchase.order.overdue event", zap.Error(err))
+			continue
+		}
+
+		s.logger.Info(ctx, "published purchase.order.overdue event",
+			zap.String("order_id", order.ID.String()),
+			zap.String("vendor_id", order.VendorID.String()),
+		)
+
+		if err := s.storage.MarkOrderOverdueNotified(ctx, order.ID, now); err != nil {
+			s.logger.Error(ctx, "failed to mark order overdue notified", zap.String("order_id", order.ID.String()), zap.Error(err))
+		}
+	}
+}
+
+// truncateToDate drops the time of day, since expected delivery dates are stored as dates
+func truncateToDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func latestExpectedDeliveryDate(items []model.PurchaseOrderItem) *time.Time {
+	var latest *time.Time
+	for _, item := range items {
+		if item.ExpectedDeliveryDate != nil && (latest == nil || item.ExpectedDeliveryDate.After(*latest)) {
+			latest = item.ExpectedDeliveryDate
+		}
+	}
+	return latest
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *Service) AddOrderCharge(ctx context.Context, orderID string, req model.CreateLandedCostChargeRequest) (model.LandedCostCharge, error) {
+	order, err := s.storage.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return model.LandedCostCharge{}, err
+	}
+
+	if order.Status != model.PurchaseOrderStatusDraft {
+		return model.LandedCostCharge{}, errors.ErrBadRequest
+	}
+
+	charge := newLandedCostCharge(order.ID, req)
+
+	if charge.AllocationMethod == model.AllocationMethodWeight {
+		items, err := s.storage.GetOrderItemsByOrderID(ctx, orderID)
+		if err != nil {
+			return model.LandedCostCharge{}, err
+		}
+		if _, err := allocationBases(items, charge.AllocationMethod); err != nil {
+			s.logger.Error(ctx, "cannot allocate charge by weight", zap.String("order_id", orderID), zap.Error(err))
+			return model.LandedCostCharge{}, errors.ErrBadRequest
+		}
+	}
+
+	if err := s.storage.CreateOrderCharge(ctx, charge); err != nil {
+		s.logger.Error(ctx, "failed to create order charge", zap.Error(err))
+		return model.LandedCostCharge{}, err
+	}
+
+	return charge, nil
+}
+
+func (s *Service) ListOrderCharges(ctx context.Context, orderID string) ([]model.LandedCostCharge, error) {
+	if _, err := s.storage.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListOrderChargesByOrderID(ctx, orderID)
+}
+
+func (s *Service) DeleteOrderCharge(ctx context.Context, orderID, chargeID string) error {
+	order, err := s.storage.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	charge, err := s.storage.GetOrderChargeByID(ctx, chargeID)
+	if err != nil {
+		return err
+	}
+
+	if charge.OrderID != order.ID {
+		return errors.ErrNotFound
+	}
+
+	if order.Status != model.PurchaseOrderStatusDraft {
+		return errors.ErrBadRequest
+	}
+
+	return s.storage.DeleteOrderCharge(ctx, chargeID)
+}
+
+func newLandedCostCharge(orderID uuid.UUID, req model.CreateLandedCostChargeRequest) model.LandedCostCharge {
+	allocationMethod := req.AllocationMethod
+	if allocationMethod == "" {
+		allocationMethod = model.AllocationMethodValue
+	}
+
+	return model.LandedCostCharge{
+		ID:               uuid.New(),
+		OrderID:          orderID,
+		ChargeType:       req.ChargeType,
+		Description:      strings.TrimSpace(req.Description),
+		Amount:           roundAmount(req.Amount),
+		AllocationMethod: allocationMethod,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+}
+
+// allocateLandedCosts spreads every charge across the order lines using the charge's allocation
+// method and sets LandedUnitCost on each line. It returns the amount allocated to each line.
+// Rounding differences go to the last line so that the allocations add up to the charge amount.
+func allocateLandedCosts(items []model.PurchaseOrderItem, charges []model.LandedCostCharge) ([]float64, error) {
+	allocated := make([]float64, len(items))
+
+	for _, charge := range charges {
+		bases, err := allocationBases(items, charge.AllocationMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		var totalBase float64
+		for _, base := range bases {
+			totalBase += base
+		}
+
+		remaining := charge.Amount
+		for i, base := range bases {
+			share := roundAmount(charge.Amount * base / totalBase)
+			if i == len(bases)-1 {
+				share = roundAmount(remaining)
+			}
+			allocated[i] += share
+			remaining -= share
+		}
+	}
+
+	for i := range items {
+		allocated[i] = roundAmount(allocated[i])
+		landedUnitCost := math.Round((items[i].UnitPrice+allocated[i]/float64(items[i].Quantity))*10000) / 10000
+		items[i].LandedUnitCost = &landedUnitCost
+	}
+
+	return allocated, nil
+}
+
+func allocationBases(items []model.PurchaseOrderItem, method model.AllocationMethod) ([]float64, error) {
+	bases := make([]float64, len(items))
+
+	var totalBase float64
+	for i, item := range items {
+		switch method {
+		case model.AllocationMethodQuantity:
+			bases[i] = float64(item.BaseQuantity)
+		case model.AllocationMethodWeight:
+			bases[i] = item.UnitWeight * float64(item.Quantity)
+		default:
+			bases[i] = item.Subtotal
+		}
+		totalBase += bases[i]
+	}
+
+	if totalBase <= 0 {
+		return nil, fmt.Errorf("order lines have no %s to allocate by", strings.ToLower(string(method)))
+	}
+
+	return bases, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"math"
+	"microservice-challenge/services/purchase/model"
+	"testing"
+)
+
+func TestAllocateLandedCosts(t *testing.T) {
+	// Subtotals 20, 40 and 30; base quantities 2, 1 and 3; weights 2, 4 and 1.5
+	orderLines := func() []model.PurchaseOrderItem {
+		return []model.PurchaseOrderItem{
+			{Quantity: 2, UnitPrice: 10, Subtotal: 20, BaseQuantity: 2, UnitWeight: 1},
+			{Quantity: 1, UnitPrice: 40, Subtotal: 40, BaseQuantity: 1, UnitWeight: 4},
+			{Quantity: 3, UnitPrice: 10, Subtotal: 30, BaseQuantity: 3, UnitWeight: 0.5},
+		}
+	}
+	equalLines := func() []model.PurchaseOrderItem {
+		return []model.PurchaseOrderItem{
+			{Quantity: 1, UnitPrice: 10, Subtotal: 10, BaseQuantity: 1},
+			{Quantity: 1, UnitPrice: 10, Subtotal: 10, BaseQuantity: 1},
+			{Quantity: 1, UnitPrice: 10, Subtotal: 10, BaseQuantity: 1},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		items         []model.PurchaseOrderItem
+		charges       []model.LandedCostCharge
+		wantAllocated []float64
+		wantLanded    []float64
+		wantErr       bool
+	}{
+		{
+			name:          "by value",
+			items:         orderLines(),
+			charges:       []model.LandedCostCharge{{Amount: 9, AllocationMethod: model.AllocationMethodValue}},
+			wantAllocated: []float64{2, 4, 3},
+			wantLanded:    []float64{11, 44, 11},
+		},
+		{
+			name:          "by base quantity",
+			items:         orderLines(),
+			charges:       []model.LandedCostCharge{{Amount: 12, AllocationMethod: model.AllocationMethodQuantity}},
+			wantAllocated: []float64{4, 2, 6},
+			wantLanded:    []float64{12, 42, 12},
+		},
+		{
+			name:          "by weight",
+			items:         orderLines(),
+			charges:       []model.LandedCostCharge{{Amount: 15, AllocationMethod: model.AllocationMethodWeight}},
+			wantAllocated: []float64{4, 8, 3},
+			wantLanded:    []float64{12, 48, 11},
+		},
+		{
+			name:  "charges add up per line",
+			items: orderLines(),
+			charges: []model.LandedCostCharge{
+				{Amount: 9, AllocationMethod: model.AllocationMethodValue},
+				{Amount: 12, AllocationMethod: model.AllocationMethodQuantity},
+			},
+			wantAllocated: []float64{6, 6, 9},
+			wantLanded:    []float64{13, 46, 13},
+		},
+		{
+			name:          "rounding difference goes to the last line",
+			items:         equalLines(),
+			charges:       []model.LandedCostCharge{{Amount: 10, AllocationMethod: model.AllocationMethodValue}},
+			wantAllocated: []float64{3.33, 3.33, 3.34},
+			wantLanded:    []float64{13.33, 13.33, 13.34},
+		},
+		{
+			name:          "no charges leaves the unit price",
+			items:         orderLines(),
+			wantAllocated: []float64{0, 0, 0},
+			wantLanded:    []float64{10, 40, 10},
+		},
+		{
+			name:    "lines without weight cannot take a charge by weight",
+			items:   equalLines(),
+			charges: []model.LandedCostCharge{{Amount: 5, AllocationMethod: model.AllocationMethodWeight}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocated, err := allocateLandedCosts(tt.items, tt.charges)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("allocateLandedCosts() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for i := range tt.items {
+				if math.Abs(allocated[i]-tt.wantAllocated[i]) > 1e-9 {
+					t.Errorf("line %d allocated = %v, want %v", i, allocated[i], tt.wantAllocated[i])
+				}
+				landed := tt.items[i].LandedUnitCost
+				if landed == nil {
+					t.Fatalf("line %d has no landed unit cost", i)
+				}
+				if math.Abs(*landed-tt.wantLanded[i]) > 1e-9 {
+					t.Errorf("line %d landed unit cost = %v, want %v", i, *landed, tt.wantLanded[i])
+				}
+			}
+
+			var total, wantTotal float64
+			for i := range allocated {
+				total += allocated[i]
+				wantTotal += tt.wantAllocated[i]
+			}
+			if math.Abs(total-wantTotal) > 1e-9 {
+				t.Errorf("total allocated = %v, want %v", total, wantTotal)
+			}
+		})
+	}
+}
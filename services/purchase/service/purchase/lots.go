@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"microservice-challenge/services/purchase/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newReceivedLots builds the lots recorded on receipt of an order. Every lot must be for an item
+// on the order, a lot number may appear once per item, and the lots of an item may not exceed
+// the quantity ordered.
+func newReceivedLots(order model.PurchaseOrder, items []model.PurchaseOrderItem, reqs []model.ReceiveLotRequest) ([]model.ReceivedLot, error) {
+	ordered := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		ordered[item.ItemID] += item.BaseQuantity
+	}
+
+	received := make(map[uuid.UUID]int, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	lots := make([]model.ReceivedLot, 0, len(reqs))
+
+	for i, req := range reqs {
+		if _, ok := ordered[req.ItemID]; !ok {
+			return nil, fmt.Errorf("lot[%d]: item %s is not on the order", i, req.ItemID)
+		}
+
+		lotNumber := strings.TrimSpace(req.LotNumber)
+		key := req.ItemID.String() + "/" + lotNumber
+		if seen[key] {
+			return nil, fmt.Errorf("lot[%d]: lot %s is listed twice for item %s", i, lotNumber, req.ItemID)
+		}
+		seen[key] = true
+
+		received[req.ItemID] += req.Quantity
+		if received[req.ItemID] > ordered[req.ItemID] {
+			return nil, fmt.Errorf("lot[%d]: lots exceed the quantity ordered for item %s", i, req.ItemID)
+		}
+
+		lots = append(lots, model.ReceivedLot{
+			ID:         uuid.New(),
+			OrderID:    order.ID,
+			ItemID:     req.ItemID,
+			LotNumber:  lotNumber,
+			ExpiryDate: truncateToDate(req.ExpiryDate),
+			Quantity:   req.Quantity,
+			CreatedAt:  time.Now(),
+		})
+	}
+
+	return lots, nil
+}
+
+// lotEventEntries converts received lots to the form carried by the purchase.order.received event
+func lotEventEntries(lots []model.ReceivedLot) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(lots))
+	for _, lot := range lots {
+		entry := map[string]interface{}{
+			"item_id":    lot.ItemID.String(),
+			"lot_number": lot.LotNumber,
+			"quantity":   lot.Quantity,
+		}
+		if lot.ExpiryDate != nil {
+			entry["expiry_date"] = lot.ExpiryDate.Format(time.DateOnly)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"math"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/middleware"
+	"microservice-challenge/services/purchase/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *Service) CancelOrder(ctx context.Context, id string) (model.PurchaseOrderWithItems, error) {
+	order, err := s.storage.GetOrderByID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	if order.Status != model.PurchaseOrderStatusDraft {
+		return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
+	}
+
+	// A receive racing the cancel wins if it gets there first; the cancel then fails with ErrConflict
+	if err := s.storage.CancelOrder(ctx, id); err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	order.Status = model.PurchaseOrderStatusCancelled
+	order.UpdatedAt = time.Now()
+
+	items, err := s.storage.GetOrderItemsByOrderID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	return model.PurchaseOrderWithItems{
+		PurchaseOrder: order,
+		Items:         items,
+	}, nil
+}
+
+// CreateReturn sends received goods back to the vendor. It records the return together
+// with a debit note against the vendor and publishes purchase.order.returned so that
+// inventory can take the goods out of stock.
+func (s *Service) CreateReturn(ctx context.Context, orderID string, req model.CreatePurchaseReturnRequest) (model.PurchaseReturnWithItems, error) {
+	order, err := s.storage.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	if order.Status != model.PurchaseOrderStatusReceived && order.Status != model.PurchaseOrderStatusPaid {
+		return model.PurchaseReturnWithItems{}, errors.ErrBadRequest
+	}
+
+	orderItems, err := s.storage.GetOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	receivedQuantities := make(map[uuid.UUID]int)
+	receivedValues := make(map[uuid.UUID]float64)
+	for _, item := range orderItems {
+		receivedQuantities[item.ItemID] += item.BaseQuantity
+		receivedValues[item.ItemID] += item.Subtotal
+	}
+
+	returnedQuantities, err := s.storage.GetReturnedQuantitiesByOrderID(ctx, orderID)
+	if err != nil {
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	ret := model.PurchaseReturnWithItems{
+		PurchaseReturn: model.PurchaseReturn{
+			ID:        uuid.New(),
+			OrderID:   order.ID,
+			VendorID:  order.VendorID,
+			Reason:    strings.TrimSpace(req.Reason),
+			CreatedAt: time.Now(),
+		},
+		Items: make([]model.PurchaseReturnItem, 0, len(req.Items)),
+	}
+
+	for _, itemReq := range req.Items {
+		receivedQuantity := receivedQuantities[itemReq.ItemID]
+		if receivedQuantity == 0 {
+			s.logger.Error(ctx, "returned item is not part of the purchase order", zap.String("item_id", itemReq.ItemID.String()))
+			return model.PurchaseReturnWithItems{}, errors.ErrBadRequest
+		}
+
+		returnedQuantities[itemReq.ItemID] += itemReq.Quantity
+		if returnedQuantities[itemReq.ItemID] > receivedQuantity {
+			s.logger.Error(ctx, "return quantity exceeds received quantity",
+				zap.String("item_id", itemReq.ItemID.String()),
+				zap.Int("received", receivedQuantity),
+				zap.Int("returned", returnedQuantities[itemReq.ItemID]),
+			)
+			return model.PurchaseReturnWithItems{}, errors.ErrBadRequest
+		}
+
+		unitPrice := roundAmount(receivedValues[itemReq.ItemID] / float64(receivedQuantity))
+		subtotal := roundAmount(unitPrice * float64(itemReq.Quantity))
+
+		ret.Items = append(ret.Items, model.PurchaseReturnItem{
+			ID:        uuid.New(),
+			ReturnID:  ret.ID,
+			ItemID:    itemReq.ItemID,
+			Quantity:  itemReq.Quantity,
+			UnitPrice: unitPrice,
+			Subtotal:  subtotal,
+			CreatedAt: time.Now(),
+		})
+		ret.TotalAmount += subtotal
+	}
+
+	receivedSerials, err := s.storage.ListOrderSerialsByOrderID(ctx, orderID)
+	if err != nil {
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	returnedSerials, err := s.storage.ListReturnedSerialsByOrderID(ctx, orderID)
+	if err != nil {
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	ret.Serials, err = newReturnedSerials(ret, receivedSerials, returnedSerials, req.Serials)
+	if err != nil {
+		s.logger.Error(ctx, "invalid serial numbers on return", zap.String("order_id", orderID), zap.Error(err))
+		return model.PurchaseReturnWithItems{}, errors.ErrBadRequest
+	}
+
+	ret.TotalAmount = roundAmount(ret.TotalAmount)
+	ret.DebitNote = &model.DebitNote{
+		ID:        uuid.New(),
+		VendorID:  order.VendorID,
+		OrderID:   order.ID,
+		ReturnID:  ret.ID,
+		Amount:    ret.TotalAmount,
+		Status:    model.DebitNoteStatusOpen,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.storage.CreateReturn(ctx, ret); err != nil {
+		s.logger.Error(ctx, "failed to store purchase return", zap.Error(err))
+		return model.PurchaseReturnWithItems{}, err
+	}
+
+	eventItems := make([]map[string]interface{}, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		eventItems = append(eventItems, map[string]interface{}{
+			"item_id":    item.ItemID.String(),
+			"quantity":   item.Quantity,
+			"unit_price": item.UnitPrice,
+			"subtotal":   item.Subtotal,
+		})
+	}
+
+	event := map[string]interface{}{
+		"event_type":    "purchase.order.returned",
+		"order_id":      order.ID.String(),
+		"vendor_id":     order.VendorID.String(),
+		"return_id":     ret.ID.String(),
+		"debit_note_id": ret.DebitNote.ID.String(),
+		"items":         eventItems,
+		"total_amount":  ret.TotalAmount,
+		"timestamp":     time.Now().Format(time.RFC3339),
+	}
+	if len(ret.Serials) > 0 {
+		event["serials"] = returnedSerialEventEntries(ret.Serials)
+	}
+	if warehouseID := s.orderWarehouseID(order); warehouseID != "" {
+		event["warehouse_id"] = warehouseID
+	}
+	if userID := middleware.GetUserIDFromContext(ctx); userID != "" {
+		event["user_id"] = userID
+	}
+
+	if err := s.natsClient.Publish("purchase.order.returned", event); err != nil {
+		s.logger.Error(ctx, "failed to publish purchase.order.returned event", zap.Error(err))
+	} else {
+		s.logger.Info(ctx, "published purchase.order.returned event",
+			zap.String("order_id", order.ID.String()),
+			zap.String("return_id", ret.ID.String()),
+		)
+	}
+
+	return ret, nil
+}
+
+func (s *Service) ListReturns(ctx context.Context, orderID string) ([]model.PurchaseReturnWithItems, error) {
+	if _, err := s.storage.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListReturnsByOrderID(ctx, orderID)
+}
+
+func (s *Service) ListDebitNotes(ctx context.Context, vendorID *uuid.UUID, limit, offset int) ([]model.DebitNote, error) {
+	return s.storage.ListDebitNotes(ctx, vendorID, limit, offset)
+}
+
+func (s *Service) GetVendorBalance(ctx context.Context, vendorID string) (model.VendorBalance, error) {
+	return s.storage.GetVendorBalance(ctx, vendorID)
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
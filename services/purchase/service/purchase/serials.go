@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// serializedItemIDs asks inventory which of the items on an order are tracked by serial number
+func (s *Service) serializedItemIDs(ctx context.Context, items []model.PurchaseOrderItem) (map[uuid.UUID]bool, error) {
+	token, err := s.getTokenFromContext(ctx)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	checked := make(map[uuid.UUID]bool, len(items))
+	serialized := make(map[uuid.UUID]bool)
+	for _, item := range items {
+		if checked[item.ItemID] {
+			continue
+		}
+		checked[item.ItemID] = true
+
+		inventoryItem, err := s.inventoryClient.GetItemByID(ctx, item.ItemID.String(), token)
+		if err != nil {
+			s.logger.Error(ctx, "failed to validate item", zap.String("item_id", item.ItemID.String()), zap.Error(err))
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrBadRequest
+			}
+			return nil, errors.ErrInternalServerError
+		}
+		if inventoryItem.Serialized {
+			serialized[item.ItemID] = true
+		}
+	}
+
+	return serialized, nil
+}
+
+// newReceivedSerials builds the serials recorded on receipt of an order. Every unit of a
+// serialized item must be named by its own serial number, and other items may not carry any.
+func newReceivedSerials(order model.PurchaseOrder, items []model.PurchaseOrderItem, serialized map[uuid.UUID]bool, reqs []model.ItemSerialsRequest) ([]model.ReceivedSerial, error) {
+	ordered := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		ordered[item.ItemID] += item.BaseQuantity
+	}
+
+	received := make(map[uuid.UUID]int, len(reqs))
+	seen := make(map[string]bool)
+	serials := make([]model.ReceivedSerial, 0)
+
+	for i, req := range reqs {
+		if _, ok := ordered[req.ItemID]; !ok {
+			return nil, fmt.Errorf("serials[%d]: item %s is not on the order", i, req.ItemID)
+		}
+		if !serialized[req.ItemID] {
+			return nil, fmt.Errorf("serials[%d]: item %s is not serialized", i, req.ItemID)
+		}
+
+		for _, serialNumber := range req.SerialNumbers {
+			serialNumber = strings.TrimSpace(serialNumber)
+			key := req.ItemID.String() + "/" + serialNumber
+			if seen[key] {
+				return nil, fmt.Errorf("serials[%d]: serial number %s is listed twice for item %s", i, serialNumber, req.ItemID)
+			}
+			seen[key] = true
+			received[req.ItemID]++
+
+			serials = append(serials, model.ReceivedSerial{
+				ID:           uuid.New(),
+				OrderID:      order.ID,
+				ItemID:       req.ItemID,
+				SerialNumber: serialNumber,
+				CreatedAt:    time.Now(),
+			})
+		}
+	}
+
+	for _, item := range items {
+		if serialized[item.ItemID] && received[item.ItemID] != ordered[item.ItemID] {
+			return nil, fmt.Errorf("item %s needs %d serial numbers, got %d", item.ItemID, ordered[item.ItemID], received[item.ItemID])
+		}
+	}
+
+	return serials, nil
+}
+
+// newReturnedSerials builds the serials recorded on a return. Items whose receipt captured serial
+// numbers must name one serial per unit returned, chosen from the units received on the order
+// that have not been returned already.
+func newReturnedSerials(ret model.PurchaseReturnWithItems, received []model.ReceivedSerial, returned []model.ReturnedSerial, reqs []model.ItemSerialsRequest) ([]model.ReturnedSerial, error) {
+	receivable := make(map[string]bool, len(received))
+	serialized := make(map[uuid.UUID]bool)
+	for _, serial := range received {
+		receivable[serial.ItemID.String()+"/"+serial.SerialNumber] = true
+		serialized[serial.ItemID] = true
+	}
+	for _, serial := range returned {
+		delete(receivable, serial.ItemID.String()+"/"+serial.SerialNumber)
+	}
+
+	quantities := make(map[uuid.UUID]int, len(ret.Items))
+	for _, item := range ret.Items {
+		quantities[item.ItemID] += item.Quantity
+	}
+
+	counted := make(map[uuid.UUID]int, len(reqs))
+	serials := make([]model.ReturnedSerial, 0)
+
+	for i, req := range reqs {
+		if _, ok := quantities[req.ItemID]; !ok {
+			return nil, fmt.Errorf("serials[%d]: item %s is not on the return", i, req.ItemID)
+		}
+		if !serialized[req.ItemID] {
+			return nil, fmt.Errorf("serials[%d]: item %s was received without serial numbers", i, req.ItemID)
+		}
+
+		for _, serialNumber := range req.SerialNumbers {
+			serialNumber = strings.TrimSpace(serialNumber)
+			key := req.ItemID.String() + "/" + serialNumber
+			if !receivable[key] {
+				return nil, fmt.Errorf("serials[%d]: serial number %s of item %s is not held from this order", i, serialNumber, req.ItemID)
+			}
+			delete(receivable, key)
+			counted[req.ItemID]++
+
+			serials = append(serials, model.ReturnedSerial{
+				ID:           uuid.New(),
+				ReturnID:     ret.ID,
+				ItemID:       req.ItemID,
+				SerialNumber: serialNumber,
+				CreatedAt:    time.Now(),
+			})
+		}
+	}
+
+	for _, item := range ret.Items {
+		if serialized[item.ItemID] && counted[item.ItemID] != quantities[item.ItemID] {
+			return nil, fmt.Errorf("item %s needs %d serial numbers, got %d", item.ItemID, quantities[item.ItemID], counted[item.ItemID])
+		}
+	}
+
+	return serials, nil
+}
+
+// receivedSerialEventEntries groups received serials by item in the form carried by the
+// purchase.order.received event
+func receivedSerialEventEntries(serials []model.ReceivedSerial) []map[string]interface{} {
+	itemIDs := make([]uuid.UUID, 0)
+	serialNumbers := make(map[uuid.UUID][]string)
+	for _, serial := range serials {
+		if _, seen := serialNumbers[serial.ItemID]; !seen {
+			itemIDs = append(itemIDs, serial.ItemID)
+		}
+		serialNumbers[serial.ItemID] = append(serialNumbers[serial.ItemID], serial.SerialNumber)
+	}
+	return serialEventEntries(itemIDs, serialNumbers)
+}
+
+// returnedSerialEventEntries groups returned serials by item in the form carried by the
+// purchase.order.returned event
+func returnedSerialEventEntries(serials []model.ReturnedSerial) []map[string]interface{} {
+	itemIDs := make([]uuid.UUID, 0)
+	serialNumbers := make(map[uuid.UUID][]string)
+	for _, serial := range serials {
+		if _, seen := serialNumbers[serial.ItemID]; !seen {
+			itemIDs = append(itemIDs, serial.ItemID)
+		}
+		serialNumbers[serial.ItemID] = append(serialNumbers[serial.ItemID], serial.SerialNumber)
+	}
+	return serialEventEntries(itemIDs, serialNumbers)
+}
+
+func serialEventEntries(itemIDs []uuid.UUID, serialNumbers map[uuid.UUID][]string) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		entries = append(entries, map[string]interface{}{
+			"item_id":        itemID.String(),
+			"serial_numbers": serialNumbers[itemID],
+		})
+	}
+	return entries
+}
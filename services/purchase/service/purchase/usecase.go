@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"microservice-challenge/package/errors"
 	"microservice-challenge/package/log"
@@ -14,6 +15,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
 	"go.uber.org/zap"
 )
 
@@ -24,17 +26,33 @@ type Service struct {
 	inventoryClient *client.InventoryClient
 	authClient      *client.AuthClient
 	logger          log.Logger
+
+	// defaultWarehouseID is used for orders without a warehouse; uuid.Nil leaves the choice to inventory
+	defaultWarehouseID uuid.UUID
 }
 
-func NewService(storage storage.Storage, natsClient *natsclient.Client, contactClient *client.ContactClient, inventoryClient *client.InventoryClient, authClient *client.AuthClient, logger log.Logger) *Service {
+func NewService(storage storage.Storage, natsClient *natsclient.Client, contactClient *client.ContactClient, inventoryClient *client.InventoryClient, authClient *client.AuthClient, defaultWarehouseID uuid.UUID, logger log.Logger) *Service {
 	return &Service{
-		storage:         storage,
-		natsClient:      natsClient,
-		contactClient:   contactClient,
-		inventoryClient: inventoryClient,
-		authClient:      authClient,
-		logger:          logger,
+		storage:            storage,
+		natsClient:         natsClient,
+		contactClient:      contactClient,
+		inventoryClient:    inventoryClient,
+		authClient:         authClient,
+		logger:             logger,
+		defaultWarehouseID: defaultWarehouseID,
+	}
+}
+
+// orderWarehouseID returns the warehouse the order is received into, falling back to the
+// configured default. An empty string means no warehouse is known.
+func (s *Service) orderWarehouseID(order model.PurchaseOrder) string {
+	if order.WarehouseID != nil {
+		return order.WarehouseID.String()
+	}
+	if s.defaultWarehouseID != uuid.Nil {
+		return s.defaultWarehouseID.String()
 	}
+	return ""
 }
 
 func (s *Service) getTokenFromContext(ctx context.Context) (string, error) {
@@ -60,29 +78,15 @@ func (s *Service) CreateOrder(ctx context.Context, req model.CreatePurchaseOrder
 		return model.PurchaseOrderWithItems{}, errors.ErrInternalServerError
 	}
 
-	_, err = s.contactClient.GetVendorByID(ctx, req.VendorID.String(), token)
+	order, err := s.newDraftOrder(ctx, token, req)
 	if err != nil {
-		s.logger.Error(ctx, "failed to validate vendor", zap.String("vendor_id", req.VendorID.String()), zap.Error(err), zap.String("error_type", fmt.Sprintf("%T", err)), zap.String("error_msg", err.Error()))
-		if err == errors.ErrNotFound {
-			return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
-		}
-		return model.PurchaseOrderWithItems{}, errors.ErrInternalServerError
-	}
-
-	order := model.PurchaseOrder{
-		ID:          uuid.New(),
-		VendorID:    req.VendorID,
-		Status:      model.PurchaseOrderStatusDraft,
-		TotalAmount: 0,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		return model.PurchaseOrderWithItems{}, err
 	}
 
 	type itemResult struct {
-		item     model.PurchaseOrderItem
-		subtotal float64
-		err      error
-		itemReq  model.CreatePurchaseOrderItemRequest
+		item    model.PurchaseOrderItem
+		err     error
+		itemReq model.CreatePurchaseOrderItemRequest
 	}
 
 	results := make(chan itemResult, len(req.Items))
@@ -92,46 +96,70 @@ func (s *Service) CreateOrder(ctx context.Context, req model.CreatePurchaseOrder
 		wg.Add(1)
 		go func(ir model.CreatePurchaseOrderItemRequest) {
 			defer wg.Done()
-			inventoryItem, err := s.inventoryClient.GetItemByID(ctx, ir.ItemID.String(), token)
+			item, err := s.buildOrderItem(ctx, token, order, ir, false)
 			if err != nil {
 				results <- itemResult{err: err, itemReq: ir}
 				return
 			}
 
-			subtotal := inventoryItem.UnitPrice * float64(ir.Quantity)
-			item := model.PurchaseOrderItem{
-				ID:        uuid.New(),
-				OrderID:   order.ID,
-				ItemID:    ir.ItemID,
-				Quantity:  ir.Quantity,
-				UnitPrice: inventoryItem.UnitPrice,
-				Subtotal:  subtotal,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			}
-			results <- itemResult{item: item, subtotal: subtotal}
+			results <- itemResult{item: item}
 		}(itemReq)
 	}
 
 	wg.Wait()
 	close(results)
 
-	var totalAmount float64
 	items := make([]model.PurchaseOrderItem, 0, len(req.Items))
 
 	for result := range results {
 		if result.err != nil {
 			s.logger.Error(ctx, "failed to validate item", zap.String("item_id", result.itemReq.ItemID.String()), zap.Error(result.err))
-			if result.err == errors.ErrNotFound {
+			if result.err == errors.ErrNotFound || result.err == errors.ErrBadRequest {
 				return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
 			}
 			return model.PurchaseOrderWithItems{}, errors.ErrInternalServerError
 		}
 		items = append(items, result.item)
-		totalAmount += result.subtotal
 	}
 
+	return s.storeNewOrder(ctx, order, items)
+}
+
+// newDraftOrder validates the vendor of a new order with the contact service and returns the
+// draft order, without its lines
+func (s *Service) newDraftOrder(ctx context.Context, token string, req model.CreatePurchaseOrderRequest) (model.PurchaseOrder, error) {
+	_, err := s.contactClient.GetVendorByID(ctx, req.VendorID.String(), token)
+	if err != nil {
+		s.logger.Error(ctx, "failed to validate vendor", zap.String("vendor_id", req.VendorID.String()), zap.Error(err), zap.String("error_type", fmt.Sprintf("%T", err)), zap.String("error_msg", err.Error()))
+		if err == errors.ErrNotFound {
+			return model.PurchaseOrder{}, errors.ErrBadRequest
+		}
+		return model.PurchaseOrder{}, errors.ErrInternalServerError
+	}
+
+	return model.PurchaseOrder{
+		ID:                   uuid.New(),
+		VendorID:             req.VendorID,
+		WarehouseID:          req.WarehouseID,
+		Status:               model.PurchaseOrderStatusDraft,
+		TotalAmount:          0,
+		ExpectedDeliveryDate: truncateToDate(req.ExpectedDeliveryDate),
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}, nil
+}
+
+// storeNewOrder saves a new order with its priced lines, totalling them and taking the
+// expected delivery date from the lines when the order has none
+func (s *Service) storeNewOrder(ctx context.Context, order model.PurchaseOrder, items []model.PurchaseOrderItem) (model.PurchaseOrderWithItems, error) {
+	var totalAmount float64
+	for _, item := range items {
+		totalAmount += item.Subtotal
+	}
 	order.TotalAmount = totalAmount
+	if order.ExpectedDeliveryDate == nil {
+		order.ExpectedDeliveryDate = latestExpectedDeliveryDate(items)
+	}
 
 	if err := s.storage.CreateOrder(ctx, order); err != nil {
 		s.logger.Error(ctx, "failed to store order", zap.Error(err))
@@ -160,9 +188,27 @@ func (s *Service) GetOrderByID(ctx context.Context, id string) (model.PurchaseOr
 		return model.PurchaseOrderWithItems{}, err
 	}
 
+	charges, err := s.storage.ListOrderChargesByOrderID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	lots, err := s.storage.ListOrderLotsByOrderID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	serials, err := s.storage.ListOrderSerialsByOrderID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
 	return model.PurchaseOrderWithItems{
 		PurchaseOrder: order,
 		Items:         items,
+		Charges:       charges,
+		Lots:          lots,
+		Serials:       serials,
 	}, nil
 }
 
@@ -170,6 +216,12 @@ func (s *Service) ListOrders(ctx context.Context, limit, offset int) ([]model.Pu
 	return s.storage.ListOrders(ctx, limit, offset)
 }
 
+// ListOpenOrdersByItemID lists the draft orders that include the item. Inventory checks these
+// before it archives an item.
+func (s *Service) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.PurchaseOrder, error) {
+	return s.storage.ListOpenOrdersByItemID(ctx, itemID, limit, offset)
+}
+
 func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePurchaseOrderRequest) (model.PurchaseOrderWithItems, error) {
 	order, err := s.storage.GetOrderByID(ctx, id)
 	if err != nil {
@@ -189,6 +241,14 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePu
 		return model.PurchaseOrderWithItems{}, errors.ErrInternalServerError
 	}
 
+	if req.WarehouseID != nil {
+		order.WarehouseID = req.WarehouseID
+	}
+
+	if req.ExpectedDeliveryDate != nil {
+		order.ExpectedDeliveryDate = truncateToDate(req.ExpectedDeliveryDate)
+	}
+
 	type itemResult struct {
 		item     model.PurchaseOrderItem
 		subtotal float64
@@ -203,24 +263,13 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePu
 		wg.Add(1)
 		go func(ir model.CreatePurchaseOrderItemRequest) {
 			defer wg.Done()
-			inventoryItem, err := s.inventoryClient.GetItemByID(ctx, ir.ItemID.String(), token)
+			item, err := s.buildOrderItem(ctx, token, order, ir, false)
 			if err != nil {
 				results <- itemResult{err: err, itemReq: ir}
 				return
 			}
 
-			subtotal := inventoryItem.UnitPrice * float64(ir.Quantity)
-			item := model.PurchaseOrderItem{
-				ID:        uuid.New(),
-				OrderID:   order.ID,
-				ItemID:    ir.ItemID,
-				Quantity:  ir.Quantity,
-				UnitPrice: inventoryItem.UnitPrice,
-				Subtotal:  subtotal,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			}
-			results <- itemResult{item: item, subtotal: subtotal}
+			results <- itemResult{item: item, subtotal: item.Subtotal}
 		}(itemReq)
 	}
 
@@ -233,7 +282,7 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePu
 	for result := range results {
 		if result.err != nil {
 			s.logger.Error(ctx, "failed to validate item", zap.String("item_id", result.itemReq.ItemID.String()), zap.Error(result.err))
-			if result.err == errors.ErrNotFound {
+			if result.err == errors.ErrNotFound || result.err == errors.ErrBadRequest {
 				return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
 			}
 			return model.PurchaseOrderWithItems{}, errors.ErrInternalServerError
@@ -244,6 +293,9 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePu
 
 	order.TotalAmount = totalAmount
 	order.UpdatedAt = time.Now()
+	if order.ExpectedDeliveryDate == nil {
+		order.ExpectedDeliveryDate = latestExpectedDeliveryDate(items)
+	}
 
 	if err := s.storage.UpdateOrder(ctx, order); err != nil {
 		s.logger.Error(ctx, "failed to update order in storage", zap.Error(err))
@@ -266,7 +318,7 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdatePu
 	}, nil
 }
 
-func (s *Service) ReceiveOrder(ctx context.Context, id string) (model.PurchaseOrderWithItems, error) {
+func (s *Service) ReceiveOrder(ctx context.Context, id string, req model.ReceivePurchaseOrderRequest) (model.PurchaseOrderWithItems, error) {
 	order, err := s.storage.GetOrderByID(ctx, id)
 	if err != nil {
 		return model.PurchaseOrderWithItems{}, err
@@ -281,30 +333,86 @@ func (s *Service) ReceiveOrder(ctx context.Context, id string) (model.PurchaseOr
 		return model.PurchaseOrderWithItems{}, err
 	}
 
-	if err := s.storage.UpdateOrderStatus(ctx, id, model.PurchaseOrderStatusReceived); err != nil {
+	charges, err := s.storage.ListOrderChargesByOrderID(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	receiptCharges := make([]model.LandedCostCharge, 0, len(req.Charges))
+	for _, chargeReq := range req.Charges {
+		receiptCharges = append(receiptCharges, newLandedCostCharge(order.ID, chargeReq))
+	}
+	charges = append(charges, receiptCharges...)
+
+	allocated, err := allocateLandedCosts(items, charges)
+	if err != nil {
+		s.logger.Error(ctx, "failed to allocate landed costs", zap.String("order_id", id), zap.Error(err))
+		return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
+	}
+
+	lots, err := newReceivedLots(order, items, req.Lots)
+	if err != nil {
+		s.logger.Error(ctx, "invalid lots on receipt", zap.String("order_id", id), zap.Error(err))
+		return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
+	}
+
+	serialized, err := s.serializedItemIDs(ctx, items)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, err
+	}
+
+	serials, err := newReceivedSerials(order, items, serialized, req.Serials)
+	if err != nil {
+		s.logger.Error(ctx, "invalid serial numbers on receipt", zap.String("order_id", id), zap.Error(err))
+		return model.PurchaseOrderWithItems{}, errors.ErrBadRequest
+	}
+
+	receivedAt := time.Now()
+	if err := s.storage.ReceiveOrder(ctx, id, receivedAt, receiptCharges, items, lots, serials); err != nil {
 		return model.PurchaseOrderWithItems{}, err
 	}
 
 	order.Status = model.PurchaseOrderStatusReceived
-	order.UpdatedAt = time.Now()
+	order.ReceivedAt = &receivedAt
+	order.UpdatedAt = receivedAt
 
+	var landedCostTotal float64
 	eventItems := make([]map[string]interface{}, 0, len(items))
-	for _, item := range items {
+	for i, item := range items {
+		landedCostTotal += allocated[i]
 		eventItems = append(eventItems, map[string]interface{}{
-			"item_id":    item.ItemID.String(),
-			"quantity":   item.Quantity,
-			"unit_price": item.UnitPrice,
-			"subtotal":   item.Subtotal,
+			"item_id":           item.ItemID.String(),
+			"quantity":          item.Quantity,
+			"unit":              item.Unit,
+			"conversion_factor": item.ConversionFactor,
+			"base_quantity":     item.BaseQuantity,
+			"unit_price":        item.UnitPrice,
+			"subtotal":          item.Subtotal,
+			"landed_cost":       allocated[i],
+			"landed_unit_cost":  *item.LandedUnitCost,
 		})
 	}
 
 	event := map[string]interface{}{
-		"event_type":   "purchase.order.received",
-		"order_id":     order.ID.String(),
-		"vendor_id":    order.VendorID.String(),
-		"items":        eventItems,
-		"total_amount": order.TotalAmount,
-		"timestamp":    time.Now().Format(time.RFC3339),
+		"event_type":        "purchase.order.received",
+		"order_id":          order.ID.String(),
+		"vendor_id":         order.VendorID.String(),
+		"items":             eventItems,
+		"total_amount":      order.TotalAmount,
+		"landed_cost_total": roundAmount(landedCostTotal),
+		"timestamp":         time.Now().Format(time.RFC3339),
+	}
+	if len(lots) > 0 {
+		event["lots"] = lotEventEntries(lots)
+	}
+	if len(serials) > 0 {
+		event["serials"] = receivedSerialEventEntries(serials)
+	}
+	if warehouseID := s.orderWarehouseID(order); warehouseID != "" {
+		event["warehouse_id"] = warehouseID
+	}
+	if userID := middleware.GetUserIDFromContext(ctx); userID != "" {
+		event["user_id"] = userID
 	}
 
 	if err := s.natsClient.Publish("purchase.order.received", event); err != nil {
@@ -319,6 +427,9 @@ func (s *Service) ReceiveOrder(ctx context.Context, id string) (model.PurchaseOr
 	return model.PurchaseOrderWithItems{
 		PurchaseOrder: order,
 		Items:         items,
+		Charges:       charges,
+		Lots:          lots,
+		Serials:       serials,
 	}, nil
 }
 
@@ -349,3 +460,228 @@ func (s *Service) PayOrder(ctx context.Context, id string) (model.PurchaseOrderW
 		Items:         items,
 	}, nil
 }
+
+func (s *Service) StartEventSubscriptions(ctx context.Context) error {
+	reorderSub, err := s.natsClient.Subscribe("inventory.reorder.needed", func(msg *nats.Msg) {
+		s.handleReorderNeeded(ctx, msg)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info(ctx, "subscribed to inventory.reorder.needed", zap.String("subscription", reorderSub.Subject))
+
+	return nil
+}
+
+func (s *Service) handleReorderNeeded(ctx context.Context, msg *nats.Msg) {
+	var event map[string]interface{}
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error(ctx, "failed to unmarshal inventory.reorder.needed event", zap.Error(err))
+		return
+	}
+
+	items, ok := event["items"].([]interface{})
+	if !ok {
+		s.logger.Error(ctx, "invalid items format in inventory.reorder.needed event")
+		return
+	}
+
+	vendorOrder := make([]uuid.UUID, 0)
+	itemsByVendor := make(map[uuid.UUID][]model.CreatePurchaseOrderItemRequest)
+
+	for _, itemData := range items {
+		itemMap, ok := itemData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		itemID, err := uuid.Parse(fmt.Sprint(itemMap["item_id"]))
+		if err != nil {
+			continue
+		}
+
+		vendorIDStr, ok := itemMap["preferred_vendor_id"].(string)
+		if !ok {
+			s.logger.Warn(ctx, "skipping reorder item without preferred vendor", zap.String("item_id", itemID.String()))
+			continue
+		}
+		vendorID, err := uuid.Parse(vendorIDStr)
+		if err != nil {
+			s.logger.Warn(ctx, "skipping reorder item with invalid preferred vendor", zap.String("item_id", itemID.String()))
+			continue
+		}
+
+		quantity := reorderQuantity(itemMap)
+		if quantity <= 0 {
+			continue
+		}
+
+		// A redelivered event, or one raised again before the stock arrives, must not order the
+		// item twice; a draft order still to be received already covers it
+		if s.hasOpenOrder(ctx, itemID) {
+			s.logger.Info(ctx, "skipping reorder item with an open purchase order", zap.String("item_id", itemID.String()))
+			continue
+		}
+
+		if _, exists := itemsByVendor[vendorID]; !exists {
+			vendorOrder = append(vendorOrder, vendorID)
+		}
+		itemsByVendor[vendorID] = append(itemsByVendor[vendorID], model.CreatePurchaseOrderItemRequest{
+			ItemID:   itemID,
+			Quantity: quantity,
+		})
+	}
+
+	failures := make([]reorderFailure, 0)
+	for _, vendorID := range vendorOrder {
+		req := model.CreatePurchaseOrderRequest{
+			VendorID: vendorID,
+			Items:    itemsByVendor[vendorID],
+		}
+
+		order, failed := s.createReorderOrder(ctx, req)
+		failures = append(failures, failed...)
+		if len(order.Items) == 0 {
+			continue
+		}
+
+		s.logger.Info(ctx, "created draft purchase order for reorder",
+			zap.String("order_id", order.ID.String()),
+			zap.String("vendor_id", vendorID.String()),
+			zap.Int("items", len(order.Items)),
+		)
+	}
+
+	s.publishReorderFailed(ctx, failures)
+}
+
+// reorderFailure is a reorder line no draft order could be created for
+type reorderFailure struct {
+	itemID   uuid.UUID
+	vendorID uuid.UUID
+	reason   string
+}
+
+// createReorderOrder creates the draft order for one vendor's reorder lines. Quantities below the
+// vendor's minimum order quantity are raised to it. A line that cannot be ordered, such as one
+// without a catalog cost, is left off the order rather than failing the other lines; it is
+// returned as a failure together with every line when the order cannot be created at all.
+func (s *Service) createReorderOrder(ctx context.Context, req model.CreatePurchaseOrderRequest) (model.PurchaseOrderWithItems, []reorderFailure) {
+	failAll := func(reason string) []reorderFailure {
+		failures := make([]reorderFailure, 0, len(req.Items))
+		for _, itemReq := range req.Items {
+			failures = append(failures, reorderFailure{itemID: itemReq.ItemID, vendorID: req.VendorID, reason: reason})
+		}
+		return failures
+	}
+
+	token, err := s.getTokenFromContext(ctx)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, failAll(reorderFailureReason(err))
+	}
+
+	order, err := s.newDraftOrder(ctx, token, req)
+	if err != nil {
+		return model.PurchaseOrderWithItems{}, failAll(reorderFailureReason(err))
+	}
+
+	items := make([]model.PurchaseOrderItem, 0, len(req.Items))
+	failures := make([]reorderFailure, 0)
+	for _, itemReq := range req.Items {
+		item, err := s.buildOrderItem(ctx, token, order, itemReq, true)
+		if err != nil {
+			s.logger.Error(ctx, "failed to add reorder item to draft purchase order",
+				zap.String("item_id", itemReq.ItemID.String()),
+				zap.String("vendor_id", req.VendorID.String()),
+				zap.Error(err),
+			)
+			failures = append(failures, reorderFailure{itemID: itemReq.ItemID, vendorID: req.VendorID, reason: reorderFailureReason(err)})
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return model.PurchaseOrderWithItems{}, failures
+	}
+
+	created, err := s.storeNewOrder(ctx, order, items)
+	if err != nil {
+		s.logger.Error(ctx, "failed to create draft purchase order for reorder",
+			zap.String("vendor_id", req.VendorID.String()),
+			zap.Int("items", len(items)),
+			zap.Error(err),
+		)
+		return model.PurchaseOrderWithItems{}, failAll(reorderFailureReason(err))
+	}
+
+	return created, failures
+}
+
+func reorderFailureReason(err error) string {
+	switch err {
+	case errors.ErrNotFound:
+		return "not_found"
+	case errors.ErrBadRequest:
+		return "rejected"
+	default:
+		return "error"
+	}
+}
+
+// publishReorderFailed publishes purchase.reorder.failed for the reorder lines no draft order was
+// created for, so that inventory clears their reorder request and reports them again
+func (s *Service) publishReorderFailed(ctx context.Context, failures []reorderFailure) {
+	if len(failures) == 0 {
+		return
+	}
+
+	eventItems := make([]map[string]interface{}, 0, len(failures))
+	for _, failure := range failures {
+		eventItems = append(eventItems, map[string]interface{}{
+			"item_id":   failure.itemID.String(),
+			"vendor_id": failure.vendorID.String(),
+			"reason":    failure.reason,
+		})
+	}
+
+	event := map[string]interface{}{
+		"event_type": "purchase.reorder.failed",
+		"items":      eventItems,
+		"timestamp":  time.Now().Format(time.RFC3339),
+	}
+
+	if err := s.natsClient.Publish("purchase.reorder.failed", event); err != nil {
+		s.logger.Error(ctx, "failed to publish purchase.reorder.failed event", zap.Error(err))
+	}
+}
+
+// hasOpenOrder reports whether a draft order, from any vendor, includes the item. If the check
+// fails the item is treated as covered and logged, rather than risk ordering it twice.
+func (s *Service) hasOpenOrder(ctx context.Context, itemID uuid.UUID) bool {
+	orders, err := s.storage.ListOpenOrdersByItemID(ctx, itemID.String(), 1, 0)
+	if err != nil {
+		s.logger.Error(ctx, "failed to check open purchase orders for reorder item",
+			zap.String("item_id", itemID.String()),
+			zap.Error(err),
+		)
+		return true
+	}
+	return len(orders) > 0
+}
+
+// reorderQuantity returns the quantity to order for a reorder event item, falling back to
+// the shortfall against the reorder point when no reorder quantity is configured.
+func reorderQuantity(itemMap map[string]interface{}) int {
+	if quantity, ok := itemMap["reorder_quantity"].(float64); ok && quantity > 0 {
+		return int(quantity)
+	}
+
+	reorderPoint, _ := itemMap["reorder_point"].(float64)
+	onHand, _ := itemMap["quantity"].(float64)
+	if shortfall := int(reorderPoint) - int(onHand); shortfall > 0 {
+		return shortfall
+	}
+
+	return 1
+}
@@ -0,0 +1,189 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// convertDBCatalogItemToModel converts sqlc generated db.VendorCatalogItem to model.VendorCatalogItem
+func convertDBCatalogItemToModel(dbItem db.VendorCatalogItem) model.VendorCatalogItem {
+	item := model.VendorCatalogItem{
+		ID:               dbItem.ID,
+		VendorID:         dbItem.VendorID,
+		ItemID:           dbItem.ItemID,
+		MinOrderQuantity: int(dbItem.MinOrderQuantity),
+		LeadTimeDays:     int(dbItem.LeadTimeDays),
+		CreatedAt:        dbItem.CreatedAt,
+		UpdatedAt:        dbItem.UpdatedAt,
+	}
+
+	if dbItem.VendorSku.Valid {
+		item.VendorSKU = dbItem.VendorSku.String
+	}
+
+	if cost, err := strconv.ParseFloat(dbItem.Cost, 64); err == nil {
+		item.Cost = cost
+	}
+
+	return item
+}
+
+// convertVendorSKUToNullString converts a vendor SKU to sql.NullString
+func convertVendorSKUToNullString(vendorSKU string) sql.NullString {
+	vendorSKU = strings.TrimSpace(vendorSKU)
+	if vendorSKU == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: vendorSKU, Valid: true}
+}
+
+// convertFilterIDToNullUUID converts an optional filter ID to uuid.NullUUID
+func convertFilterIDToNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func (s *Storage) CreateCatalogItem(ctx context.Context, item model.VendorCatalogItem) error {
+	params := db.CreateCatalogItemParams{
+		ID:               item.ID,
+		VendorID:         item.VendorID,
+		ItemID:           item.ItemID,
+		VendorSku:        convertVendorSKUToNullString(item.VendorSKU),
+		Cost:             strconv.FormatFloat(item.Cost, 'f', 2, 64),
+		MinOrderQuantity: int32(item.MinOrderQuantity),
+		LeadTimeDays:     int32(item.LeadTimeDays),
+		CreatedAt:        item.CreatedAt,
+		UpdatedAt:        item.UpdatedAt,
+	}
+
+	if err := s.queries.CreateCatalogItem(ctx, params); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				return errors.ErrConflict
+			}
+		}
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCatalogItemByID(ctx context.Context, id string) (model.VendorCatalogItem, error) {
+	catalogItemID, err := uuid.Parse(id)
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrBadRequest
+	}
+
+	dbItem, err := s.queries.GetCatalogItemByID(ctx, catalogItemID)
+	if err == sql.ErrNoRows {
+		return model.VendorCatalogItem{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrInternalServerError
+	}
+
+	return convertDBCatalogItemToModel(dbItem), nil
+}
+
+func (s *Storage) GetCatalogItemByVendorAndItem(ctx context.Context, vendorID, itemID string) (model.VendorCatalogItem, error) {
+	vendorUUID, err := uuid.Parse(vendorID)
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrBadRequest
+	}
+	itemUUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrBadRequest
+	}
+
+	params := db.GetCatalogItemByVendorAndItemParams{
+		VendorID: vendorUUID,
+		ItemID:   itemUUID,
+	}
+
+	dbItem, err := s.queries.GetCatalogItemByVendorAndItem(ctx, params)
+	if err == sql.ErrNoRows {
+		return model.VendorCatalogItem{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.VendorCatalogItem{}, errors.ErrInternalServerError
+	}
+
+	return convertDBCatalogItemToModel(dbItem), nil
+}
+
+func (s *Storage) ListCatalogItems(ctx context.Context, filter model.VendorCatalogFilter, limit, offset int) ([]model.VendorCatalogItem, error) {
+	params := db.ListCatalogItemsParams{
+		VendorID: convertFilterIDToNullUUID(filter.VendorID),
+		ItemID:   convertFilterIDToNullUUID(filter.ItemID),
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	}
+
+	dbItems, err := s.queries.ListCatalogItems(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	items := make([]model.VendorCatalogItem, 0, len(dbItems))
+	for _, dbItem := range dbItems {
+		items = append(items, convertDBCatalogItemToModel(dbItem))
+	}
+
+	return items, nil
+}
+
+func (s *Storage) UpdateCatalogItem(ctx context.Context, item model.VendorCatalogItem) error {
+	_, err := s.queries.GetCatalogItemByID(ctx, item.ID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	params := db.UpdateCatalogItemParams{
+		ID:               item.ID,
+		VendorSku:        convertVendorSKUToNullString(item.VendorSKU),
+		Cost:             strconv.FormatFloat(item.Cost, 'f', 2, 64),
+		MinOrderQuantity: int32(item.MinOrderQuantity),
+		LeadTimeDays:     int32(item.LeadTimeDays),
+		UpdatedAt:        item.UpdatedAt,
+	}
+
+	if err := s.queries.UpdateCatalogItem(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCatalogItem(ctx context.Context, id string) error {
+	catalogItemID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	_, err = s.queries.GetCatalogItemByID(ctx, catalogItemID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := s.queries.DeleteCatalogItem(ctx, catalogItemID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -5,27 +5,117 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type DebitNote struct {
+	ID        uuid.UUID `json:"id"`
+	VendorID  uuid.UUID `json:"vendor_id"`
+	OrderID   uuid.UUID `json:"order_id"`
+	ReturnID  uuid.UUID `json:"return_id"`
+	Amount    string    `json:"amount"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type PurchaseOrder struct {
-	ID          uuid.UUID `json:"id"`
-	VendorID    uuid.UUID `json:"vendor_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   uuid.UUID     `json:"id"`
+	VendorID             uuid.UUID     `json:"vendor_id"`
+	Status               string        `json:"status"`
+	TotalAmount          string        `json:"total_amount"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+	ExpectedDeliveryDate sql.NullTime  `json:"expected_delivery_date"`
+	ReceivedAt           sql.NullTime  `json:"received_at"`
+	OverdueNotifiedAt    sql.NullTime  `json:"overdue_notified_at"`
+	WarehouseID          uuid.NullUUID `json:"warehouse_id"`
+}
+
+type PurchaseOrderCharge struct {
+	ID               uuid.UUID      `json:"id"`
+	OrderID          uuid.UUID      `json:"order_id"`
+	ChargeType       string         `json:"charge_type"`
+	Description      sql.NullString `json:"description"`
+	Amount           string         `json:"amount"`
+	AllocationMethod string         `json:"allocation_method"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
 }
 
 type PurchaseOrderItem struct {
+	ID                   uuid.UUID      `json:"id"`
+	OrderID              uuid.UUID      `json:"order_id"`
+	ItemID               uuid.UUID      `json:"item_id"`
+	Quantity             int32          `json:"quantity"`
+	UnitPrice            string         `json:"unit_price"`
+	Subtotal             string         `json:"subtotal"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	UnitWeight           string         `json:"unit_weight"`
+	LandedUnitCost       sql.NullString `json:"landed_unit_cost"`
+	ExpectedDeliveryDate sql.NullTime   `json:"expected_delivery_date"`
+	Unit                 string         `json:"unit"`
+	ConversionFactor     int32          `json:"conversion_factor"`
+	BaseQuantity         int32          `json:"base_quantity"`
+}
+
+type PurchaseOrderLot struct {
+	ID         uuid.UUID    `json:"id"`
+	OrderID    uuid.UUID    `json:"order_id"`
+	ItemID     uuid.UUID    `json:"item_id"`
+	LotNumber  string       `json:"lot_number"`
+	ExpiryDate sql.NullTime `json:"expiry_date"`
+	Quantity   int32        `json:"quantity"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type PurchaseOrderSerial struct {
+	ID           uuid.UUID `json:"id"`
+	OrderID      uuid.UUID `json:"order_id"`
+	ItemID       uuid.UUID `json:"item_id"`
+	SerialNumber string    `json:"serial_number"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PurchaseReturn struct {
+	ID          uuid.UUID      `json:"id"`
+	OrderID     uuid.UUID      `json:"order_id"`
+	VendorID    uuid.UUID      `json:"vendor_id"`
+	Reason      sql.NullString `json:"reason"`
+	TotalAmount string         `json:"total_amount"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type PurchaseReturnItem struct {
 	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
+	ReturnID  uuid.UUID `json:"return_id"`
 	ItemID    uuid.UUID `json:"item_id"`
 	Quantity  int32     `json:"quantity"`
 	UnitPrice string    `json:"unit_price"`
 	Subtotal  string    `json:"subtotal"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PurchaseReturnSerial struct {
+	ID           uuid.UUID `json:"id"`
+	ReturnID     uuid.UUID `json:"return_id"`
+	ItemID       uuid.UUID `json:"item_id"`
+	SerialNumber string    `json:"serial_number"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type VendorCatalogItem struct {
+	ID               uuid.UUID      `json:"id"`
+	VendorID         uuid.UUID      `json:"vendor_id"`
+	ItemID           uuid.UUID      `json:"item_id"`
+	VendorSku        sql.NullString `json:"vendor_sku"`
+	Cost             string         `json:"cost"`
+	MinOrderQuantity int32          `json:"min_order_quantity"`
+	LeadTimeDays     int32          `json:"lead_time_days"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
 }
@@ -7,25 +7,31 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const createOrderItem = `-- name: CreateOrderItem :exec
-INSERT INTO purchase_order_items (id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO purchase_order_items (id, order_id, item_id, quantity, unit_price, subtotal, unit_weight, expected_delivery_date, unit, conversion_factor, base_quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 `
 
 type CreateOrderItemParams struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
-	UnitPrice string    `json:"unit_price"`
-	Subtotal  string    `json:"subtotal"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                   uuid.UUID    `json:"id"`
+	OrderID              uuid.UUID    `json:"order_id"`
+	ItemID               uuid.UUID    `json:"item_id"`
+	Quantity             int32        `json:"quantity"`
+	UnitPrice            string       `json:"unit_price"`
+	Subtotal             string       `json:"subtotal"`
+	UnitWeight           string       `json:"unit_weight"`
+	ExpectedDeliveryDate sql.NullTime `json:"expected_delivery_date"`
+	Unit                 string       `json:"unit"`
+	ConversionFactor     int32        `json:"conversion_factor"`
+	BaseQuantity         int32        `json:"base_quantity"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error {
@@ -36,6 +42,11 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Quantity,
 		arg.UnitPrice,
 		arg.Subtotal,
+		arg.UnitWeight,
+		arg.ExpectedDeliveryDate,
+		arg.Unit,
+		arg.ConversionFactor,
+		arg.BaseQuantity,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -53,7 +64,7 @@ func (q *Queries) DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UU
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at
+SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at, unit_weight, landed_unit_cost, expected_delivery_date, unit, conversion_factor, base_quantity
 FROM purchase_order_items
 WHERE order_id = $1
 ORDER BY created_at ASC
@@ -77,6 +88,12 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.Subtotal,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UnitWeight,
+			&i.LandedUnitCost,
+			&i.ExpectedDeliveryDate,
+			&i.Unit,
+			&i.ConversionFactor,
+			&i.BaseQuantity,
 		); err != nil {
 			return nil, err
 		}
@@ -90,3 +107,20 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 	}
 	return items, nil
 }
+
+const updateOrderItemLandedCost = `-- name: UpdateOrderItemLandedCost :exec
+UPDATE purchase_order_items
+SET landed_unit_cost = $2, updated_at = $3
+WHERE id = $1
+`
+
+type UpdateOrderItemLandedCostParams struct {
+	ID             uuid.UUID      `json:"id"`
+	LandedUnitCost sql.NullString `json:"landed_unit_cost"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+func (q *Queries) UpdateOrderItemLandedCost(ctx context.Context, arg UpdateOrderItemLandedCostParams) error {
+	_, err := q.db.ExecContext(ctx, updateOrderItemLandedCost, arg.ID, arg.LandedUnitCost, arg.UpdatedAt)
+	return err
+}
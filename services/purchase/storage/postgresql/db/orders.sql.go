@@ -7,23 +7,26 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const createOrder = `-- name: CreateOrder :exec
-INSERT INTO purchase_orders (id, vendor_id, status, total_amount, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO purchase_orders (id, vendor_id, status, total_amount, expected_delivery_date, created_at, updated_at, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateOrderParams struct {
-	ID          uuid.UUID `json:"id"`
-	VendorID    uuid.UUID `json:"vendor_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   uuid.UUID     `json:"id"`
+	VendorID             uuid.UUID     `json:"vendor_id"`
+	Status               string        `json:"status"`
+	TotalAmount          string        `json:"total_amount"`
+	ExpectedDeliveryDate sql.NullTime  `json:"expected_delivery_date"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+	WarehouseID          uuid.NullUUID `json:"warehouse_id"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error {
@@ -32,14 +35,16 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error
 		arg.VendorID,
 		arg.Status,
 		arg.TotalAmount,
+		arg.ExpectedDeliveryDate,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.WarehouseID,
 	)
 	return err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, vendor_id, status, total_amount, created_at, updated_at
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
 FROM purchase_orders
 WHERE id = $1
 `
@@ -54,12 +59,124 @@ func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (PurchaseOrder
 		&i.TotalAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ExpectedDeliveryDate,
+		&i.ReceivedAt,
+		&i.OverdueNotifiedAt,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
+const getOrderByIDForUpdate = `-- name: GetOrderByIDForUpdate :one
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
+FROM purchase_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetOrderByIDForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrder, error) {
+	row := q.db.QueryRowContext(ctx, getOrderByIDForUpdate, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.VendorID,
+		&i.Status,
+		&i.TotalAmount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpectedDeliveryDate,
+		&i.ReceivedAt,
+		&i.OverdueNotifiedAt,
+		&i.WarehouseID,
+	)
+	return i, err
+}
+
+const getVendorDeliveryStats = `-- name: GetVendorDeliveryStats :one
+SELECT
+    COUNT(*) AS received_orders,
+    COUNT(*) FILTER (WHERE received_at::date <= expected_delivery_date) AS on_time_orders,
+    COUNT(*) FILTER (WHERE received_at::date > expected_delivery_date) AS late_orders,
+    COALESCE(AVG(received_at::date - expected_delivery_date) FILTER (WHERE received_at::date > expected_delivery_date), 0)::numeric AS average_days_late
+FROM purchase_orders
+WHERE vendor_id = $1
+  AND received_at IS NOT NULL
+  AND expected_delivery_date IS NOT NULL
+`
+
+type GetVendorDeliveryStatsRow struct {
+	ReceivedOrders  int64  `json:"received_orders"`
+	OnTimeOrders    int64  `json:"on_time_orders"`
+	LateOrders      int64  `json:"late_orders"`
+	AverageDaysLate string `json:"average_days_late"`
+}
+
+func (q *Queries) GetVendorDeliveryStats(ctx context.Context, vendorID uuid.UUID) (GetVendorDeliveryStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getVendorDeliveryStats, vendorID)
+	var i GetVendorDeliveryStatsRow
+	err := row.Scan(
+		&i.ReceivedOrders,
+		&i.OnTimeOrders,
+		&i.LateOrders,
+		&i.AverageDaysLate,
+	)
+	return i, err
+}
+
+const listOpenOrdersByItemID = `-- name: ListOpenOrdersByItemID :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND EXISTS (
+    SELECT 1 FROM purchase_order_items poi
+    WHERE poi.order_id = po.id AND poi.item_id = $1
+  )
+ORDER BY po.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListOpenOrdersByItemIDParams struct {
+	ItemID uuid.UUID `json:"item_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+func (q *Queries) ListOpenOrdersByItemID(ctx context.Context, arg ListOpenOrdersByItemIDParams) ([]PurchaseOrder, error) {
+	rows, err := q.db.QueryContext(ctx, listOpenOrdersByItemID, arg.ItemID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrder{}
+	for rows.Next() {
+		var i PurchaseOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.VendorID,
+			&i.Status,
+			&i.TotalAmount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpectedDeliveryDate,
+			&i.ReceivedAt,
+			&i.OverdueNotifiedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrders = `-- name: ListOrders :many
-SELECT id, vendor_id, status, total_amount, created_at, updated_at
+SELECT id, vendor_id, status, total_amount, created_at, updated_at, expected_delivery_date, received_at, overdue_notified_at, warehouse_id
 FROM purchase_orders
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -86,6 +203,59 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Purch
 			&i.TotalAmount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ExpectedDeliveryDate,
+			&i.ReceivedAt,
+			&i.OverdueNotifiedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrdersPendingOverdueNotice = `-- name: ListOrdersPendingOverdueNotice :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND po.overdue_notified_at IS NULL
+  AND (
+    po.expected_delivery_date < $1::date
+    OR EXISTS (
+      SELECT 1 FROM purchase_order_items poi
+      WHERE poi.order_id = po.id AND poi.expected_delivery_date < $1::date
+    )
+  )
+ORDER BY po.created_at ASC
+`
+
+func (q *Queries) ListOrdersPendingOverdueNotice(ctx context.Context, asOf time.Time) ([]PurchaseOrder, error) {
+	rows, err := q.db.QueryContext(ctx, listOrdersPendingOverdueNotice, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrder{}
+	for rows.Next() {
+		var i PurchaseOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.VendorID,
+			&i.Status,
+			&i.TotalAmount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpectedDeliveryDate,
+			&i.ReceivedAt,
+			&i.OverdueNotifiedAt,
+			&i.WarehouseID,
 		); err != nil {
 			return nil, err
 		}
@@ -100,21 +270,130 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Purch
 	return items, nil
 }
 
+const listOverdueOrders = `-- name: ListOverdueOrders :many
+SELECT po.id, po.vendor_id, po.status, po.total_amount, po.created_at, po.updated_at, po.expected_delivery_date, po.received_at, po.overdue_notified_at, po.warehouse_id
+FROM purchase_orders po
+WHERE po.status = 'Draft'
+  AND (
+    po.expected_delivery_date < $1::date
+    OR EXISTS (
+      SELECT 1 FROM purchase_order_items poi
+      WHERE poi.order_id = po.id AND poi.expected_delivery_date < $1::date
+    )
+  )
+ORDER BY po.expected_delivery_date ASC NULLS LAST, po.created_at ASC
+LIMIT $2 OFFSET $3
+`
+
+type ListOverdueOrdersParams struct {
+	AsOf   time.Time `json:"as_of"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+func (q *Queries) ListOverdueOrders(ctx context.Context, arg ListOverdueOrdersParams) ([]PurchaseOrder, error) {
+	rows, err := q.db.QueryContext(ctx, listOverdueOrders, arg.AsOf, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrder{}
+	for rows.Next() {
+		var i PurchaseOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.VendorID,
+			&i.Status,
+			&i.TotalAmount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpectedDeliveryDate,
+			&i.ReceivedAt,
+			&i.OverdueNotifiedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOrderOverdueNotified = `-- name: MarkOrderOverdueNotified :exec
+UPDATE purchase_orders
+SET overdue_notified_at = $2::timestamp
+WHERE id = $1
+`
+
+type MarkOrderOverdueNotifiedParams struct {
+	ID         uuid.UUID `json:"id"`
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+func (q *Queries) MarkOrderOverdueNotified(ctx context.Context, arg MarkOrderOverdueNotifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markOrderOverdueNotified, arg.ID, arg.NotifiedAt)
+	return err
+}
+
+const cancelDraftOrder = `-- name: CancelDraftOrder :execrows
+UPDATE purchase_orders
+SET status = 'Cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'Draft'
+`
+
+func (q *Queries) CancelDraftOrder(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelDraftOrder, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markOrderReceived = `-- name: MarkOrderReceived :exec
+UPDATE purchase_orders
+SET status = 'Received',
+    received_at = $2::timestamp,
+    updated_at = $2::timestamp
+WHERE id = $1
+`
+
+type MarkOrderReceivedParams struct {
+	ID         uuid.UUID `json:"id"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+func (q *Queries) MarkOrderReceived(ctx context.Context, arg MarkOrderReceivedParams) error {
+	_, err := q.db.ExecContext(ctx, markOrderReceived, arg.ID, arg.ReceivedAt)
+	return err
+}
+
 const updateOrder = `-- name: UpdateOrder :exec
 UPDATE purchase_orders
 SET vendor_id = $2,
     status = $3,
     total_amount = $4,
-    updated_at = $5
+    expected_delivery_date = $5,
+    overdue_notified_at = NULL,
+    updated_at = $6,
+    warehouse_id = $7
 WHERE id = $1
 `
 
 type UpdateOrderParams struct {
-	ID          uuid.UUID `json:"id"`
-	VendorID    uuid.UUID `json:"vendor_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   uuid.UUID     `json:"id"`
+	VendorID             uuid.UUID     `json:"vendor_id"`
+	Status               string        `json:"status"`
+	TotalAmount          string        `json:"total_amount"`
+	ExpectedDeliveryDate sql.NullTime  `json:"expected_delivery_date"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+	WarehouseID          uuid.NullUUID `json:"warehouse_id"`
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) error {
@@ -123,7 +402,9 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) error
 		arg.VendorID,
 		arg.Status,
 		arg.TotalAmount,
+		arg.ExpectedDeliveryDate,
 		arg.UpdatedAt,
+		arg.WarehouseID,
 	)
 	return err
 }
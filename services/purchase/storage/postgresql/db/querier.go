@@ -6,18 +6,54 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	CancelDraftOrder(ctx context.Context, id uuid.UUID) (int64, error)
+	CreateCatalogItem(ctx context.Context, arg CreateCatalogItemParams) error
+	CreateDebitNote(ctx context.Context, arg CreateDebitNoteParams) error
 	CreateOrder(ctx context.Context, arg CreateOrderParams) error
+	CreateOrderCharge(ctx context.Context, arg CreateOrderChargeParams) error
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error
+	CreateOrderLot(ctx context.Context, arg CreateOrderLotParams) error
+	CreateOrderSerial(ctx context.Context, arg CreateOrderSerialParams) error
+	CreateReturn(ctx context.Context, arg CreateReturnParams) error
+	CreateReturnItem(ctx context.Context, arg CreateReturnItemParams) error
+	CreateReturnSerial(ctx context.Context, arg CreateReturnSerialParams) error
+	DeleteCatalogItem(ctx context.Context, id uuid.UUID) error
+	DeleteOrderCharge(ctx context.Context, id uuid.UUID) error
 	DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) error
+	GetCatalogItemByID(ctx context.Context, id uuid.UUID) (VendorCatalogItem, error)
+	GetCatalogItemByVendorAndItem(ctx context.Context, arg GetCatalogItemByVendorAndItemParams) (VendorCatalogItem, error)
+	GetDebitNoteByReturnID(ctx context.Context, returnID uuid.UUID) (DebitNote, error)
 	GetOrderByID(ctx context.Context, id uuid.UUID) (PurchaseOrder, error)
+	GetOrderByIDForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrder, error)
+	GetOrderChargeByID(ctx context.Context, id uuid.UUID) (PurchaseOrderCharge, error)
 	GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseOrderItem, error)
+	GetReturnItemsByReturnID(ctx context.Context, returnID uuid.UUID) ([]PurchaseReturnItem, error)
+	GetReturnedQuantitiesByOrderID(ctx context.Context, orderID uuid.UUID) ([]GetReturnedQuantitiesByOrderIDRow, error)
+	GetVendorBalance(ctx context.Context, vendorID uuid.UUID) (GetVendorBalanceRow, error)
+	GetVendorDeliveryStats(ctx context.Context, vendorID uuid.UUID) (GetVendorDeliveryStatsRow, error)
+	ListCatalogItems(ctx context.Context, arg ListCatalogItemsParams) ([]VendorCatalogItem, error)
+	ListDebitNotes(ctx context.Context, arg ListDebitNotesParams) ([]DebitNote, error)
+	ListOpenOrdersByItemID(ctx context.Context, arg ListOpenOrdersByItemIDParams) ([]PurchaseOrder, error)
+	ListOrderChargesByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseOrderCharge, error)
+	ListOrderLotsByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseOrderLot, error)
+	ListOrderSerialsByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseOrderSerial, error)
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]PurchaseOrder, error)
+	ListOrdersPendingOverdueNotice(ctx context.Context, asOf time.Time) ([]PurchaseOrder, error)
+	ListOverdueOrders(ctx context.Context, arg ListOverdueOrdersParams) ([]PurchaseOrder, error)
+	ListReturnSerialsByReturnID(ctx context.Context, returnID uuid.UUID) ([]PurchaseReturnSerial, error)
+	ListReturnedSerialsByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseReturnSerial, error)
+	ListReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]PurchaseReturn, error)
+	MarkOrderOverdueNotified(ctx context.Context, arg MarkOrderOverdueNotifiedParams) error
+	MarkOrderReceived(ctx context.Context, arg MarkOrderReceivedParams) error
+	UpdateCatalogItem(ctx context.Context, arg UpdateCatalogItemParams) error
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) error
+	UpdateOrderItemLandedCost(ctx context.Context, arg UpdateOrderItemLandedCostParams) error
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
 }
 
@@ -0,0 +1,90 @@
+package postgresql
+
+import (
+	"context"
+	"math"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) ListOverdueOrders(ctx context.Context, asOf time.Time, limit, offset int) ([]model.PurchaseOrder, error) {
+	params := db.ListOverdueOrdersParams{
+		AsOf:   asOf,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	dbOrders, err := s.queries.ListOverdueOrders(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	orders := make([]model.PurchaseOrder, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orders = append(orders, convertDBOrderToModel(dbOrder))
+	}
+
+	return orders, nil
+}
+
+func (s *Storage) ListOrdersPendingOverdueNotice(ctx context.Context, asOf time.Time) ([]model.PurchaseOrder, error) {
+	dbOrders, err := s.queries.ListOrdersPendingOverdueNotice(ctx, asOf)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	orders := make([]model.PurchaseOrder, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orders = append(orders, convertDBOrderToModel(dbOrder))
+	}
+
+	return orders, nil
+}
+
+func (s *Storage) MarkOrderOverdueNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) error {
+	params := db.MarkOrderOverdueNotifiedParams{
+		ID:         id,
+		NotifiedAt: notifiedAt,
+	}
+
+	if err := s.queries.MarkOrderOverdueNotified(ctx, params); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) GetVendorDeliveryPerformance(ctx context.Context, vendorID string) (model.VendorDeliveryPerformance, error) {
+	vendorUUID, err := uuid.Parse(vendorID)
+	if err != nil {
+		return model.VendorDeliveryPerformance{}, errors.ErrBadRequest
+	}
+
+	row, err := s.queries.GetVendorDeliveryStats(ctx, vendorUUID)
+	if err != nil {
+		return model.VendorDeliveryPerformance{}, errors.ErrInternalServerError
+	}
+
+	performance := model.VendorDeliveryPerformance{
+		VendorID:       vendorUUID,
+		ReceivedOrders: int(row.ReceivedOrders),
+		OnTimeOrders:   int(row.OnTimeOrders),
+		LateOrders:     int(row.LateOrders),
+	}
+
+	if averageDaysLate, err := strconv.ParseFloat(row.AverageDaysLate, 64); err == nil {
+		performance.AverageDaysLate = math.Round(averageDaysLate*100) / 100
+	}
+
+	if performance.ReceivedOrders > 0 {
+		rate := float64(performance.OnTimeOrders) / float64(performance.ReceivedOrders) * 100
+		performance.OnTimeRate = math.Round(rate*100) / 100
+	}
+
+	return performance, nil
+}
@@ -0,0 +1,192 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// convertDBChargeToModel converts sqlc generated db.PurchaseOrderCharge to model.LandedCostCharge
+func convertDBChargeToModel(dbCharge db.PurchaseOrderCharge) model.LandedCostCharge {
+	charge := model.LandedCostCharge{
+		ID:               dbCharge.ID,
+		OrderID:          dbCharge.OrderID,
+		ChargeType:       model.LandedCostChargeType(dbCharge.ChargeType),
+		AllocationMethod: model.AllocationMethod(dbCharge.AllocationMethod),
+		CreatedAt:        dbCharge.CreatedAt,
+		UpdatedAt:        dbCharge.UpdatedAt,
+	}
+
+	if dbCharge.Description.Valid {
+		charge.Description = dbCharge.Description.String
+	}
+
+	if amount, err := strconv.ParseFloat(dbCharge.Amount, 64); err == nil {
+		charge.Amount = amount
+	}
+
+	return charge
+}
+
+// convertModelChargeToCreateParams converts model.LandedCostCharge to sqlc CreateOrderChargeParams
+func convertModelChargeToCreateParams(charge model.LandedCostCharge) db.CreateOrderChargeParams {
+	params := db.CreateOrderChargeParams{
+		ID:               charge.ID,
+		OrderID:          charge.OrderID,
+		ChargeType:       string(charge.ChargeType),
+		Amount:           strconv.FormatFloat(charge.Amount, 'f', 2, 64),
+		AllocationMethod: string(charge.AllocationMethod),
+		CreatedAt:        charge.CreatedAt,
+		UpdatedAt:        charge.UpdatedAt,
+	}
+
+	if charge.Description != "" {
+		params.Description = sql.NullString{String: charge.Description, Valid: true}
+	}
+
+	return params
+}
+
+func (s *Storage) CreateOrderCharge(ctx context.Context, charge model.LandedCostCharge) error {
+	if err := s.queries.CreateOrderCharge(ctx, convertModelChargeToCreateParams(charge)); err != nil {
+		return errors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *Storage) GetOrderChargeByID(ctx context.Context, id string) (model.LandedCostCharge, error) {
+	chargeID, err := uuid.Parse(id)
+	if err != nil {
+		return model.LandedCostCharge{}, errors.ErrBadRequest
+	}
+
+	dbCharge, err := s.queries.GetOrderChargeByID(ctx, chargeID)
+	if err == sql.ErrNoRows {
+		return model.LandedCostCharge{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return model.LandedCostCharge{}, errors.ErrInternalServerError
+	}
+
+	return convertDBChargeToModel(dbCharge), nil
+}
+
+func (s *Storage) ListOrderChargesByOrderID(ctx context.Context, orderID string) ([]model.LandedCostCharge, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbCharges, err := s.queries.ListOrderChargesByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	charges := make([]model.LandedCostCharge, 0, len(dbCharges))
+	for _, dbCharge := range dbCharges {
+		charges = append(charges, convertDBChargeToModel(dbCharge))
+	}
+
+	return charges, nil
+}
+
+func (s *Storage) DeleteOrderCharge(ctx context.Context, id string) error {
+	chargeID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	if err := s.queries.DeleteOrderCharge(ctx, chargeID); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ReceiveOrder stores the charges added at receipt, the allocated landed unit cost of every
+// line and the Received status with its receipt time in a single transaction. The order is
+// locked and must still be a draft, so of two concurrent receipts or a receipt racing a
+// cancel only one goes through; the other gets ErrConflict.
+func (s *Storage) ReceiveOrder(ctx context.Context, orderID string, receivedAt time.Time, charges []model.LandedCostCharge, items []model.PurchaseOrderItem, lots []model.ReceivedLot, serials []model.ReceivedSerial) error {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	order, err := qtx.GetOrderByIDForUpdate(ctx, orderUUID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if model.PurchaseOrderStatus(order.Status) != model.PurchaseOrderStatusDraft {
+		return errors.ErrConflict
+	}
+
+	for _, charge := range charges {
+		if err := qtx.CreateOrderCharge(ctx, convertModelChargeToCreateParams(charge)); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	for _, item := range items {
+		params := db.UpdateOrderItemLandedCostParams{
+			ID:        item.ID,
+			UpdatedAt: time.Now(),
+		}
+		if item.LandedUnitCost != nil {
+			params.LandedUnitCost = sql.NullString{String: strconv.FormatFloat(*item.LandedUnitCost, 'f', 4, 64), Valid: true}
+		}
+		if err := qtx.UpdateOrderItemLandedCost(ctx, params); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	for _, lot := range lots {
+		if err := qtx.CreateOrderLot(ctx, convertModelLotToCreateParams(lot)); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	for _, serial := range serials {
+		serialParams := db.CreateOrderSerialParams{
+			ID:           serial.ID,
+			OrderID:      serial.OrderID,
+			ItemID:       serial.ItemID,
+			SerialNumber: serial.SerialNumber,
+			CreatedAt:    serial.CreatedAt,
+		}
+		if err := qtx.CreateOrderSerial(ctx, serialParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	receivedParams := db.MarkOrderReceivedParams{
+		ID:         orderUUID,
+		ReceivedAt: receivedAt,
+	}
+	if err := qtx.MarkOrderReceived(ctx, receivedParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
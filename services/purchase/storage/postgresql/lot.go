@@ -0,0 +1,55 @@
+package postgresql
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+
+	"github.com/google/uuid"
+)
+
+// convertDBLotToModel converts sqlc generated db.PurchaseOrderLot to model.ReceivedLot
+func convertDBLotToModel(dbLot db.PurchaseOrderLot) model.ReceivedLot {
+	return model.ReceivedLot{
+		ID:         dbLot.ID,
+		OrderID:    dbLot.OrderID,
+		ItemID:     dbLot.ItemID,
+		LotNumber:  dbLot.LotNumber,
+		ExpiryDate: convertNullTimeToPtr(dbLot.ExpiryDate),
+		Quantity:   int(dbLot.Quantity),
+		CreatedAt:  dbLot.CreatedAt,
+	}
+}
+
+// convertModelLotToCreateParams converts model.ReceivedLot to sqlc CreateOrderLotParams
+func convertModelLotToCreateParams(lot model.ReceivedLot) db.CreateOrderLotParams {
+	return db.CreateOrderLotParams{
+		ID:         lot.ID,
+		OrderID:    lot.OrderID,
+		ItemID:     lot.ItemID,
+		LotNumber:  lot.LotNumber,
+		ExpiryDate: convertPtrToNullTime(lot.ExpiryDate),
+		Quantity:   int32(lot.Quantity),
+		CreatedAt:  lot.CreatedAt,
+	}
+}
+
+func (s *Storage) ListOrderLotsByOrderID(ctx context.Context, orderID string) ([]model.ReceivedLot, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbLots, err := s.queries.ListOrderLotsByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	lots := make([]model.ReceivedLot, 0, len(dbLots))
+	for _, dbLot := range dbLots {
+		lots = append(lots, convertDBLotToModel(dbLot))
+	}
+
+	return lots, nil
+}
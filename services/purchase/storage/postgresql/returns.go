@@ -0,0 +1,338 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// convertDBReturnToModel converts sqlc generated db.PurchaseReturn to model.PurchaseReturn
+func convertDBReturnToModel(dbReturn db.PurchaseReturn) model.PurchaseReturn {
+	ret := model.PurchaseReturn{
+		ID:        dbReturn.ID,
+		OrderID:   dbReturn.OrderID,
+		VendorID:  dbReturn.VendorID,
+		CreatedAt: dbReturn.CreatedAt,
+	}
+
+	if dbReturn.Reason.Valid {
+		ret.Reason = dbReturn.Reason.String
+	}
+
+	if totalAmount, err := strconv.ParseFloat(dbReturn.TotalAmount, 64); err == nil {
+		ret.TotalAmount = totalAmount
+	}
+
+	return ret
+}
+
+// convertDBReturnItemToModel converts sqlc generated db.PurchaseReturnItem to model.PurchaseReturnItem
+func convertDBReturnItemToModel(dbItem db.PurchaseReturnItem) model.PurchaseReturnItem {
+	item := model.PurchaseReturnItem{
+		ID:        dbItem.ID,
+		ReturnID:  dbItem.ReturnID,
+		ItemID:    dbItem.ItemID,
+		Quantity:  int(dbItem.Quantity),
+		CreatedAt: dbItem.CreatedAt,
+	}
+
+	if unitPrice, err := strconv.ParseFloat(dbItem.UnitPrice, 64); err == nil {
+		item.UnitPrice = unitPrice
+	}
+	if subtotal, err := strconv.ParseFloat(dbItem.Subtotal, 64); err == nil {
+		item.Subtotal = subtotal
+	}
+
+	return item
+}
+
+// convertDBDebitNoteToModel converts sqlc generated db.DebitNote to model.DebitNote
+func convertDBDebitNoteToModel(dbNote db.DebitNote) model.DebitNote {
+	note := model.DebitNote{
+		ID:        dbNote.ID,
+		VendorID:  dbNote.VendorID,
+		OrderID:   dbNote.OrderID,
+		ReturnID:  dbNote.ReturnID,
+		Status:    model.DebitNoteStatus(dbNote.Status),
+		CreatedAt: dbNote.CreatedAt,
+		UpdatedAt: dbNote.UpdatedAt,
+	}
+
+	if amount, err := strconv.ParseFloat(dbNote.Amount, 64); err == nil {
+		note.Amount = amount
+	}
+
+	return note
+}
+
+func (s *Storage) CreateReturn(ctx context.Context, ret model.PurchaseReturnWithItems) error {
+	if ret.DebitNote == nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	// Lock the order so that concurrent returns against it are serialized, then
+	// re-check the returned quantities and serials against what is committed.
+	if err := checkReturnAgainstOrder(ctx, qtx, ret); err != nil {
+		return err
+	}
+
+	returnParams := db.CreateReturnParams{
+		ID:          ret.ID,
+		OrderID:     ret.OrderID,
+		VendorID:    ret.VendorID,
+		TotalAmount: strconv.FormatFloat(ret.TotalAmount, 'f', 2, 64),
+		CreatedAt:   ret.CreatedAt,
+	}
+	if ret.Reason != "" {
+		returnParams.Reason = sql.NullString{String: ret.Reason, Valid: true}
+	}
+
+	if err := qtx.CreateReturn(ctx, returnParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	for _, item := range ret.Items {
+		itemParams := db.CreateReturnItemParams{
+			ID:        item.ID,
+			ReturnID:  item.ReturnID,
+			ItemID:    item.ItemID,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+			Subtotal:  strconv.FormatFloat(item.Subtotal, 'f', 2, 64),
+			CreatedAt: item.CreatedAt,
+		}
+		if err := qtx.CreateReturnItem(ctx, itemParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	for _, serial := range ret.Serials {
+		serialParams := db.CreateReturnSerialParams{
+			ID:           serial.ID,
+			ReturnID:     serial.ReturnID,
+			ItemID:       serial.ItemID,
+			SerialNumber: serial.SerialNumber,
+			CreatedAt:    serial.CreatedAt,
+		}
+		if err := qtx.CreateReturnSerial(ctx, serialParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	noteParams := db.CreateDebitNoteParams{
+		ID:        ret.DebitNote.ID,
+		VendorID:  ret.DebitNote.VendorID,
+		OrderID:   ret.DebitNote.OrderID,
+		ReturnID:  ret.DebitNote.ReturnID,
+		Amount:    strconv.FormatFloat(ret.DebitNote.Amount, 'f', 2, 64),
+		Status:    string(ret.DebitNote.Status),
+		CreatedAt: ret.DebitNote.CreatedAt,
+		UpdatedAt: ret.DebitNote.UpdatedAt,
+	}
+	if err := qtx.CreateDebitNote(ctx, noteParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// checkReturnAgainstOrder locks the purchase order and verifies inside the return
+// transaction that the order can still take the return: it must be received or paid,
+// no item may be returned beyond its received base quantity and no serial number may
+// be returned twice.
+func checkReturnAgainstOrder(ctx context.Context, qtx *db.Queries, ret model.PurchaseReturnWithItems) error {
+	order, err := qtx.GetOrderByIDForUpdate(ctx, ret.OrderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		return errors.ErrInternalServerError
+	}
+
+	status := model.PurchaseOrderStatus(order.Status)
+	if status != model.PurchaseOrderStatusReceived && status != model.PurchaseOrderStatusPaid {
+		return errors.ErrBadRequest
+	}
+
+	orderItems, err := qtx.GetOrderItemsByOrderID(ctx, ret.OrderID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	receivedQuantities := make(map[uuid.UUID]int)
+	for _, item := range orderItems {
+		receivedQuantities[item.ItemID] += int(item.BaseQuantity)
+	}
+
+	rows, err := qtx.GetReturnedQuantitiesByOrderID(ctx, ret.OrderID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	returnedQuantities := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		returnedQuantities[row.ItemID] = int(row.Quantity)
+	}
+
+	for _, item := range ret.Items {
+		returnedQuantities[item.ItemID] += item.Quantity
+		if returnedQuantities[item.ItemID] > receivedQuantities[item.ItemID] {
+			return errors.ErrBadRequest
+		}
+	}
+
+	if len(ret.Serials) == 0 {
+		return nil
+	}
+
+	returnedSerials, err := qtx.ListReturnedSerialsByOrderID(ctx, ret.OrderID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	returned := make(map[uuid.UUID]map[string]bool)
+	for _, serial := range returnedSerials {
+		if returned[serial.ItemID] == nil {
+			returned[serial.ItemID] = make(map[string]bool)
+		}
+		returned[serial.ItemID][serial.SerialNumber] = true
+	}
+
+	for _, serial := range ret.Serials {
+		if returned[serial.ItemID][serial.SerialNumber] {
+			return errors.ErrBadRequest
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) ListReturnsByOrderID(ctx context.Context, orderID string) ([]model.PurchaseReturnWithItems, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbReturns, err := s.queries.ListReturnsByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	returns := make([]model.PurchaseReturnWithItems, 0, len(dbReturns))
+	for _, dbReturn := range dbReturns {
+		dbItems, err := s.queries.GetReturnItemsByReturnID(ctx, dbReturn.ID)
+		if err != nil {
+			return nil, errors.ErrInternalServerError
+		}
+
+		items := make([]model.PurchaseReturnItem, 0, len(dbItems))
+		for _, dbItem := range dbItems {
+			items = append(items, convertDBReturnItemToModel(dbItem))
+		}
+
+		dbSerials, err := s.queries.ListReturnSerialsByReturnID(ctx, dbReturn.ID)
+		if err != nil {
+			return nil, errors.ErrInternalServerError
+		}
+
+		ret := model.PurchaseReturnWithItems{
+			PurchaseReturn: convertDBReturnToModel(dbReturn),
+			Items:          items,
+		}
+		for _, dbSerial := range dbSerials {
+			ret.Serials = append(ret.Serials, convertDBReturnSerialToModel(dbSerial))
+		}
+
+		dbNote, err := s.queries.GetDebitNoteByReturnID(ctx, dbReturn.ID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, errors.ErrInternalServerError
+		}
+		if err == nil {
+			note := convertDBDebitNoteToModel(dbNote)
+			ret.DebitNote = &note
+		}
+
+		returns = append(returns, ret)
+	}
+
+	return returns, nil
+}
+
+func (s *Storage) GetReturnedQuantitiesByOrderID(ctx context.Context, orderID string) (map[uuid.UUID]int, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	rows, err := s.queries.GetReturnedQuantitiesByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	quantities := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		quantities[row.ItemID] = int(row.Quantity)
+	}
+
+	return quantities, nil
+}
+
+func (s *Storage) ListDebitNotes(ctx context.Context, vendorID *uuid.UUID, limit, offset int) ([]model.DebitNote, error) {
+	params := db.ListDebitNotesParams{
+		VendorID: convertFilterIDToNullUUID(vendorID),
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	}
+
+	dbNotes, err := s.queries.ListDebitNotes(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	notes := make([]model.DebitNote, 0, len(dbNotes))
+	for _, dbNote := range dbNotes {
+		notes = append(notes, convertDBDebitNoteToModel(dbNote))
+	}
+
+	return notes, nil
+}
+
+func (s *Storage) GetVendorBalance(ctx context.Context, vendorID string) (model.VendorBalance, error) {
+	vendorUUID, err := uuid.Parse(vendorID)
+	if err != nil {
+		return model.VendorBalance{}, errors.ErrBadRequest
+	}
+
+	row, err := s.queries.GetVendorBalance(ctx, vendorUUID)
+	if err != nil {
+		return model.VendorBalance{}, errors.ErrInternalServerError
+	}
+
+	balance := model.VendorBalance{VendorID: vendorUUID}
+	if payable, err := strconv.ParseFloat(row.PayableAmount, 64); err == nil {
+		balance.PayableAmount = payable
+	}
+	if debit, err := strconv.ParseFloat(row.DebitAmount, 64); err == nil {
+		balance.DebitAmount = debit
+	}
+	balance.Balance = balance.PayableAmount - balance.DebitAmount
+
+	return balance, nil
+}
@@ -0,0 +1,71 @@
+package postgresql
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/services/purchase/model"
+	"microservice-challenge/services/purchase/storage/postgresql/db"
+
+	"github.com/google/uuid"
+)
+
+// convertDBOrderSerialToModel converts sqlc generated db.PurchaseOrderSerial to model.ReceivedSerial
+func convertDBOrderSerialToModel(dbSerial db.PurchaseOrderSerial) model.ReceivedSerial {
+	return model.ReceivedSerial{
+		ID:           dbSerial.ID,
+		OrderID:      dbSerial.OrderID,
+		ItemID:       dbSerial.ItemID,
+		SerialNumber: dbSerial.SerialNumber,
+		CreatedAt:    dbSerial.CreatedAt,
+	}
+}
+
+// convertDBReturnSerialToModel converts sqlc generated db.PurchaseReturnSerial to model.ReturnedSerial
+func convertDBReturnSerialToModel(dbSerial db.PurchaseReturnSerial) model.ReturnedSerial {
+	return model.ReturnedSerial{
+		ID:           dbSerial.ID,
+		ReturnID:     dbSerial.ReturnID,
+		ItemID:       dbSerial.ItemID,
+		SerialNumber: dbSerial.SerialNumber,
+		CreatedAt:    dbSerial.CreatedAt,
+	}
+}
+
+func (s *Storage) ListOrderSerialsByOrderID(ctx context.Context, orderID string) ([]model.ReceivedSerial, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbSerials, err := s.queries.ListOrderSerialsByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials := make([]model.ReceivedSerial, 0, len(dbSerials))
+	for _, dbSerial := range dbSerials {
+		serials = append(serials, convertDBOrderSerialToModel(dbSerial))
+	}
+
+	return serials, nil
+}
+
+// ListReturnedSerialsByOrderID lists the serials sent back on any return against the order
+func (s *Storage) ListReturnedSerialsByOrderID(ctx context.Context, orderID string) ([]model.ReturnedSerial, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbSerials, err := s.queries.ListReturnedSerialsByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials := make([]model.ReturnedSerial, 0, len(dbSerials))
+	for _, dbSerial := range dbSerials {
+		serials = append(serials, convertDBReturnSerialToModel(dbSerial))
+	}
+
+	return serials, nil
+}
@@ -7,6 +7,7 @@ import (
 	"microservice-challenge/services/purchase/model"
 	"microservice-challenge/services/purchase/storage/postgresql/db"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -37,29 +38,41 @@ func convertDBOrderToModel(dbOrder db.PurchaseOrder) model.PurchaseOrder {
 		order.TotalAmount = totalAmount
 	}
 
+	order.ExpectedDeliveryDate = convertNullTimeToPtr(dbOrder.ExpectedDeliveryDate)
+	order.ReceivedAt = convertNullTimeToPtr(dbOrder.ReceivedAt)
+
+	if dbOrder.WarehouseID.Valid {
+		warehouseID := dbOrder.WarehouseID.UUID
+		order.WarehouseID = &warehouseID
+	}
+
 	return order
 }
 
 // convertModelOrderToCreateParams converts model.PurchaseOrder to sqlc CreateOrderParams
 func convertModelOrderToCreateParams(order model.PurchaseOrder) db.CreateOrderParams {
 	return db.CreateOrderParams{
-		ID:          order.ID,
-		VendorID:    order.VendorID,
-		Status:      string(order.Status),
-		TotalAmount: strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:                   order.ID,
+		VendorID:             order.VendorID,
+		Status:               string(order.Status),
+		TotalAmount:          strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
+		ExpectedDeliveryDate: convertPtrToNullTime(order.ExpectedDeliveryDate),
+		CreatedAt:            order.CreatedAt,
+		UpdatedAt:            order.UpdatedAt,
+		WarehouseID:          convertFilterIDToNullUUID(order.WarehouseID),
 	}
 }
 
 // convertModelOrderToUpdateParams converts model.PurchaseOrder to sqlc UpdateOrderParams
 func convertModelOrderToUpdateParams(order model.PurchaseOrder) db.UpdateOrderParams {
 	return db.UpdateOrderParams{
-		ID:          order.ID,
-		VendorID:    order.VendorID,
-		Status:      string(order.Status),
-		TotalAmount: strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
-		UpdatedAt:   order.UpdatedAt,
+		ID:                   order.ID,
+		VendorID:             order.VendorID,
+		Status:               string(order.Status),
+		TotalAmount:          strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
+		ExpectedDeliveryDate: convertPtrToNullTime(order.ExpectedDeliveryDate),
+		UpdatedAt:            order.UpdatedAt,
+		WarehouseID:          convertFilterIDToNullUUID(order.WarehouseID),
 	}
 }
 
@@ -72,6 +85,10 @@ func convertDBOrderItemToModel(dbItem db.PurchaseOrderItem) model.PurchaseOrderI
 		Quantity:  int(dbItem.Quantity),
 		CreatedAt: dbItem.CreatedAt,
 		UpdatedAt: dbItem.UpdatedAt,
+
+		Unit:             dbItem.Unit,
+		ConversionFactor: int(dbItem.ConversionFactor),
+		BaseQuantity:     int(dbItem.BaseQuantity),
 	}
 
 	if unitPrice, err := strconv.ParseFloat(dbItem.UnitPrice, 64); err == nil {
@@ -80,6 +97,16 @@ func convertDBOrderItemToModel(dbItem db.PurchaseOrderItem) model.PurchaseOrderI
 	if subtotal, err := strconv.ParseFloat(dbItem.Subtotal, 64); err == nil {
 		item.Subtotal = subtotal
 	}
+	if unitWeight, err := strconv.ParseFloat(dbItem.UnitWeight, 64); err == nil {
+		item.UnitWeight = unitWeight
+	}
+	if dbItem.LandedUnitCost.Valid {
+		if landedUnitCost, err := strconv.ParseFloat(dbItem.LandedUnitCost.String, 64); err == nil {
+			item.LandedUnitCost = &landedUnitCost
+		}
+	}
+
+	item.ExpectedDeliveryDate = convertNullTimeToPtr(dbItem.ExpectedDeliveryDate)
 
 	return item
 }
@@ -87,15 +114,37 @@ func convertDBOrderItemToModel(dbItem db.PurchaseOrderItem) model.PurchaseOrderI
 // convertModelOrderItemToCreateParams converts model.PurchaseOrderItem to sqlc CreateOrderItemParams
 func convertModelOrderItemToCreateParams(item model.PurchaseOrderItem) db.CreateOrderItemParams {
 	return db.CreateOrderItemParams{
-		ID:        item.ID,
-		OrderID:   item.OrderID,
-		ItemID:    item.ItemID,
-		Quantity:  int32(item.Quantity),
-		UnitPrice: strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
-		Subtotal:  strconv.FormatFloat(item.Subtotal, 'f', 2, 64),
-		CreatedAt: item.CreatedAt,
-		UpdatedAt: item.UpdatedAt,
+		ID:                   item.ID,
+		OrderID:              item.OrderID,
+		ItemID:               item.ItemID,
+		Quantity:             int32(item.Quantity),
+		UnitPrice:            strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+		Subtotal:             strconv.FormatFloat(item.Subtotal, 'f', 2, 64),
+		UnitWeight:           strconv.FormatFloat(item.UnitWeight, 'f', 3, 64),
+		ExpectedDeliveryDate: convertPtrToNullTime(item.ExpectedDeliveryDate),
+		Unit:                 item.Unit,
+		ConversionFactor:     int32(item.ConversionFactor),
+		BaseQuantity:         int32(item.BaseQuantity),
+		CreatedAt:            item.CreatedAt,
+		UpdatedAt:            item.UpdatedAt,
+	}
+}
+
+// convertNullTimeToPtr converts a nullable database timestamp to an optional time
+func convertNullTimeToPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
+
+// convertPtrToNullTime converts an optional time to a nullable database timestamp
+func convertPtrToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
 	}
+	return sql.NullTime{Time: *t, Valid: true}
 }
 
 func (s *Storage) CreateOrder(ctx context.Context, order model.PurchaseOrder) error {
@@ -142,6 +191,32 @@ func (s *Storage) ListOrders(ctx context.Context, limit, offset int) ([]model.Pu
 	return orders, nil
 }
 
+// ListOpenOrdersByItemID lists the draft orders with a line for the item
+func (s *Storage) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.PurchaseOrder, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListOpenOrdersByItemIDParams{
+		ItemID: id,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	dbOrders, err := s.queries.ListOpenOrdersByItemID(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	orders := make([]model.PurchaseOrder, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orders = append(orders, convertDBOrderToModel(dbOrder))
+	}
+
+	return orders, nil
+}
+
 func (s *Storage) UpdateOrder(ctx context.Context, order model.PurchaseOrder) error {
 	_, err := s.queries.GetOrderByID(ctx, order.ID)
 	if err == sql.ErrNoRows {
@@ -185,6 +260,33 @@ func (s *Storage) UpdateOrderStatus(ctx context.Context, id string, status model
 	return nil
 }
 
+// CancelOrder cancels a draft order. The status is checked in the update itself, so an order
+// received or cancelled in the meantime is left alone and ErrConflict returned.
+func (s *Storage) CancelOrder(ctx context.Context, id string) error {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	cancelled, err := s.queries.CancelDraftOrder(ctx, orderID)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	if cancelled > 0 {
+		return nil
+	}
+
+	_, err = s.queries.GetOrderByID(ctx, orderID)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return errors.ErrConflict
+}
+
 func (s *Storage) CreateOrderItem(ctx context.Context, item model.PurchaseOrderItem) error {
 	params := convertModelOrderItemToCreateParams(item)
 	if err := s.queries.CreateOrderItem(ctx, params); err != nil {
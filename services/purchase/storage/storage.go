@@ -3,17 +3,49 @@ package storage
 import (
 	"context"
 	"microservice-challenge/services/purchase/model"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Storage interface {
 	CreateOrder(ctx context.Context, order model.PurchaseOrder) error
 	GetOrderByID(ctx context.Context, id string) (model.PurchaseOrder, error)
 	ListOrders(ctx context.Context, limit, offset int) ([]model.PurchaseOrder, error)
+	ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.PurchaseOrder, error)
 	UpdateOrder(ctx context.Context, order model.PurchaseOrder) error
 	UpdateOrderStatus(ctx context.Context, id string, status model.PurchaseOrderStatus) error
+	CancelOrder(ctx context.Context, id string) error
+	ListOverdueOrders(ctx context.Context, asOf time.Time, limit, offset int) ([]model.PurchaseOrder, error)
+	ListOrdersPendingOverdueNotice(ctx context.Context, asOf time.Time) ([]model.PurchaseOrder, error)
+	MarkOrderOverdueNotified(ctx context.Context, id uuid.UUID, notifiedAt time.Time) error
+	GetVendorDeliveryPerformance(ctx context.Context, vendorID string) (model.VendorDeliveryPerformance, error)
 
 	CreateOrderItem(ctx context.Context, item model.PurchaseOrderItem) error
 	CreateOrderItems(ctx context.Context, items []model.PurchaseOrderItem) error
 	GetOrderItemsByOrderID(ctx context.Context, orderID string) ([]model.PurchaseOrderItem, error)
 	DeleteOrderItemsByOrderID(ctx context.Context, orderID string) error
+
+	CreateOrderCharge(ctx context.Context, charge model.LandedCostCharge) error
+	GetOrderChargeByID(ctx context.Context, id string) (model.LandedCostCharge, error)
+	ListOrderChargesByOrderID(ctx context.Context, orderID string) ([]model.LandedCostCharge, error)
+	DeleteOrderCharge(ctx context.Context, id string) error
+	ReceiveOrder(ctx context.Context, orderID string, receivedAt time.Time, charges []model.LandedCostCharge, items []model.PurchaseOrderItem, lots []model.ReceivedLot, serials []model.ReceivedSerial) error
+
+	ListOrderLotsByOrderID(ctx context.Context, orderID string) ([]model.ReceivedLot, error)
+	ListOrderSerialsByOrderID(ctx context.Context, orderID string) ([]model.ReceivedSerial, error)
+
+	CreateCatalogItem(ctx context.Context, item model.VendorCatalogItem) error
+	GetCatalogItemByID(ctx context.Context, id string) (model.VendorCatalogItem, error)
+	GetCatalogItemByVendorAndItem(ctx context.Context, vendorID, itemID string) (model.VendorCatalogItem, error)
+	ListCatalogItems(ctx context.Context, filter model.VendorCatalogFilter, limit, offset int) ([]model.VendorCatalogItem, error)
+	UpdateCatalogItem(ctx context.Context, item model.VendorCatalogItem) error
+	DeleteCatalogItem(ctx context.Context, id string) error
+
+	CreateReturn(ctx context.Context, ret model.PurchaseReturnWithItems) error
+	ListReturnsByOrderID(ctx context.Context, orderID string) ([]model.PurchaseReturnWithItems, error)
+	GetReturnedQuantitiesByOrderID(ctx context.Context, orderID string) (map[uuid.UUID]int, error)
+	ListReturnedSerialsByOrderID(ctx context.Context, orderID string) ([]model.ReturnedSerial, error)
+	ListDebitNotes(ctx context.Context, vendorID *uuid.UUID, limit, offset int) ([]model.DebitNote, error)
+	GetVendorBalance(ctx context.Context, vendorID string) (model.VendorBalance, error)
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"microservice-challenge/package/client"
 	"microservice-challenge/services/inventory/model"
+	"net/url"
+	"time"
 )
 
 type InventoryClient struct {
@@ -25,3 +27,39 @@ func (c *InventoryClient) GetItemByID(ctx context.Context, itemID string, token
 	}
 	return item, nil
 }
+
+// GetItemPriceAt returns the item's unit price in effect at a time
+func (c *InventoryClient) GetItemPriceAt(ctx context.Context, itemID string, at time.Time, token string) (model.ItemPrice, error) {
+	var price model.ItemPrice
+	path := fmt.Sprintf("/items/%s/price?at=%s", itemID, url.QueryEscape(at.UTC().Format(time.RFC3339Nano)))
+	if err := c.Get(ctx, path, token, &price); err != nil {
+		return model.ItemPrice{}, err
+	}
+	return price, nil
+}
+
+// ReserveStock replaces the stock held for a sales order
+func (c *InventoryClient) ReserveStock(ctx context.Context, orderID string, req model.ReserveStockRequest, token string) ([]model.StockReservation, error) {
+	var reservations []model.StockReservation
+	path := fmt.Sprintf("/reservations/%s/%s", model.ReservationSourceSalesOrder, orderID)
+	if err := c.Put(ctx, path, req, token, &reservations); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// ReleaseStock releases the stock held for a sales order
+func (c *InventoryClient) ReleaseStock(ctx context.Context, orderID string, token string) error {
+	path := fmt.Sprintf("/reservations/%s/%s", model.ReservationSourceSalesOrder, orderID)
+	return c.Delete(ctx, path, token)
+}
+
+// LookupSerial finds the units carrying a serial number, across all items
+func (c *InventoryClient) LookupSerial(ctx context.Context, serialNumber string, token string) ([]model.SerialLookup, error) {
+	var serials []model.SerialLookup
+	path := fmt.Sprintf("/serials/%s", url.PathEscape(serialNumber))
+	if err := c.Get(ctx, path, token, &serials); err != nil {
+		return nil, err
+	}
+	return serials, nil
+}
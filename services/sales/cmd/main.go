@@ -33,6 +33,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -115,7 +116,16 @@ func main() {
 
 	storage := postgresql.NewStorage(db)
 
-	service := salesservice.NewService(storage, natsClient, contactClient, inventoryClient, authClient, logger)
+	defaultWarehouseID := uuid.Nil
+	if value := os.Getenv("DEFAULT_WAREHOUSE_ID"); value != "" {
+		warehouseID, err := uuid.Parse(value)
+		if err != nil {
+			logger.Fatal(ctx, "invalid DEFAULT_WAREHOUSE_ID", zap.String("value", value))
+		}
+		defaultWarehouseID = warehouseID
+	}
+
+	service := salesservice.NewService(storage, natsClient, contactClient, inventoryClient, authClient, defaultWarehouseID, logger)
 
 	handler := httphandler.NewHandler(service, logger)
 	r := router.NewRouter(handler, logger, cfg.JWT.Secret, db)
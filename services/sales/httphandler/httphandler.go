@@ -51,7 +51,13 @@ func (h *Handler) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 	limit, offset := pagination.GetLimitOffset(r)
 
-	orders, err := h.service.ListOrders(ctx, limit, offset)
+	var orders []model.SalesOrder
+	var err error
+	if itemID := r.URL.Query().Get("open_for_item"); itemID != "" {
+		orders, err = h.service.ListOpenOrdersByItemID(ctx, itemID, limit, offset)
+	} else {
+		orders, err = h.service.ListOrders(ctx, limit, offset)
+	}
 	if err != nil {
 		h.logger.Error(ctx, "failed to list orders", zap.Error(err))
 		response.SendErrorResponse(w, err)
@@ -116,7 +122,15 @@ func (h *Handler) ConfirmOrder(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id := chi.URLParam(r, "id")
 
-	order, err := h.service.ConfirmOrder(ctx, id)
+	// The body is optional: an order without serialized items needs no payload
+	var req model.ConfirmOrderRequest
+	if r.ContentLength != 0 {
+		if err := h.parseAndValidateRequest(w, r, &req); err != nil {
+			return
+		}
+	}
+
+	order, err := h.service.ConfirmOrder(ctx, id, req)
 	if err != nil {
 		h.logger.Error(ctx, "failed to confirm order", zap.Error(err))
 		response.SendErrorResponse(w, err)
@@ -38,6 +38,9 @@ type SalesOrder struct {
 	ID         uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	CustomerID uuid.UUID `json:"customer_id" db:"customer_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 
+	// WarehouseID is the warehouse stock ships from; nil means the configured default warehouse
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty" db:"warehouse_id" example:"00000000-0000-0000-0000-000000000001"`
+
 	Status      OrderStatus `json:"status" db:"status" example:"Draft"`
 	TotalAmount float64     `json:"total_amount" db:"total_amount" example:"2599.98"`
 
@@ -54,25 +57,59 @@ type OrderItem struct {
 	UnitPrice float64 `json:"unit_price" db:"unit_price" example:"1299.99"`
 	Subtotal  float64 `json:"subtotal" db:"subtotal" example:"2599.98"`
 
+	// Unit is the unit of measure Quantity and UnitPrice are in; BaseQuantity is the quantity in
+	// the item's base unit, ConversionFactor base units to each unit
+	Unit             string `json:"unit" db:"unit" example:"each"`
+	ConversionFactor int    `json:"conversion_factor" db:"conversion_factor" example:"1"`
+	BaseQuantity     int    `json:"base_quantity" db:"base_quantity" example:"2"`
+
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2025-11-20T12:00:00Z"`
 }
 
+// OrderSerial is a unit of a serialized item picked for an order
+type OrderSerial struct {
+	ID      uuid.UUID `json:"id" db:"id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	OrderID uuid.UUID `json:"order_id" db:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ItemID  uuid.UUID `json:"item_id" db:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+
+	SerialNumber string `json:"serial_number" db:"serial_number" example:"SN-5CD1234XYZ"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2025-11-20T12:00:00Z"`
+}
+
 type SalesOrderWithItems struct {
 	SalesOrder
-	Items []OrderItem `json:"items"`
+	Items   []OrderItem   `json:"items"`
+	Serials []OrderSerial `json:"serials,omitempty"`
 }
 
 type CreateOrderRequest struct {
-	CustomerID uuid.UUID                `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Items      []CreateOrderItemRequest `json:"items"`
+	CustomerID  uuid.UUID                `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID *uuid.UUID               `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Items       []CreateOrderItemRequest `json:"items"`
 }
 
 type CreateOrderItemRequest struct {
 	ItemID   uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
 	Quantity int       `json:"quantity" example:"2"`
+
+	// Unit defaults to the item's base unit
+	Unit string `json:"unit,omitempty" example:"each"`
 }
 
 type UpdateOrderRequest struct {
-	Items []CreateOrderItemRequest `json:"items"`
+	WarehouseID *uuid.UUID               `json:"warehouse_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Items       []CreateOrderItemRequest `json:"items"`
+}
+
+// ConfirmOrderRequest picks the units shipped for serialized items. The body is optional for
+// orders without serialized items.
+type ConfirmOrderRequest struct {
+	Serials []ItemSerialsRequest `json:"serials"`
+}
+
+type ItemSerialsRequest struct {
+	ItemID        uuid.UUID `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	SerialNumbers []string  `json:"serial_numbers" example:"SN-5CD1234XYZ"`
 }
@@ -28,6 +28,7 @@ func (r *CreateOrderItemRequest) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(&r.ItemID, validation.Required),
 		validation.Field(&r.Quantity, validation.Required, validation.Min(1)),
+		validation.Field(&r.Unit, validation.Length(0, 20)),
 	)
 }
 
@@ -47,3 +48,27 @@ func (r *UpdateOrderRequest) Validate() error {
 
 	return nil
 }
+
+func (r *ConfirmOrderRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Serials, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+
+	// Validate each entry in the serials slice
+	for i, serials := range r.Serials {
+		if err := serials.Validate(); err != nil {
+			return validation.NewError("serials", fmt.Sprintf("serials[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *ItemSerialsRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ItemID, validation.Required),
+		validation.Field(&r.SerialNumbers, validation.Required, validation.Length(1, 1000), validation.Each(validation.Required, validation.Length(1, 100))),
+	)
+}
@@ -1,9 +1,9 @@
 -- name: CreateOrderItem :exec
-INSERT INTO order_items (id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+INSERT INTO order_items (id, order_id, item_id, quantity, unit_price, subtotal, unit, conversion_factor, base_quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at
+SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at, unit, conversion_factor, base_quantity
 FROM order_items
 WHERE order_id = $1
 ORDER BY created_at ASC;
@@ -1,24 +1,36 @@
 -- name: CreateOrder :exec
-INSERT INTO sales_orders (id, customer_id, status, total_amount, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO sales_orders (id, customer_id, status, total_amount, created_at, updated_at, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
 
 -- name: GetOrderByID :one
-SELECT id, customer_id, status, total_amount, created_at, updated_at
+SELECT id, customer_id, status, total_amount, created_at, updated_at, warehouse_id
 FROM sales_orders
 WHERE id = $1;
 
 -- name: ListOrders :many
-SELECT id, customer_id, status, total_amount, created_at, updated_at
+SELECT id, customer_id, status, total_amount, created_at, updated_at, warehouse_id
 FROM sales_orders
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
+-- name: ListOpenOrdersByItemID :many
+SELECT so.id, so.customer_id, so.status, so.total_amount, so.created_at, so.updated_at, so.warehouse_id
+FROM sales_orders so
+WHERE so.status = 'Draft'
+  AND EXISTS (
+    SELECT 1 FROM order_items oi
+    WHERE oi.order_id = so.id AND oi.item_id = $1
+  )
+ORDER BY so.created_at DESC
+LIMIT $2 OFFSET $3;
+
 -- name: UpdateOrder :exec
 UPDATE sales_orders
 SET customer_id = $2,
     status = $3,
     total_amount = $4,
-    updated_at = $5
+    updated_at = $5,
+    warehouse_id = $6
 WHERE id = $1;
 
 -- name: UpdateOrderStatus :exec
@@ -0,0 +1,9 @@
+-- name: CreateOrderSerial :exec
+INSERT INTO sales_order_serials (id, order_id, item_id, serial_number, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListOrderSerialsByOrderID :many
+SELECT id, order_id, item_id, serial_number, created_at
+FROM sales_order_serials
+WHERE order_id = $1
+ORDER BY created_at ASC, serial_number ASC;
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/sales/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// reserveOrderStock holds stock for the lines of a draft order so inventory no longer shows it
+// as available to sell. The reservation replaces any held for the order before, and is released
+// by inventory once the order is confirmed. Inventory refuses to reserve more than is available,
+// which fails the order with ErrConflict.
+func (s *Service) reserveOrderStock(ctx context.Context, order model.SalesOrder, items []model.OrderItem, token string) error {
+	req := inventorymodel.ReserveStockRequest{
+		WarehouseID: order.WarehouseID,
+		Items:       make([]inventorymodel.ReserveStockItemRequest, 0, len(items)),
+	}
+	if req.WarehouseID == nil && s.defaultWarehouseID != uuid.Nil {
+		req.WarehouseID = &s.defaultWarehouseID
+	}
+	for _, item := range items {
+		req.Items = append(req.Items, inventorymodel.ReserveStockItemRequest{
+			ItemID:   item.ItemID,
+			Quantity: item.BaseQuantity,
+		})
+	}
+
+	if _, err := s.inventoryClient.ReserveStock(ctx, order.ID.String(), req, token); err != nil {
+		s.logger.Error(ctx, "failed to reserve stock for sales order",
+			zap.String("order_id", order.ID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// releaseOrderStock releases the stock held for an order that could not be saved. A failure is
+// logged; the reservation then lapses when it expires.
+func (s *Service) releaseOrderStock(ctx context.Context, orderID uuid.UUID, token string) {
+	if err := s.inventoryClient.ReleaseStock(ctx, orderID.String(), token); err != nil {
+		s.logger.Error(ctx, "failed to release stock for sales order",
+			zap.String("order_id", orderID.String()),
+			zap.Error(err),
+		)
+	}
+}
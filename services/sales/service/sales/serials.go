@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/sales/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// newOrderSerials checks the units picked for an order. Every unit of a serialized item must be
+// picked by its own serial number, held in stock by inventory in the warehouse the order ships
+// from; other items may not carry any serial numbers.
+func (s *Service) newOrderSerials(ctx context.Context, order model.SalesOrder, items []model.OrderItem, reqs []model.ItemSerialsRequest) ([]model.OrderSerial, error) {
+	token, err := s.getTokenFromContext(ctx)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		if _, seen := quantities[item.ItemID]; !seen {
+			itemIDs = append(itemIDs, item.ItemID)
+		}
+		quantities[item.ItemID] += item.BaseQuantity
+	}
+
+	serialized := make(map[uuid.UUID]bool)
+	for _, itemID := range itemIDs {
+		inventoryItem, err := s.inventoryClient.GetItemByID(ctx, itemID.String(), token)
+		if err != nil {
+			s.logger.Error(ctx, "failed to validate item", zap.String("item_id", itemID.String()), zap.Error(err))
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrBadRequest
+			}
+			return nil, errors.ErrInternalServerError
+		}
+		if inventoryItem.Serialized {
+			serialized[itemID] = true
+		}
+	}
+
+	warehouseID := s.orderWarehouseID(order)
+	picked := make(map[uuid.UUID]int, len(reqs))
+	seen := make(map[string]bool)
+	serials := make([]model.OrderSerial, 0)
+
+	for _, req := range reqs {
+		if !serialized[req.ItemID] {
+			s.logger.Error(ctx, "serial numbers given for an item that is not serialized or not on the order", zap.String("item_id", req.ItemID.String()))
+			return nil, errors.ErrBadRequest
+		}
+
+		for _, serialNumber := range req.SerialNumbers {
+			serialNumber = strings.TrimSpace(serialNumber)
+			key := req.ItemID.String() + "/" + serialNumber
+			if seen[key] {
+				s.logger.Error(ctx, "serial number picked twice", zap.String("item_id", req.ItemID.String()), zap.String("serial_number", serialNumber))
+				return nil, errors.ErrBadRequest
+			}
+			seen[key] = true
+
+			if err := s.checkSerialInStock(ctx, req.ItemID, serialNumber, warehouseID, token); err != nil {
+				return nil, err
+			}
+
+			picked[req.ItemID]++
+			serials = append(serials, model.OrderSerial{
+				ID:           uuid.New(),
+				OrderID:      order.ID,
+				ItemID:       req.ItemID,
+				SerialNumber: serialNumber,
+				CreatedAt:    time.Now(),
+			})
+		}
+	}
+
+	for _, itemID := range itemIDs {
+		if serialized[itemID] && picked[itemID] != quantities[itemID] {
+			s.logger.Error(ctx, "serial numbers do not cover the quantity ordered",
+				zap.String("item_id", itemID.String()),
+				zap.Int("quantity", quantities[itemID]),
+				zap.Int("serials", picked[itemID]),
+			)
+			return nil, errors.ErrBadRequest
+		}
+	}
+
+	return serials, nil
+}
+
+// checkSerialInStock makes sure inventory holds the unit in stock, in the given warehouse when one
+// is known
+func (s *Service) checkSerialInStock(ctx context.Context, itemID uuid.UUID, serialNumber, warehouseID, token string) error {
+	units, err := s.inventoryClient.LookupSerial(ctx, serialNumber, token)
+	if err != nil && err != errors.ErrNotFound {
+		s.logger.Error(ctx, "failed to look up serial number", zap.String("serial_number", serialNumber), zap.Error(err))
+		return errors.ErrInternalServerError
+	}
+
+	for _, unit := range units {
+		if unit.ItemID != itemID || unit.Status != inventorymodel.StockSerialStatusInStock {
+			continue
+		}
+		if warehouseID != "" && unit.WarehouseID.String() != warehouseID {
+			continue
+		}
+		return nil
+	}
+
+	s.logger.Error(ctx, "serial number is not in stock",
+		zap.String("item_id", itemID.String()),
+		zap.String("serial_number", serialNumber),
+		zap.String("warehouse_id", warehouseID),
+	)
+	return errors.ErrBadRequest
+}
+
+// serialEventEntries groups picked serials by item in the form carried by the
+// sales.order.confirmed event
+func serialEventEntries(serials []model.OrderSerial) []map[string]interface{} {
+	itemIDs := make([]uuid.UUID, 0)
+	serialNumbers := make(map[uuid.UUID][]string)
+	for _, serial := range serials {
+		if _, seen := serialNumbers[serial.ItemID]; !seen {
+			itemIDs = append(itemIDs, serial.ItemID)
+		}
+		serialNumbers[serial.ItemID] = append(serialNumbers[serial.ItemID], serial.SerialNumber)
+	}
+
+	entries := make([]map[string]interface{}, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		entries = append(entries, map[string]interface{}{
+			"item_id":        itemID.String(),
+			"serial_numbers": serialNumbers[itemID],
+		})
+	}
+	return entries
+}
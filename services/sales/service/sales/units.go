@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"microservice-challenge/package/errors"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/sales/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// orderLineKey identifies an order line by the item and the unit it was entered in
+type orderLineKey struct {
+	itemID uuid.UUID
+	unit   string
+}
+
+func newOrderLineKey(item model.OrderItem) orderLineKey {
+	return orderLineKey{itemID: item.ItemID, unit: strings.ToLower(item.Unit)}
+}
+
+// getItemPricedAt fetches an item with the unit price that was in effect at a time, so that
+// order lines are priced as of when the order was placed
+func (s *Service) getItemPricedAt(ctx context.Context, itemID uuid.UUID, at time.Time, token string) (inventorymodel.Item, error) {
+	item, err := s.inventoryClient.GetItemByID(ctx, itemID.String(), token)
+	if err != nil {
+		return inventorymodel.Item{}, err
+	}
+
+	price, err := s.inventoryClient.GetItemPriceAt(ctx, itemID.String(), at, token)
+	if err != nil {
+		return inventorymodel.Item{}, err
+	}
+	item.UnitPrice = price.UnitPrice
+
+	return item, nil
+}
+
+// newOrderItem prices an order line in the unit it was entered in. The item's price is per base
+// unit, so a unit holding several base units costs as many times more. A unit the item is not
+// sold in is rejected, as is an archived item.
+func newOrderItem(orderID uuid.UUID, inventoryItem inventorymodel.Item, req model.CreateOrderItemRequest) (model.OrderItem, error) {
+	if inventoryItem.ArchivedAt != nil {
+		return model.OrderItem{}, errors.ErrBadRequest
+	}
+
+	factor, ok := inventoryItem.ConversionFactor(req.Unit)
+	if !ok {
+		return model.OrderItem{}, errors.ErrBadRequest
+	}
+
+	unit := strings.TrimSpace(req.Unit)
+	if unit == "" {
+		unit = inventoryItem.BaseUnit
+	}
+
+	unitPrice := inventoryItem.UnitPrice * float64(factor)
+	return model.OrderItem{
+		ID:               uuid.New(),
+		OrderID:          orderID,
+		ItemID:           req.ItemID,
+		Quantity:         req.Quantity,
+		UnitPrice:        unitPrice,
+		Subtotal:         unitPrice * float64(req.Quantity),
+		Unit:             unit,
+		ConversionFactor: factor,
+		BaseQuantity:     req.Quantity * factor,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}, nil
+}
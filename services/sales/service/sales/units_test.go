@@ -0,0 +1,110 @@
+package service
+
+import (
+	"microservice-challenge/package/errors"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/sales/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewOrderItem(t *testing.T) {
+	itemID := uuid.New()
+	archivedAt := time.Now()
+
+	item := inventorymodel.Item{
+		ID: itemID, UnitPrice: 2.5, BaseUnit: "each",
+		Units: []inventorymodel.ItemUnit{{Unit: "case", ConversionFactor: 12}},
+	}
+	archived := item
+	archived.ArchivedAt = &archivedAt
+
+	tests := []struct {
+		name             string
+		item             inventorymodel.Item
+		req              model.CreateOrderItemRequest
+		wantUnit         string
+		wantFactor       int
+		wantBaseQuantity int
+		wantUnitPrice    float64
+		wantSubtotal     float64
+		wantErr          error
+	}{
+		{
+			name:             "no unit is the base unit",
+			item:             item,
+			req:              model.CreateOrderItemRequest{ItemID: itemID, Quantity: 4},
+			wantUnit:         "each",
+			wantFactor:       1,
+			wantBaseQuantity: 4,
+			wantUnitPrice:    2.5,
+			wantSubtotal:     10,
+		},
+		{
+			name:             "larger unit is priced per unit entered",
+			item:             item,
+			req:              model.CreateOrderItemRequest{ItemID: itemID, Quantity: 2, Unit: "case"},
+			wantUnit:         "case",
+			wantFactor:       12,
+			wantBaseQuantity: 24,
+			wantUnitPrice:    30,
+			wantSubtotal:     60,
+		},
+		{
+			name:             "unit is kept as entered without spaces",
+			item:             item,
+			req:              model.CreateOrderItemRequest{ItemID: itemID, Quantity: 1, Unit: " Case "},
+			wantUnit:         "Case",
+			wantFactor:       12,
+			wantBaseQuantity: 12,
+			wantUnitPrice:    30,
+			wantSubtotal:     30,
+		},
+		{
+			name:    "unit the item is not sold in is rejected",
+			item:    item,
+			req:     model.CreateOrderItemRequest{ItemID: itemID, Quantity: 1, Unit: "pallet"},
+			wantErr: errors.ErrBadRequest,
+		},
+		{
+			name:    "archived item is rejected",
+			item:    archived,
+			req:     model.CreateOrderItemRequest{ItemID: itemID, Quantity: 1},
+			wantErr: errors.ErrBadRequest,
+		},
+	}
+
+	orderID := uuid.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newOrderItem(orderID, tt.item, tt.req)
+			if err != tt.wantErr {
+				t.Fatalf("newOrderItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.OrderID != orderID || got.ItemID != itemID {
+				t.Errorf("line belongs to order %s item %s, want order %s item %s", got.OrderID, got.ItemID, orderID, itemID)
+			}
+			if got.Unit != tt.wantUnit {
+				t.Errorf("unit = %q, want %q", got.Unit, tt.wantUnit)
+			}
+			if got.ConversionFactor != tt.wantFactor {
+				t.Errorf("conversion factor = %d, want %d", got.ConversionFactor, tt.wantFactor)
+			}
+			if got.BaseQuantity != tt.wantBaseQuantity {
+				t.Errorf("base quantity = %d, want %d", got.BaseQuantity, tt.wantBaseQuantity)
+			}
+			if got.UnitPrice != tt.wantUnitPrice {
+				t.Errorf("unit price = %v, want %v", got.UnitPrice, tt.wantUnitPrice)
+			}
+			if got.Subtotal != tt.wantSubtotal {
+				t.Errorf("subtotal = %v, want %v", got.Subtotal, tt.wantSubtotal)
+			}
+		})
+	}
+}
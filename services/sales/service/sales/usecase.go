@@ -24,17 +24,33 @@ type Service struct {
 	inventoryClient *client.InventoryClient
 	authClient      *client.AuthClient
 	logger          log.Logger
+
+	// defaultWarehouseID is used for orders without a warehouse; uuid.Nil leaves the choice to inventory
+	defaultWarehouseID uuid.UUID
 }
 
-func NewService(storage storage.Storage, natsClient *natsclient.Client, contactClient *client.ContactClient, inventoryClient *client.InventoryClient, authClient *client.AuthClient, logger log.Logger) *Service {
+func NewService(storage storage.Storage, natsClient *natsclient.Client, contactClient *client.ContactClient, inventoryClient *client.InventoryClient, authClient *client.AuthClient, defaultWarehouseID uuid.UUID, logger log.Logger) *Service {
 	return &Service{
-		storage:         storage,
-		natsClient:      natsClient,
-		contactClient:   contactClient,
-		inventoryClient: inventoryClient,
-		authClient:      authClient,
-		logger:          logger,
+		storage:            storage,
+		natsClient:         natsClient,
+		contactClient:      contactClient,
+		inventoryClient:    inventoryClient,
+		authClient:         authClient,
+		logger:             logger,
+		defaultWarehouseID: defaultWarehouseID,
+	}
+}
+
+// orderWarehouseID returns the warehouse stock for the order ships from, falling back to the
+// configured default. An empty string means no warehouse is known.
+func (s *Service) orderWarehouseID(order model.SalesOrder) string {
+	if order.WarehouseID != nil {
+		return order.WarehouseID.String()
+	}
+	if s.defaultWarehouseID != uuid.Nil {
+		return s.defaultWarehouseID.String()
 	}
+	return ""
 }
 
 func (s *Service) getTokenFromContext(ctx context.Context) (string, error) {
@@ -72,10 +88,11 @@ func (s *Service) CreateOrder(ctx context.Context, req model.CreateOrderRequest)
 	order := model.SalesOrder{
 		ID:          uuid.New(),
 		CustomerID:  req.CustomerID,
+		WarehouseID: req.WarehouseID,
 		Status:      model.OrderStatusDraft,
 		TotalAmount: 0,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
 	}
 
 	type itemResult struct {
@@ -92,24 +109,18 @@ func (s *Service) CreateOrder(ctx context.Context, req model.CreateOrderRequest)
 		wg.Add(1)
 		go func(ir model.CreateOrderItemRequest) {
 			defer wg.Done()
-			inventoryItem, err := s.inventoryClient.GetItemByID(ctx, ir.ItemID.String(), token)
+			inventoryItem, err := s.getItemPricedAt(ctx, ir.ItemID, order.CreatedAt, token)
 			if err != nil {
 				results <- itemResult{err: err, itemReq: ir}
 				return
 			}
 
-			subtotal := inventoryItem.UnitPrice * float64(ir.Quantity)
-			item := model.OrderItem{
-				ID:        uuid.New(),
-				OrderID:   order.ID,
-				ItemID:    ir.ItemID,
-				Quantity:  ir.Quantity,
-				UnitPrice: inventoryItem.UnitPrice,
-				Subtotal:  subtotal,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+			item, err := newOrderItem(order.ID, inventoryItem, ir)
+			if err != nil {
+				results <- itemResult{err: err, itemReq: ir}
+				return
 			}
-			results <- itemResult{item: item, subtotal: subtotal}
+			results <- itemResult{item: item, subtotal: item.Subtotal}
 		}(itemReq)
 	}
 
@@ -133,11 +144,17 @@ func (s *Service) CreateOrder(ctx context.Context, req model.CreateOrderRequest)
 
 	order.TotalAmount = totalAmount
 
+	if err := s.reserveOrderStock(ctx, order, items, token); err != nil {
+		return model.SalesOrderWithItems{}, err
+	}
+
 	if err := s.storage.CreateOrder(ctx, order); err != nil {
+		s.releaseOrderStock(ctx, order.ID, token)
 		return model.SalesOrderWithItems{}, err
 	}
 
 	if err := s.storage.CreateOrderItems(ctx, items); err != nil {
+		s.releaseOrderStock(ctx, order.ID, token)
 		return model.SalesOrderWithItems{}, err
 	}
 
@@ -158,9 +175,15 @@ func (s *Service) GetOrderByID(ctx context.Context, id string) (model.SalesOrder
 		return model.SalesOrderWithItems{}, err
 	}
 
+	serials, err := s.storage.ListOrderSerialsByOrderID(ctx, id)
+	if err != nil {
+		return model.SalesOrderWithItems{}, err
+	}
+
 	return model.SalesOrderWithItems{
 		SalesOrder: order,
 		Items:      items,
+		Serials:    serials,
 	}, nil
 }
 
@@ -168,6 +191,12 @@ func (s *Service) ListOrders(ctx context.Context, limit, offset int) ([]model.Sa
 	return s.storage.ListOrders(ctx, limit, offset)
 }
 
+// ListOpenOrdersByItemID lists the draft orders that include the item. Inventory checks these
+// before it archives an item.
+func (s *Service) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.SalesOrder, error) {
+	return s.storage.ListOpenOrdersByItemID(ctx, itemID, limit, offset)
+}
+
 func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdateOrderRequest) (model.SalesOrderWithItems, error) {
 	order, err := s.storage.GetOrderByID(ctx, id)
 	if err != nil {
@@ -178,9 +207,11 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdateOr
 		return model.SalesOrderWithItems{}, errors.ErrBadRequest
 	}
 
-	if err := s.storage.DeleteOrderItemsByOrderID(ctx, id); err != nil {
+	previousItems, err := s.storage.GetOrderItemsByOrderID(ctx, id)
+	if err != nil {
 		return model.SalesOrderWithItems{}, err
 	}
+	previousOrder := order
 
 	token, err := s.getTokenFromContext(ctx)
 	if err != nil {
@@ -190,6 +221,13 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdateOr
 	var totalAmount float64
 	items := make([]model.OrderItem, 0, len(req.Items))
 
+	// Lines already on the order keep the price they were entered at. Lines the edit adds are
+	// priced now: an item created or first priced after the order has no price at its creation.
+	previousPrices := make(map[orderLineKey]float64, len(previousItems))
+	for _, item := range previousItems {
+		previousPrices[newOrderLineKey(item)] = item.UnitPrice
+	}
+
 	for _, itemReq := range req.Items {
 		inventoryItem, err := s.inventoryClient.GetItemByID(ctx, itemReq.ItemID.String(), token)
 		if err != nil {
@@ -200,33 +238,57 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdateOr
 			return model.SalesOrderWithItems{}, err
 		}
 
-		subtotal := inventoryItem.UnitPrice * float64(itemReq.Quantity)
-		item := model.OrderItem{
-			ID:        uuid.New(),
-			OrderID:   order.ID,
-			ItemID:    itemReq.ItemID,
-			Quantity:  itemReq.Quantity,
-			UnitPrice: inventoryItem.UnitPrice,
-			Subtotal:  subtotal,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+		item, err := newOrderItem(order.ID, inventoryItem, itemReq)
+		if err != nil {
+			return model.SalesOrderWithItems{}, err
+		}
+
+		unitPrice, ok := previousPrices[newOrderLineKey(item)]
+		if !ok {
+			price, err := s.inventoryClient.GetItemPriceAt(ctx, itemReq.ItemID.String(), time.Now().UTC(), token)
+			if err != nil {
+				s.logger.Error(ctx, "failed to price item", zap.String("item_id", itemReq.ItemID.String()), zap.Error(err))
+				if err == errors.ErrNotFound {
+					return model.SalesOrderWithItems{}, errors.ErrBadRequest
+				}
+				return model.SalesOrderWithItems{}, err
+			}
+			unitPrice = price.UnitPrice * float64(item.ConversionFactor)
 		}
+		item.UnitPrice = unitPrice
+		item.Subtotal = unitPrice * float64(item.Quantity)
+
 		items = append(items, item)
-		totalAmount += subtotal
+		totalAmount += item.Subtotal
 	}
 
+	if req.WarehouseID != nil {
+		order.WarehouseID = req.WarehouseID
+	}
 	order.TotalAmount = totalAmount
 	order.UpdatedAt = time.Now()
 
+	if err := s.reserveOrderStock(ctx, order, items, token); err != nil {
+		return model.SalesOrderWithItems{}, err
+	}
+
+	// If the order cannot be saved it keeps its previous lines, so they get their stock back
+	restoreReservation := func() {
+		s.reserveOrderStock(ctx, previousOrder, previousItems, token)
+	}
+
 	if err := s.storage.UpdateOrder(ctx, order); err != nil {
+		restoreReservation()
 		return model.SalesOrderWithItems{}, err
 	}
 
 	if err := s.storage.DeleteOrderItemsByOrderID(ctx, id); err != nil {
+		restoreReservation()
 		return model.SalesOrderWithItems{}, err
 	}
 
 	if err := s.storage.CreateOrderItems(ctx, items); err != nil {
+		restoreReservation()
 		return model.SalesOrderWithItems{}, err
 	}
 
@@ -236,7 +298,7 @@ func (s *Service) UpdateOrder(ctx context.Context, id string, req model.UpdateOr
 	}, nil
 }
 
-func (s *Service) ConfirmOrder(ctx context.Context, id string) (model.SalesOrderWithItems, error) {
+func (s *Service) ConfirmOrder(ctx context.Context, id string, req model.ConfirmOrderRequest) (model.SalesOrderWithItems, error) {
 	order, err := s.storage.GetOrderByID(ctx, id)
 	if err != nil {
 		return model.SalesOrderWithItems{}, err
@@ -251,7 +313,12 @@ func (s *Service) ConfirmOrder(ctx context.Context, id string) (model.SalesOrder
 		return model.SalesOrderWithItems{}, err
 	}
 
-	if err := s.storage.UpdateOrderStatus(ctx, id, model.OrderStatusConfirmed); err != nil {
+	serials, err := s.newOrderSerials(ctx, order, items, req.Serials)
+	if err != nil {
+		return model.SalesOrderWithItems{}, err
+	}
+
+	if err := s.storage.ConfirmOrder(ctx, id, serials); err != nil {
 		return model.SalesOrderWithItems{}, err
 	}
 
@@ -261,10 +328,13 @@ func (s *Service) ConfirmOrder(ctx context.Context, id string) (model.SalesOrder
 	eventItems := make([]map[string]interface{}, 0, len(items))
 	for _, item := range items {
 		eventItems = append(eventItems, map[string]interface{}{
-			"item_id":    item.ItemID.String(),
-			"quantity":   item.Quantity,
-			"unit_price": item.UnitPrice,
-			"subtotal":   item.Subtotal,
+			"item_id":           item.ItemID.String(),
+			"quantity":          item.Quantity,
+			"unit":              item.Unit,
+			"conversion_factor": item.ConversionFactor,
+			"base_quantity":     item.BaseQuantity,
+			"unit_price":        item.UnitPrice,
+			"subtotal":          item.Subtotal,
 		})
 	}
 
@@ -276,6 +346,15 @@ func (s *Service) ConfirmOrder(ctx context.Context, id string) (model.SalesOrder
 		"total_amount": order.TotalAmount,
 		"timestamp":    time.Now().Format(time.RFC3339),
 	}
+	if len(serials) > 0 {
+		event["serials"] = serialEventEntries(serials)
+	}
+	if warehouseID := s.orderWarehouseID(order); warehouseID != "" {
+		event["warehouse_id"] = warehouseID
+	}
+	if userID := middleware.GetUserIDFromContext(ctx); userID != "" {
+		event["user_id"] = userID
+	}
 
 	if err := s.natsClient.Publish("sales.order.confirmed", event); err != nil {
 		s.logger.Error(ctx, "failed to publish sales.order.confirmed event", zap.Error(err))
@@ -289,6 +368,7 @@ func (s *Service) ConfirmOrder(ctx context.Context, id string) (model.SalesOrder
 	return model.SalesOrderWithItems{
 		SalesOrder: order,
 		Items:      items,
+		Serials:    serials,
 	}, nil
 }
 
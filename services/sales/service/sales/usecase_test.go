@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"microservice-challenge/package/errors"
+	"microservice-challenge/package/log"
+	"microservice-challenge/package/middleware"
+	inventorymodel "microservice-challenge/services/inventory/model"
+	"microservice-challenge/services/sales/client"
+	"microservice-challenge/services/sales/model"
+	"microservice-challenge/services/sales/storage"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeOrderStorage keeps a single order in memory; methods UpdateOrder does not use are left
+// to the embedded nil interface
+type fakeOrderStorage struct {
+	storage.Storage
+	order model.SalesOrder
+	items []model.OrderItem
+}
+
+func (f *fakeOrderStorage) GetOrderByID(ctx context.Context, id string) (model.SalesOrder, error) {
+	if id != f.order.ID.String() {
+		return model.SalesOrder{}, errors.ErrNotFound
+	}
+	return f.order, nil
+}
+
+func (f *fakeOrderStorage) GetOrderItemsByOrderID(ctx context.Context, orderID string) ([]model.OrderItem, error) {
+	return f.items, nil
+}
+
+func (f *fakeOrderStorage) UpdateOrder(ctx context.Context, order model.SalesOrder) error {
+	f.order = order
+	return nil
+}
+
+func (f *fakeOrderStorage) DeleteOrderItemsByOrderID(ctx context.Context, orderID string) error {
+	f.items = nil
+	return nil
+}
+
+func (f *fakeOrderStorage) CreateOrderItems(ctx context.Context, items []model.OrderItem) error {
+	f.items = items
+	return nil
+}
+
+// inventoryItem is an item served by the fake inventory service with its price history
+type inventoryItem struct {
+	item   inventorymodel.Item
+	prices []inventorymodel.ItemPrice
+}
+
+// newInventoryServer fakes the inventory endpoints UpdateOrder calls: items, their price at a
+// time and stock reservations
+func newInventoryServer(t *testing.T, items map[uuid.UUID]inventoryItem) *httptest.Server {
+	t.Helper()
+
+	respond := func(w http.ResponseWriter, data interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": http.StatusOK, "data": data})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/reservations/") {
+			respond(w, []inventorymodel.StockReservation{})
+			return
+		}
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) < 2 || parts[0] != "items" {
+			http.NotFound(w, r)
+			return
+		}
+		id, err := uuid.Parse(parts[1])
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		entry, ok := items[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if len(parts) == 2 {
+			respond(w, entry.item)
+			return
+		}
+
+		at, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("at"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var price *inventorymodel.ItemPrice
+		for i := range entry.prices {
+			if !entry.prices[i].EffectiveFrom.After(at) {
+				price = &entry.prices[i]
+			}
+		}
+		if price == nil {
+			http.NotFound(w, r)
+			return
+		}
+		respond(w, price)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestUpdateOrderPricing(t *testing.T) {
+	draftCreated := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	repriced := draftCreated.Add(24 * time.Hour)
+	itemCreated := draftCreated.Add(48 * time.Hour)
+
+	existingID := uuid.New()
+	newID := uuid.New()
+	unknownID := uuid.New()
+
+	catalog := map[uuid.UUID]inventoryItem{
+		existingID: {
+			item: inventorymodel.Item{
+				ID: existingID, UnitPrice: 12, BaseUnit: "each",
+				Units: []inventorymodel.ItemUnit{{Unit: "box", ConversionFactor: 6}},
+			},
+			prices: []inventorymodel.ItemPrice{
+				{UnitPrice: 10, EffectiveFrom: draftCreated.Add(-time.Hour)},
+				{UnitPrice: 12, EffectiveFrom: repriced},
+			},
+		},
+		// Created and first priced after the draft, so it has no price at the draft's creation
+		newID: {
+			item:   inventorymodel.Item{ID: newID, UnitPrice: 4, BaseUnit: "each"},
+			prices: []inventorymodel.ItemPrice{{UnitPrice: 4, EffectiveFrom: itemCreated}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		items      []model.CreateOrderItemRequest
+		wantPrices []float64
+		wantTotal  float64
+		wantErr    error
+	}{
+		{
+			name:       "existing line keeps its stored price",
+			items:      []model.CreateOrderItemRequest{{ItemID: existingID, Quantity: 3}},
+			wantPrices: []float64{10},
+			wantTotal:  30,
+		},
+		{
+			name: "item created after the draft is priced now",
+			items: []model.CreateOrderItemRequest{
+				{ItemID: existingID, Quantity: 2},
+				{ItemID: newID, Quantity: 5},
+			},
+			wantPrices: []float64{10, 4},
+			wantTotal:  40,
+		},
+		{
+			name:       "existing item in a new unit is priced now",
+			items:      []model.CreateOrderItemRequest{{ItemID: existingID, Quantity: 1, Unit: "box"}},
+			wantPrices: []float64{72},
+			wantTotal:  72,
+		},
+		{
+			name:    "unknown item is rejected",
+			items:   []model.CreateOrderItemRequest{{ItemID: unknownID, Quantity: 1}},
+			wantErr: errors.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := model.SalesOrder{
+				ID:          uuid.New(),
+				CustomerID:  uuid.New(),
+				Status:      model.OrderStatusDraft,
+				TotalAmount: 10,
+				CreatedAt:   draftCreated,
+				UpdatedAt:   draftCreated,
+			}
+			store := &fakeOrderStorage{
+				order: order,
+				items: []model.OrderItem{{
+					ID: uuid.New(), OrderID: order.ID, ItemID: existingID,
+					Quantity: 1, UnitPrice: 10, Subtotal: 10,
+					Unit: "each", ConversionFactor: 1, BaseQuantity: 1,
+				}},
+			}
+
+			server := newInventoryServer(t, catalog)
+			s := NewService(store, nil, nil, client.NewInventoryClient(server.URL), nil, uuid.Nil, log.InitLogger(zap.NewNop()))
+			ctx := context.WithValue(context.Background(), middleware.GetTokenKey(), "token")
+
+			got, err := s.UpdateOrder(ctx, order.ID.String(), model.UpdateOrderRequest{Items: tt.items})
+			if err != tt.wantErr {
+				t.Fatalf("UpdateOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(got.Items) != len(tt.wantPrices) {
+				t.Fatalf("UpdateOrder() returned %d lines, want %d", len(got.Items), len(tt.wantPrices))
+			}
+			for i, item := range got.Items {
+				if item.UnitPrice != tt.wantPrices[i] {
+					t.Errorf("line %d unit price = %v, want %v", i, item.UnitPrice, tt.wantPrices[i])
+				}
+			}
+			if got.TotalAmount != tt.wantTotal {
+				t.Errorf("total = %v, want %v", got.TotalAmount, tt.wantTotal)
+			}
+			if store.order.TotalAmount != tt.wantTotal {
+				t.Errorf("stored total = %v, want %v", store.order.TotalAmount, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -11,21 +11,33 @@ import (
 )
 
 type OrderItem struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
-	UnitPrice string    `json:"unit_price"`
-	Subtotal  string    `json:"subtotal"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uuid.UUID `json:"id"`
+	OrderID          uuid.UUID `json:"order_id"`
+	ItemID           uuid.UUID `json:"item_id"`
+	Quantity         int32     `json:"quantity"`
+	UnitPrice        string    `json:"unit_price"`
+	Subtotal         string    `json:"subtotal"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	Unit             string    `json:"unit"`
+	ConversionFactor int32     `json:"conversion_factor"`
+	BaseQuantity     int32     `json:"base_quantity"`
 }
 
 type SalesOrder struct {
-	ID          uuid.UUID `json:"id"`
-	CustomerID  uuid.UUID `json:"customer_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID     `json:"id"`
+	CustomerID  uuid.UUID     `json:"customer_id"`
+	Status      string        `json:"status"`
+	TotalAmount string        `json:"total_amount"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	WarehouseID uuid.NullUUID `json:"warehouse_id"`
+}
+
+type SalesOrderSerial struct {
+	ID           uuid.UUID `json:"id"`
+	OrderID      uuid.UUID `json:"order_id"`
+	ItemID       uuid.UUID `json:"item_id"`
+	SerialNumber string    `json:"serial_number"`
+	CreatedAt    time.Time `json:"created_at"`
 }
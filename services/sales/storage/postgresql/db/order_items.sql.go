@@ -13,19 +13,22 @@ import (
 )
 
 const createOrderItem = `-- name: CreateOrderItem :exec
-INSERT INTO order_items (id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO order_items (id, order_id, item_id, quantity, unit_price, subtotal, unit, conversion_factor, base_quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateOrderItemParams struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ItemID    uuid.UUID `json:"item_id"`
-	Quantity  int32     `json:"quantity"`
-	UnitPrice string    `json:"unit_price"`
-	Subtotal  string    `json:"subtotal"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uuid.UUID `json:"id"`
+	OrderID          uuid.UUID `json:"order_id"`
+	ItemID           uuid.UUID `json:"item_id"`
+	Quantity         int32     `json:"quantity"`
+	UnitPrice        string    `json:"unit_price"`
+	Subtotal         string    `json:"subtotal"`
+	Unit             string    `json:"unit"`
+	ConversionFactor int32     `json:"conversion_factor"`
+	BaseQuantity     int32     `json:"base_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error {
@@ -36,6 +39,9 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Quantity,
 		arg.UnitPrice,
 		arg.Subtotal,
+		arg.Unit,
+		arg.ConversionFactor,
+		arg.BaseQuantity,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -53,7 +59,7 @@ func (q *Queries) DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UU
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at
+SELECT id, order_id, item_id, quantity, unit_price, subtotal, created_at, updated_at, unit, conversion_factor, base_quantity
 FROM order_items
 WHERE order_id = $1
 ORDER BY created_at ASC
@@ -77,6 +83,9 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.Subtotal,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Unit,
+			&i.ConversionFactor,
+			&i.BaseQuantity,
 		); err != nil {
 			return nil, err
 		}
@@ -13,17 +13,18 @@ import (
 )
 
 const createOrder = `-- name: CreateOrder :exec
-INSERT INTO sales_orders (id, customer_id, status, total_amount, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO sales_orders (id, customer_id, status, total_amount, created_at, updated_at, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
 type CreateOrderParams struct {
-	ID          uuid.UUID `json:"id"`
-	CustomerID  uuid.UUID `json:"customer_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID     `json:"id"`
+	CustomerID  uuid.UUID     `json:"customer_id"`
+	Status      string        `json:"status"`
+	TotalAmount string        `json:"total_amount"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	WarehouseID uuid.NullUUID `json:"warehouse_id"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error {
@@ -34,12 +35,13 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error
 		arg.TotalAmount,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.WarehouseID,
 	)
 	return err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, customer_id, status, total_amount, created_at, updated_at
+SELECT id, customer_id, status, total_amount, created_at, updated_at, warehouse_id
 FROM sales_orders
 WHERE id = $1
 `
@@ -54,12 +56,62 @@ func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (SalesOrder, e
 		&i.TotalAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
+const listOpenOrdersByItemID = `-- name: ListOpenOrdersByItemID :many
+SELECT so.id, so.customer_id, so.status, so.total_amount, so.created_at, so.updated_at, so.warehouse_id
+FROM sales_orders so
+WHERE so.status = 'Draft'
+  AND EXISTS (
+    SELECT 1 FROM order_items oi
+    WHERE oi.order_id = so.id AND oi.item_id = $1
+  )
+ORDER BY so.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListOpenOrdersByItemIDParams struct {
+	ItemID uuid.UUID `json:"item_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+func (q *Queries) ListOpenOrdersByItemID(ctx context.Context, arg ListOpenOrdersByItemIDParams) ([]SalesOrder, error) {
+	rows, err := q.db.QueryContext(ctx, listOpenOrdersByItemID, arg.ItemID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SalesOrder{}
+	for rows.Next() {
+		var i SalesOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.Status,
+			&i.TotalAmount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrders = `-- name: ListOrders :many
-SELECT id, customer_id, status, total_amount, created_at, updated_at
+SELECT id, customer_id, status, total_amount, created_at, updated_at, warehouse_id
 FROM sales_orders
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -86,6 +138,7 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Sales
 			&i.TotalAmount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WarehouseID,
 		); err != nil {
 			return nil, err
 		}
@@ -105,16 +158,18 @@ UPDATE sales_orders
 SET customer_id = $2,
     status = $3,
     total_amount = $4,
-    updated_at = $5
+    updated_at = $5,
+    warehouse_id = $6
 WHERE id = $1
 `
 
 type UpdateOrderParams struct {
-	ID          uuid.UUID `json:"id"`
-	CustomerID  uuid.UUID `json:"customer_id"`
-	Status      string    `json:"status"`
-	TotalAmount string    `json:"total_amount"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID     `json:"id"`
+	CustomerID  uuid.UUID     `json:"customer_id"`
+	Status      string        `json:"status"`
+	TotalAmount string        `json:"total_amount"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	WarehouseID uuid.NullUUID `json:"warehouse_id"`
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) error {
@@ -124,6 +179,7 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) error
 		arg.Status,
 		arg.TotalAmount,
 		arg.UpdatedAt,
+		arg.WarehouseID,
 	)
 	return err
 }
@@ -13,9 +13,12 @@ import (
 type Querier interface {
 	CreateOrder(ctx context.Context, arg CreateOrderParams) error
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error
+	CreateOrderSerial(ctx context.Context, arg CreateOrderSerialParams) error
 	DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) error
 	GetOrderByID(ctx context.Context, id uuid.UUID) (SalesOrder, error)
 	GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error)
+	ListOpenOrdersByItemID(ctx context.Context, arg ListOpenOrdersByItemIDParams) ([]SalesOrder, error)
+	ListOrderSerialsByOrderID(ctx context.Context, orderID uuid.UUID) ([]SalesOrderSerial, error)
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]SalesOrder, error)
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) error
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
@@ -37,9 +37,22 @@ func convertDBOrderToModel(dbOrder db.SalesOrder) model.SalesOrder {
 		order.TotalAmount = totalAmount
 	}
 
+	if dbOrder.WarehouseID.Valid {
+		warehouseID := dbOrder.WarehouseID.UUID
+		order.WarehouseID = &warehouseID
+	}
+
 	return order
 }
 
+// convertWarehouseIDToNullUUID converts an optional warehouse ID to uuid.NullUUID
+func convertWarehouseIDToNullUUID(warehouseID *uuid.UUID) uuid.NullUUID {
+	if warehouseID == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *warehouseID, Valid: true}
+}
+
 // convertModelOrderToCreateParams converts model.SalesOrder to sqlc CreateOrderParams
 func convertModelOrderToCreateParams(order model.SalesOrder) db.CreateOrderParams {
 	return db.CreateOrderParams{
@@ -49,6 +62,7 @@ func convertModelOrderToCreateParams(order model.SalesOrder) db.CreateOrderParam
 		TotalAmount: strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
 		CreatedAt:   order.CreatedAt,
 		UpdatedAt:   order.UpdatedAt,
+		WarehouseID: convertWarehouseIDToNullUUID(order.WarehouseID),
 	}
 }
 
@@ -60,6 +74,7 @@ func convertModelOrderToUpdateParams(order model.SalesOrder) db.UpdateOrderParam
 		Status:      string(order.Status),
 		TotalAmount: strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
 		UpdatedAt:   order.UpdatedAt,
+		WarehouseID: convertWarehouseIDToNullUUID(order.WarehouseID),
 	}
 }
 
@@ -72,6 +87,10 @@ func convertDBOrderItemToModel(dbItem db.OrderItem) model.OrderItem {
 		Quantity:  int(dbItem.Quantity),
 		CreatedAt: dbItem.CreatedAt,
 		UpdatedAt: dbItem.UpdatedAt,
+
+		Unit:             dbItem.Unit,
+		ConversionFactor: int(dbItem.ConversionFactor),
+		BaseQuantity:     int(dbItem.BaseQuantity),
 	}
 
 	if unitPrice, err := strconv.ParseFloat(dbItem.UnitPrice, 64); err == nil {
@@ -95,6 +114,10 @@ func convertModelOrderItemToCreateParams(item model.OrderItem) db.CreateOrderIte
 		Subtotal:  strconv.FormatFloat(item.Subtotal, 'f', 2, 64),
 		CreatedAt: item.CreatedAt,
 		UpdatedAt: item.UpdatedAt,
+
+		Unit:             item.Unit,
+		ConversionFactor: int32(item.ConversionFactor),
+		BaseQuantity:     int32(item.BaseQuantity),
 	}
 }
 
@@ -142,6 +165,32 @@ func (s *Storage) ListOrders(ctx context.Context, limit, offset int) ([]model.Sa
 	return orders, nil
 }
 
+// ListOpenOrdersByItemID lists the draft orders with a line for the item
+func (s *Storage) ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.SalesOrder, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	params := db.ListOpenOrdersByItemIDParams{
+		ItemID: id,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	dbOrders, err := s.queries.ListOpenOrdersByItemID(ctx, params)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	orders := make([]model.SalesOrder, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orders = append(orders, convertDBOrderToModel(dbOrder))
+	}
+
+	return orders, nil
+}
+
 func (s *Storage) UpdateOrder(ctx context.Context, order model.SalesOrder) error {
 	_, err := s.queries.GetOrderByID(ctx, order.ID)
 	if err == sql.ErrNoRows {
@@ -250,3 +299,71 @@ func (s *Storage) DeleteOrderItemsByOrderID(ctx context.Context, orderID string)
 
 	return nil
 }
+
+// ConfirmOrder confirms an order together with the serial numbers picked for it
+func (s *Storage) ConfirmOrder(ctx context.Context, id string, serials []model.OrderSerial) error {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ErrInternalServerError
+	}
+	defer tx.Rollback()
+
+	qtx := s.queries.WithTx(tx)
+
+	statusParams := db.UpdateOrderStatusParams{
+		ID:     orderID,
+		Status: string(model.OrderStatusConfirmed),
+	}
+	if err := qtx.UpdateOrderStatus(ctx, statusParams); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	for _, serial := range serials {
+		serialParams := db.CreateOrderSerialParams{
+			ID:           serial.ID,
+			OrderID:      serial.OrderID,
+			ItemID:       serial.ItemID,
+			SerialNumber: serial.SerialNumber,
+			CreatedAt:    serial.CreatedAt,
+		}
+		if err := qtx.CreateOrderSerial(ctx, serialParams); err != nil {
+			return errors.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *Storage) ListOrderSerialsByOrderID(ctx context.Context, orderID string) ([]model.OrderSerial, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	dbSerials, err := s.queries.ListOrderSerialsByOrderID(ctx, orderUUID)
+	if err != nil {
+		return nil, errors.ErrInternalServerError
+	}
+
+	serials := make([]model.OrderSerial, 0, len(dbSerials))
+	for _, dbSerial := range dbSerials {
+		serials = append(serials, model.OrderSerial{
+			ID:           dbSerial.ID,
+			OrderID:      dbSerial.OrderID,
+			ItemID:       dbSerial.ItemID,
+			SerialNumber: dbSerial.SerialNumber,
+			CreatedAt:    dbSerial.CreatedAt,
+		})
+	}
+
+	return serials, nil
+}
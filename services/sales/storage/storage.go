@@ -9,8 +9,12 @@ type Storage interface {
 	CreateOrder(ctx context.Context, order model.SalesOrder) error
 	GetOrderByID(ctx context.Context, id string) (model.SalesOrder, error)
 	ListOrders(ctx context.Context, limit, offset int) ([]model.SalesOrder, error)
+	ListOpenOrdersByItemID(ctx context.Context, itemID string, limit, offset int) ([]model.SalesOrder, error)
 	UpdateOrder(ctx context.Context, order model.SalesOrder) error
 	UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) error
+	ConfirmOrder(ctx context.Context, id string, serials []model.OrderSerial) error
+
+	ListOrderSerialsByOrderID(ctx context.Context, orderID string) ([]model.OrderSerial, error)
 
 	CreateOrderItem(ctx context.Context, item model.OrderItem) error
 	CreateOrderItems(ctx context.Context, items []model.OrderItem) error